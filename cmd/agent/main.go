@@ -73,10 +73,19 @@ type Application struct {
 	logger           *logrus.Logger
 	configureUseCase *usecases.ConfigureNetworkUseCase
 	deleteUseCase    *usecases.DeleteNetworkUseCase
+	verifyUseCase    *usecases.VerifyNetworkUseCase
 	healthServer     *http.Server
 	osType           interfaces.OSType
+
+	// 마지막으로 보고한 drift 목록과 시각 (서비스 모드 drift 보고용)
+	lastDrifts     string
+	lastReportedAt time.Time
 }
 
+// driftReportRefresh는 drift 목록이 그대로여도 보고를 다시 쓰는 주기입니다.
+// controller는 마지막 적용보다 오래된 보고를 무시하므로, 적용 후에도 결과가 전달되도록 갱신합니다.
+const driftReportRefresh = 10 * time.Minute
+
 // NewApplication은 새로운 Application을 생성합니다
 func NewApplication(container *container.Container, logger *logrus.Logger) *Application {
 	return &Application{
@@ -84,6 +93,7 @@ func NewApplication(container *container.Container, logger *logrus.Logger) *Appl
 		logger:           logger,
 		configureUseCase: container.GetConfigureNetworkUseCase(),
 		deleteUseCase:    container.GetDeleteNetworkUseCase(),
+		verifyUseCase:    container.GetVerifyNetworkUseCase(),
 	}
 }
 
@@ -151,6 +161,24 @@ func (a *Application) Run() error {
 			}
 			return nil
 		}
+		// 읽기 전용 검증: 상태만 수집해 종료 메시지로 보고 (드리프트가 있어도 정상 종료)
+		if action == constants.AgentActionVerify {
+			nodeName, err := resolveNodeName(a.logger)
+			if err != nil {
+				return err
+			}
+			summary, _, err := a.verifyNetwork(ctx, nodeName)
+			if err != nil {
+				a.logger.WithError(err).Error("Failed to verify network (job mode)")
+				return err
+			}
+			_ = os.WriteFile(constants.KubernetesTerminationLogPath, summary, constants.ConfigFilePermission)
+			return nil
+		}
+		// an action this agent does not know must not fall back to applying the config
+		if action != "" && !action.IsValid() {
+			return fmt.Errorf("unsupported AGENT_ACTION %q", action)
+		}
 		if err := a.processNetworkConfigurations(ctx); err != nil {
 			a.logger.WithError(err).Error("Failed to process network configurations (job mode)")
 			return err
@@ -171,10 +199,62 @@ func (a *Application) Run() error {
 		}
 		a.container.GetHealthService().UpdateDBHealth(true, nil)
 		metrics.SetDBConnectionStatus(true)
+		if reporter := a.container.GetDriftReporter(); reporter != nil {
+			a.reportDrift(ctx, reporter)
+		}
 		return nil
 	})
 }
 
+// verifyNetwork는 노드의 실제 상태를 spec과 비교하고 결과 요약(JSON)과 drift 목록(JSON)을 반환합니다.
+// 포맷: JSON {node, interfaces[], drifts[], timestamp} - controller는 drifts로 Drifted 여부를 판정
+func (a *Application) verifyNetwork(ctx context.Context, nodeName string) (summary, drifts []byte, err error) {
+	output, err := a.verifyUseCase.Execute(ctx, usecases.VerifyNetworkInput{NodeName: nodeName})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := logrus.Fields{"node": nodeName, "interfaces": len(output.Interfaces), "drifts": len(output.Drifts)}
+	if len(output.Drifts) > 0 {
+		a.logger.WithFields(fields).Warn("Network verification found drift")
+	} else {
+		a.logger.WithFields(fields).Info("Network verification passed")
+	}
+
+	if drifts, err = json.Marshal(output.Drifts); err != nil {
+		return nil, nil, err
+	}
+	summary, err = json.Marshal(map[string]any{
+		"node":       nodeName,
+		"interfaces": output.Interfaces,
+		"drifts":     output.Drifts,
+		"timestamp":  time.Now().Format(time.RFC3339),
+	})
+	return summary, drifts, err
+}
+
+// reportDrift는 서비스(DaemonSet) 모드에서 적용 후 검증 결과를 node config annotation으로 보고합니다.
+// drift 목록이 바뀌었거나 driftReportRefresh가 지났을 때만 기록해 매 폴링마다 CR을 갱신하지 않습니다.
+func (a *Application) reportDrift(ctx context.Context, reporter interfaces.DriftReporter) {
+	nodeName, err := resolveNodeName(a.logger)
+	if err != nil {
+		return
+	}
+	summary, drifts, err := a.verifyNetwork(ctx, nodeName)
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to verify network")
+		return
+	}
+	if string(drifts) == a.lastDrifts && time.Since(a.lastReportedAt) < driftReportRefresh {
+		return
+	}
+	if err := reporter.ReportDrift(ctx, nodeName, summary); err != nil {
+		a.logger.WithError(err).Warn("Failed to report drift")
+		return
+	}
+	a.lastDrifts, a.lastReportedAt = string(drifts), time.Now()
+}
+
 // startHealthServer는 헬스체크 서버를 시작합니다
 func (a *Application) startHealthServer(port string) error {
 	healthService := a.container.GetHealthService()
@@ -6,12 +6,19 @@ import (
     "net/http"
     "os"
     "os/signal"
+    "strconv"
+    "syscall"
     "time"
+    // maintenance window time zones must resolve in the alpine image without tzdata
+    _ "time/tzdata"
 
     "multinic-agent/internal/controller"
+    "multinic-agent/internal/domain/constants"
+    "multinic-agent/internal/webhook"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    "multinic-agent/pkg/generated/clientset/versioned"
 
     corev1 "k8s.io/api/core/v1"
-    "k8s.io/client-go/dynamic"
     "k8s.io/client-go/kubernetes"
     "k8s.io/client-go/rest"
     "k8s.io/client-go/tools/clientcmd"
@@ -20,11 +27,12 @@ import (
 )
 
 func main() {
-    ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+    // SIGTERM (pod deletion) cancels ctx as well, so the leader Lease is released on shutdown
+    ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
     defer cancel()
 
     // build kube clients (in-cluster first)
-    dyn, cli := buildClients()
+    mnc, cli := buildClients()
 
     ns := getenv("CONTROLLER_NAMESPACE", getenv("POD_NAMESPACE", "multinic-system"))
     agentImage := getenv("AGENT_IMAGE", "multinic-agent:latest")
@@ -34,15 +42,28 @@ func main() {
     mode := getenv("CONTROLLER_MODE", "watch")
     jobTTL := getenv("CONTROLLER_JOB_TTL", "600")
     jobDelDelay := getenv("CONTROLLER_JOB_DELETE_DELAY", "0")
+    // interface naming is shared with agent Jobs (passed through their env)
+    maxIfaces, _ := strconv.Atoi(getenv("MAX_INTERFACES", "0"))
+    constants.SetInterfaceNaming(getenv("INTERFACE_PREFIX", ""), maxIfaces)
 
     c := &controller.Controller{
-        Dyn:             dyn,
+        MultiNic:        mnc,
         Client:          cli,
         AgentImage:      agentImage,
         ImagePullPolicy: corev1.PullIfNotPresent,
         ServiceAccount:  saName,
         NodeCRNamespace: nodeCRNS,
+        NetworkOptions:  networkOptionsFromEnv(),
     }
+    // lifecycle Events on the CRs and Nodes (kubectl describe / get events)
+    recorder, stopEvents := controller.NewEventRecorder(cli)
+    defer stopEvents()
+    c.Recorder = recorder
+    c.Rollout = rolloutFromEnv()
+    // periodic drift check of Configured nodes (off unless CONTROLLER_RESYNC_INTERVAL is set)
+    c.ResyncInterval = durationEnv("CONTROLLER_RESYNC_INTERVAL", 0)
+    c.DriftSource = getenv("CONTROLLER_DRIFT_SOURCE", controller.DriftSourceJob)
+    c.RemediateDrift = getenv("CONTROLLER_DRIFT_REMEDIATION", "false") == "true"
     if secs, err := time.ParseDuration(jobTTL+"s"); err == nil {
         t := int32(secs / time.Second)
         c.JobTTLSeconds = &t
@@ -61,16 +82,50 @@ func main() {
         }
     }()
 
-    if mode == "watch" {
-        w := controller.NewWatcher(c, ns)
-        if err := w.Start(ctx); err != nil { log.Fatalf("watcher exited: %v", err) }
+    // CRD conversion (v1alpha1 <-> v1beta1) and spec validation webhooks; the API server calls
+    // them through the multinic-webhook Service, so they only run when the serving certificate is mounted
+    wport, _ := strconv.Atoi(getenv("WEBHOOK_PORT", "9443"))
+    whs := webhook.NewServer(wport, getenv("WEBHOOK_CERT_DIR", "/etc/multinic/webhook-certs"))
+    whs.Handle("/convert", webhook.NewConversionHandler())
+    whs.Handle("/validate", webhook.NewValidationHandler())
+    if whs.CertsAvailable() {
+        go func() {
+            if err := whs.Start(ctx); err != nil {
+                log.Printf("webhook server error: %v", err)
+            }
+        }()
     } else {
-        svc := &controller.Service{Controller: c, Namespace: ns, Interval: interval}
-        if err := svc.Start(ctx); err != nil { log.Fatalf("controller exited with error: %v", err) }
+        log.Printf("webhook certificate not found in %s; webhooks disabled", whs.CertDir)
     }
+
+    // only the Lease holder watches and reconciles; metrics and webhooks are served by every replica
+    hostname, _ := os.Hostname()
+    le := controller.LeaderElectionConfig{
+        Enabled:        getenv("LEADER_ELECTION_ENABLED", "true") == "true",
+        LeaseName:      getenv("LEADER_ELECTION_LEASE_NAME", "multinic-controller"),
+        LeaseNamespace: getenv("LEADER_ELECTION_NAMESPACE", ns),
+        Identity:       getenv("POD_NAME", hostname),
+        LeaseDuration:  durationEnv("LEADER_ELECTION_LEASE_DURATION", 15*time.Second),
+        RenewDeadline:  durationEnv("LEADER_ELECTION_RENEW_DEADLINE", 10*time.Second),
+        RetryPeriod:    durationEnv("LEADER_ELECTION_RETRY_PERIOD", 2*time.Second),
+    }
+    err := controller.RunWithLeaderElection(ctx, cli, le, func(ctx context.Context) {
+        if mode == "watch" {
+            w := controller.NewWatcher(c, ns)
+            if n, err := strconv.Atoi(getenv("CONTROLLER_WORKERS", "")); err == nil && n > 0 { w.Workers = n }
+            base := durationEnv("CONTROLLER_RETRY_BASE_DELAY", controller.DefaultRetryBaseDelay)
+            max := durationEnv("CONTROLLER_RETRY_MAX_DELAY", controller.DefaultRetryMaxDelay)
+            w.Queue = controller.NewWorkQueue(base, max)
+            if err := w.Start(ctx); err != nil { log.Fatalf("watcher exited: %v", err) }
+        } else {
+            svc := &controller.Service{Controller: c, Namespace: ns, Interval: interval}
+            if err := svc.Start(ctx); err != nil && ctx.Err() == nil { log.Fatalf("controller exited with error: %v", err) }
+        }
+    })
+    if err != nil { log.Fatalf("controller stopped: %v", err) }
 }
 
-func buildClients() (dynamic.Interface, kubernetes.Interface) {
+func buildClients() (versioned.Interface, kubernetes.Interface) {
     var cfg *rest.Config
     var err error
     if cfg, err = rest.InClusterConfig(); err != nil {
@@ -78,11 +133,58 @@ func buildClients() (dynamic.Interface, kubernetes.Interface) {
         cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
         if err != nil { log.Fatalf("kubeconfig error: %v", err) }
     }
-    dyn, err := dynamic.NewForConfig(cfg)
-    if err != nil { log.Fatalf("dynamic client error: %v", err) }
+    mnc, err := versioned.NewForConfig(cfg)
+    if err != nil { log.Fatalf("multinic client error: %v", err) }
     cli, err := kubernetes.NewForConfig(cfg)
     if err != nil { log.Fatalf("kube client error: %v", err) }
-    return dyn, cli
+    return mnc, cli
+}
+
+// networkOptionsFromEnv reads the agent NETWORK_* settings given to the controller so that
+// its Jobs run with the same options as the DaemonSet (unset or invalid values are left nil)
+func networkOptionsFromEnv() *multinicv1alpha1.NetworkOptions {
+    o := &multinicv1alpha1.NetworkOptions{}
+    set := false
+    boolEnv := func(k string) *bool {
+        v, err := strconv.ParseBool(os.Getenv(k))
+        if err != nil { return nil }
+        set = true
+        return &v
+    }
+    intEnv := func(k string) *int32 {
+        v, err := strconv.Atoi(os.Getenv(k))
+        if err != nil || v <= 0 { return nil }
+        set = true
+        n := int32(v)
+        return &n
+    }
+    o.PolicyRoutingEnabled = boolEnv("NETWORK_POLICY_ROUTING_ENABLED")
+    o.RoutingTableBase = intEnv("NETWORK_ROUTING_TABLE_BASE")
+    o.RouteMetric = intEnv("NETWORK_ROUTE_METRIC")
+    o.UseNoPrefixRoute = boolEnv("NETWORK_NOPREFIXROUTE")
+    o.SetArpSysctls = boolEnv("NETWORK_SET_ARP_SYSCTLS")
+    o.SetLooseRPFilter = boolEnv("NETWORK_SET_RP_FILTER_LOOSE")
+    if !set { return nil }
+    return o
+}
+
+// rolloutFromEnv reads the controller-wide rollout wave limits (nil when neither is set)
+func rolloutFromEnv() *multinicv1alpha1.RolloutPolicy {
+    maxNodes, _ := strconv.Atoi(os.Getenv("CONTROLLER_MAX_CONCURRENT_NODES"))
+    threshold, _ := strconv.Atoi(os.Getenv("CONTROLLER_ROLLOUT_FAILURE_THRESHOLD"))
+    if maxNodes <= 0 && threshold <= 0 { return nil }
+    r := &multinicv1alpha1.RolloutPolicy{}
+    if maxNodes > 0 { r.MaxConcurrentNodes = int32(maxNodes) }
+    if threshold > 0 { r.FailureThreshold = int32(threshold) }
+    log.Printf("rollout: maxConcurrentNodes=%d failureThreshold=%d", r.MaxConcurrentNodes, r.FailureThreshold)
+    return r
+}
+
+// durationEnv parses a Go duration ("500ms", "2m"); unset or invalid values keep def
+func durationEnv(k string, def time.Duration) time.Duration {
+    d, err := time.ParseDuration(os.Getenv(k))
+    if err != nil || d <= 0 { return def }
+    return d
 }
 
 func getenv(k, def string) string { v := os.Getenv(k); if v == "" { return def }; return v }
@@ -0,0 +1,31 @@
+#!/bin/bash
+
+# pkg/apis 타입으로부터 deepcopy, clientset, lister, informer 코드를 재생성한다
+# (k8s.io/code-generator 버전은 go.mod 의 client-go 와 맞춘다)
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+CODEGEN_VERSION=${CODEGEN_VERSION:-$(cd "${SCRIPT_ROOT}" && go list -m -f '{{.Version}}' k8s.io/client-go)}
+CODEGEN_PKG=${CODEGEN_PKG:-$(go env GOMODCACHE)/k8s.io/code-generator@${CODEGEN_VERSION}}
+
+if [ ! -d "${CODEGEN_PKG}" ]; then
+    go mod download "k8s.io/code-generator@${CODEGEN_VERSION}"
+fi
+
+source "${CODEGEN_PKG}/kube_codegen.sh"
+
+THIS_PKG="multinic-agent"
+
+kube::codegen::gen_helpers \
+    --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
+    "${SCRIPT_ROOT}/pkg/apis"
+
+kube::codegen::gen_client \
+    --with-watch \
+    --output-dir "${SCRIPT_ROOT}/pkg/generated" \
+    --output-pkg "${THIS_PKG}/pkg/generated" \
+    --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
+    "${SCRIPT_ROOT}/pkg/apis"
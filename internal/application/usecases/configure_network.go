@@ -268,16 +268,44 @@ func (uc *ConfigureNetworkUseCase) Execute(ctx context.Context, input ConfigureN
 // preflightCheck validates system state before any apply to avoid link flaps.
 // - Ensures MAC is present on the node
 // - Ensures target interface is not UP (dangerous to modify)
+// - For bonds, runs the same checks against every member port
 func (uc *ConfigureNetworkUseCase) preflightCheck(ctx context.Context, iface entities.NetworkInterface) error {
+    if iface.IsBond() {
+        return uc.preflightBondMembers(ctx, iface)
+    }
     // 1) MAC presence
     foundName, err := uc.namingService.FindInterfaceNameByMAC(iface.MacAddress())
     if err != nil || strings.TrimSpace(foundName) == "" {
         return errors.NewValidationError("preflight: MAC not present on system", err)
     }
     // multinic*는 재처리 허용
-    if strings.HasPrefix(foundName, "multinic") {
+    if domconst.IsManagedInterfaceName(foundName) {
         return nil
     }
+    return uc.preflightNotInUse(ctx, foundName)
+}
+
+// preflightBondMembers checks that every bond member exists and is either already enslaved
+// to a multinic bond (re-apply) or safe to take over.
+func (uc *ConfigureNetworkUseCase) preflightBondMembers(ctx context.Context, iface entities.NetworkInterface) error {
+    for _, mac := range iface.Bond().Members() {
+        port, master, err := uc.namingService.FindPortByMAC(mac)
+        if err != nil {
+            return errors.NewValidationError(fmt.Sprintf("preflight: bond member %s not present on system", mac), err)
+        }
+        // 이미 multinic bond에 소속된 포트는 재처리 허용
+        if domconst.IsManagedInterfaceName(master) {
+            continue
+        }
+        if err := uc.preflightNotInUse(ctx, port); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// preflightNotInUse blocks UP interfaces that show in-use signals.
+func (uc *ConfigureNetworkUseCase) preflightNotInUse(ctx context.Context, foundName string) error {
     // 2) UP인 경우만 추가 검사 (DOWN이면 안전)
     if !uc.isInterfaceUp(ctx, foundName) {
         return nil
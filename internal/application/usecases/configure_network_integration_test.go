@@ -11,6 +11,7 @@ import (
     "multinic-agent/internal/domain/entities"
     "multinic-agent/internal/domain/interfaces"
     "multinic-agent/internal/domain/services"
+    "multinic-agent/internal/infrastructure/adapters"
 
     "github.com/sirupsen/logrus"
     "github.com/stretchr/testify/require"
@@ -147,7 +148,7 @@ func TestConfigureNetwork_ConcurrencyCap(t *testing.T) {
     for _, it := range ifaces { macs = append(macs, it.MacAddress()) }
     ex := &stubExec{macs: macs}
     osd := &stubOS{}
-    naming := services.NewInterfaceNamingService(fs, ex)
+    naming := services.NewInterfaceNamingService(fs, ex, adapters.NewLinkLister(ex))
     logger := logrus.New(); logger.SetLevel(logrus.DebugLevel)
 
     uc := NewConfigureNetworkUseCaseWithDetector(
@@ -179,7 +180,7 @@ func TestConfigureNetwork_RetryEventuallySucceeds(t *testing.T) {
     fs := &stubFS{}
     ex := &stubExec{macs: []string{"02:00:00:00:00:01"}}
     osd := &stubOS{}
-    naming := services.NewInterfaceNamingService(fs, ex)
+    naming := services.NewInterfaceNamingService(fs, ex, adapters.NewLinkLister(ex))
     logger := logrus.New(); logger.SetLevel(logrus.DebugLevel)
 
     uc := NewConfigureNetworkUseCaseWithDetector(
@@ -12,6 +12,7 @@ import (
 	domainErrors "multinic-agent/internal/domain/errors"
 	"multinic-agent/internal/domain/interfaces"
 	"multinic-agent/internal/domain/services"
+	"multinic-agent/internal/infrastructure/adapters"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -99,7 +100,7 @@ func TestConfigureNetworkUseCase_FailureReasonRecorded(t *testing.T) {
     // ip -o link show -> include target MAC so preflight passes
     exec.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "ip", "-o", "link", "show").Return([]byte("2: eth0: <BROADCAST,MULTICAST> mtu 1500 state DOWN\\    link/ether 00:11:22:33:44:55 brd ff:ff:ff:ff:ff:ff"), nil).Maybe()
 
-    naming := services.NewInterfaceNamingService(fs, exec)
+    naming := services.NewInterfaceNamingService(fs, exec, adapters.NewLinkLister(exec))
     logger := logrus.New(); logger.SetLevel(logrus.FatalLevel)
     uc := NewConfigureNetworkUseCase(repo, configurer, rollbacker, naming, fs, osd, logger, 1)
     out, err := uc.Execute(context.Background(), ConfigureNetworkInput{NodeName: "node"})
@@ -503,7 +504,7 @@ func TestConfigureNetworkUseCase_Execute(t *testing.T) {
 			mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "ip", "link", "show", mock.AnythingOfType("string")).Return([]byte(""), fmt.Errorf("Device does not exist")).Maybe()
 
 			// 네이밍 서비스 생성
-			namingService := services.NewInterfaceNamingService(mockFS, mockExecutor)
+			namingService := services.NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 
 			// 로거 생성
 			logger := logrus.New()
@@ -616,7 +617,7 @@ func TestConfigureNetworkUseCase_processInterface(t *testing.T) {
 			mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "ip", "addr", "show", mock.AnythingOfType("string")).Return([]byte(""), nil).Maybe()
 
 			// 네이밍 서비스 생성
-			namingService := services.NewInterfaceNamingService(mockFS, mockExecutor)
+			namingService := services.NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 
 			// 로거 생성
 			logger := logrus.New()
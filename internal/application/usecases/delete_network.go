@@ -3,6 +3,8 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"multinic-agent/internal/domain/constants"
+	"multinic-agent/internal/domain/entities"
 	"multinic-agent/internal/domain/interfaces"
 	"multinic-agent/internal/domain/services"
 	"multinic-agent/internal/infrastructure/metrics"
@@ -81,15 +83,85 @@ func (uc *DeleteNetworkUseCase) Execute(ctx context.Context, input DeleteNetwork
 	}
 
 	// 일반 모드: DB 기반 고아 인터페이스 감지
+	var output *DeleteNetworkOutput
 	switch osType {
 	case interfaces.OSTypeUbuntu:
-		return uc.executeNetplanCleanup(ctx, input)
+		output, err = uc.executeNetplanCleanup(ctx, input)
 	case interfaces.OSTypeRHEL:
-		return uc.executeIfcfgCleanup(ctx, input)
+		output, err = uc.executeIfcfgCleanup(ctx, input)
 	default:
 		uc.logger.WithField("os_type", osType).Warn("Skipping orphaned interface cleanup for unsupported OS type")
 		return &DeleteNetworkOutput{}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// spec에서 제거된 VLAN 하위 인터페이스 정리
+	uc.cleanupOrphanedVLANs(ctx, output)
+	return output, nil
+}
+
+// cleanupOrphanedVLANs는 활성 인터페이스 위에 남아 있지만 spec에서 제거된 VLAN을 삭제합니다.
+// 부모 자체가 고아인 경우에는 Rollback이 하위 VLAN까지 정리하므로 여기서는 활성 부모만 확인합니다.
+func (uc *DeleteNetworkUseCase) cleanupOrphanedVLANs(ctx context.Context, output *DeleteNetworkOutput) {
+	vlanManager, ok := uc.rollbacker.(interfaces.VLANManager)
+	if !ok {
+		return
+	}
+
+	hostname, err := uc.namingService.GetHostname()
+	if err != nil {
+		uc.logger.WithError(err).Warn("Failed to get hostname for VLAN cleanup")
+		return
+	}
+	activeInterfaces, err := uc.repository.GetAllNodeInterfaces(ctx, hostname)
+	if err != nil {
+		uc.logger.WithError(err).Warn("Failed to get active interfaces for VLAN cleanup")
+		return
+	}
+	activeByMAC := make(map[string]entities.NetworkInterface, len(activeInterfaces))
+	for _, iface := range activeInterfaces {
+		activeByMAC[strings.ToLower(iface.MacAddress())] = iface
+	}
+
+	for _, parent := range uc.namingService.GetCurrentMultinicInterfaces() {
+		mac, err := uc.namingService.GetMacAddressForInterface(parent.String())
+		if err != nil {
+			continue
+		}
+		iface, ok := activeByMAC[strings.ToLower(mac)]
+		if !ok {
+			continue
+		}
+
+		desired := make(map[string]bool)
+		for _, vlan := range iface.VLANs() {
+			desired[vlan.Name(parent.String())] = true
+		}
+
+		links, err := vlanManager.ListVLANs(ctx, parent.String())
+		if err != nil {
+			uc.logger.WithError(err).WithField("interface_name", parent.String()).Warn("Failed to list VLAN sub-interfaces")
+			continue
+		}
+		for _, link := range links {
+			if desired[link] {
+				continue
+			}
+			uc.logger.WithFields(logrus.Fields{
+				"interface_name": parent.String(),
+				"vlan":           link,
+			}).Info("Found VLAN sub-interface removed from spec")
+			if err := vlanManager.RemoveVLAN(ctx, link); err != nil {
+				output.Errors = append(output.Errors, fmt.Errorf("failed to delete VLAN %s: %w", link, err))
+				continue
+			}
+			output.DeletedInterfaces = append(output.DeletedInterfaces, link)
+			output.TotalDeleted++
+			metrics.OrphanedInterfacesDeleted.Inc()
+		}
+	}
 }
 
 // executeNetplanCleanup은 Netplan (Ubuntu) 환경의 고아 인터페이스를 정리합니다
@@ -256,31 +328,21 @@ func (uc *DeleteNetworkUseCase) findOrphanedNetplanFiles(ctx context.Context) ([
 
 // isMultinicNetplanFile은 파일이 multinic 관련 netplan 파일인지 확인합니다
 func (uc *DeleteNetworkUseCase) isMultinicNetplanFile(fileName string) bool {
-	// 9*-multinic*.yaml 패턴 매칭
-	return strings.Contains(fileName, "multinic") && strings.HasSuffix(fileName, ".yaml") &&
-		strings.HasPrefix(fileName, "9") && strings.Contains(fileName, "-")
+	// 9*-<prefix>N.yaml 패턴 매칭 (인덱스 자릿수는 최대 개수 설정에 따라 달라진다)
+	name, ok := constants.ParseConfigFileName(fileName, ".yaml")
+	return ok && constants.IsManagedInterfaceName(name)
 }
 
 // extractInterfaceNameFromFile은 파일명에서 인터페이스 이름을 추출합니다
 func (uc *DeleteNetworkUseCase) extractInterfaceNameFromFile(fileName string) string {
 	// 예: "91-multinic1.yaml" -> "multinic1" 또는 "multinic1.yaml" -> "multinic1"
-	if !strings.Contains(fileName, "multinic") {
-		return ""
+	if name, ok := constants.ParseConfigFileName(fileName, ".yaml"); ok && constants.IsManagedInterfaceName(name) {
+		return name
 	}
 
-	// .yaml 확장자 제거
+	// 접두 숫자가 없는 경우 전체가 관리 이름인지 확인 (예: "multinic1")
 	nameWithoutExt := strings.TrimSuffix(fileName, ".yaml")
-
-	// "-"로 분할된 경우 (예: "91-multinic1")
-	parts := strings.Split(nameWithoutExt, "-")
-	for _, part := range parts {
-		if strings.HasPrefix(part, "multinic") {
-			return part
-		}
-	}
-
-	// 분할되지 않은 경우 전체가 multinic로 시작하는지 확인 (예: "multinic1")
-	if strings.HasPrefix(nameWithoutExt, "multinic") {
+	if constants.IsManagedInterfaceName(nameWithoutExt) {
 		return nameWithoutExt
 	}
 
@@ -309,8 +371,9 @@ func (uc *DeleteNetworkUseCase) deleteNetplanFile(ctx context.Context, fileName,
 
 // isMultinicIfcfgFile은 파일이 multinic 관련 ifcfg 파일인지 확인합니다
 func (uc *DeleteNetworkUseCase) isMultinicIfcfgFile(fileName string) bool {
-	// ifcfg-multinic* 패턴 매칭
-	return strings.HasPrefix(fileName, "ifcfg-multinic")
+	// ifcfg-<prefix>N 패턴 매칭
+	name, ok := strings.CutPrefix(fileName, "ifcfg-")
+	return ok && constants.IsManagedInterfaceName(name)
 }
 
 // extractInterfaceNameFromIfcfgFile은 ifcfg 파일명에서 인터페이스 이름을 추출합니다
@@ -433,6 +496,9 @@ func (uc *DeleteNetworkUseCase) getMACAddressFromNetplanFile(filePath string) (s
 					Macaddress string `yaml:"macaddress"`
 				} `yaml:"match"`
 			} `yaml:"ethernets"`
+			Bonds map[string]struct {
+				Macaddress string `yaml:"macaddress"`
+			} `yaml:"bonds"`
 		} `yaml:"network"`
 	}
 
@@ -441,6 +507,13 @@ func (uc *DeleteNetworkUseCase) getMACAddressFromNetplanFile(filePath string) (s
 		return "", fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	// bond 파일은 멤버 포트마다 ethernets 항목이 있으므로 CR의 MAC인 bond macaddress를 우선합니다
+	for _, bond := range config.Network.Bonds {
+		if bond.Macaddress != "" {
+			return bond.Macaddress, nil
+		}
+	}
+
 	// Extract MAC address from the first ethernet configuration
 	for _, eth := range config.Network.Ethernets {
 		if eth.Match.Macaddress != "" {
@@ -566,11 +639,11 @@ func (uc *DeleteNetworkUseCase) executeFullIfcfgCleanup(ctx context.Context, inp
 }
 
 // cleanupMultinicInterfaceNames는 남아있는 multinicX 인터페이스를 안전하게 이름 해제합니다.
-// - 대상: 이름 패턴이 multinic0~9 이고, 인터페이스가 DOWN 상태인 경우만
+// - 대상: 이름 패턴이 관리 이름(multinic0~N) 이고, 인터페이스가 DOWN 상태인 경우만
 // - 방법: altname(ens*/enp*)가 있으면 altname으로 rename 시도, 없으면 건너뜀
 func (uc *DeleteNetworkUseCase) cleanupMultinicInterfaceNames(ctx context.Context) {
-	for i := 0; i < 10; i++ {
-		name := fmt.Sprintf("multinic%d", i)
+	for i := 0; i < constants.MaxInterfaces(); i++ {
+		name := constants.InterfaceName(i)
 		if !uc.namingService.InterfaceExists(name) {
 			continue
 		}
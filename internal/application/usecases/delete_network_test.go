@@ -8,6 +8,7 @@ import (
 	"multinic-agent/internal/domain/entities"
 	"multinic-agent/internal/domain/interfaces"
 	"multinic-agent/internal/domain/services"
+	"multinic-agent/internal/infrastructure/adapters"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -252,7 +253,7 @@ ONBOOT=yes`), nil)
 			}
 
 			// 네이밍 서비스 생성
-			namingService := services.NewInterfaceNamingService(mockFS, mockExecutor)
+			namingService := services.NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 
 			// 로거 생성
 			logger := logrus.New()
@@ -368,4 +369,62 @@ func TestDeleteNetworkUseCase_OrphanDetection(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// MockVLANRollbacker는 VLANManager까지 구현하는 롤백 mock입니다
+type MockVLANRollbacker struct {
+	MockNetworkRollbacker
+}
+
+func (m *MockVLANRollbacker) ListVLANs(ctx context.Context, parent string) ([]string, error) {
+	args := m.Called(ctx, parent)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockVLANRollbacker) RemoveVLAN(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func TestDeleteNetworkUseCase_RemovesVLANsDroppedFromSpec(t *testing.T) {
+	mockOSDetector := new(MockOSDetector)
+	mockRollbacker := new(MockVLANRollbacker)
+	mockFS := new(MockFileSystem)
+	mockExecutor := new(MockCommandExecutor)
+	mockRepo := new(MockNetworkInterfaceRepository)
+
+	mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "test", "-d", "/host").Return([]byte{}, fmt.Errorf("not found"))
+	mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "hostname").Return([]byte("test-node\n"), nil)
+	mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "ip", "addr", "show", "multinic0").
+		Return([]byte("2: multinic0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500\n    link/ether 00:11:22:33:44:00 brd ff:ff:ff:ff:ff:ff"), nil)
+	mockOSDetector.On("DetectOS").Return(interfaces.OSTypeUbuntu, nil)
+
+	// spec에는 VLAN 100만 남아 있음
+	iface := createTestInterface(0, "test-node", "00:11:22:33:44:00", "10.10.10.10", "10.10.10.0/24", 1500)
+	require.NoError(t, iface.AddVLAN(100, "", "192.168.100.10", "192.168.100.0/24", 0))
+	mockRepo.On("GetAllNodeInterfaces", mock.Anything, "test-node").Return([]entities.NetworkInterface{*iface}, nil)
+
+	mockFS.On("ListFiles", "/etc/netplan").Return([]string{"90-multinic0.yaml"}, nil)
+	mockFS.On("ReadFile", "/etc/netplan/90-multinic0.yaml").Return([]byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: "00:11:22:33:44:00"`), nil)
+	mockFS.On("Exists", "/sys/class/net/multinic0").Return(true)
+	mockFS.On("Exists", mock.Anything).Return(false)
+
+	mockRollbacker.On("ListVLANs", mock.Anything, "multinic0").Return([]string{"multinic0.100", "multinic0.200"}, nil)
+	mockRollbacker.On("RemoveVLAN", mock.Anything, "multinic0.200").Return(nil).Once()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+	namingService := services.NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
+	useCase := NewDeleteNetworkUseCase(mockOSDetector, mockRollbacker, namingService, mockRepo, mockFS, logger)
+
+	result, err := useCase.Execute(context.Background(), DeleteNetworkInput{NodeName: "test-node"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"multinic0.200"}, result.DeletedInterfaces)
+	assert.Equal(t, 1, result.TotalDeleted)
+	mockRollbacker.AssertExpectations(t)
+	mockRollbacker.AssertNotCalled(t, "Rollback", mock.Anything, mock.Anything)
+}
@@ -10,6 +10,7 @@ import (
     "multinic-agent/internal/domain/entities"
     "multinic-agent/internal/domain/interfaces"
     "multinic-agent/internal/domain/services"
+    "multinic-agent/internal/infrastructure/adapters"
 
     "github.com/sirupsen/logrus"
     "github.com/stretchr/testify/require"
@@ -39,6 +40,10 @@ func (pfExec) Execute(ctx context.Context, cmd string, args ...string) ([]byte,
 func (pfExec) ExecuteWithTimeout(ctx context.Context, _ time.Duration, cmd string, args ...string) ([]byte, error) {
     if cmd == "test" && len(args) >= 2 && args[0] == "-d" && args[1] == "/host" { return []byte{}, fmt.Errorf("not in container") }
     if cmd == "ip" {
+        if len(args) >= 5 && args[0] == "-o" && args[1] == "-4" && args[2] == "addr" && args[len(args)-1] == "eth0" {
+            // eth0 carries the node IP → UP and in use
+            return []byte("2: eth0    inet 192.168.0.10/24 brd 192.168.0.255 scope global eth0"), nil
+        }
         if len(args) >= 3 && args[0] == "addr" && args[1] == "show" { return []byte(""), fmt.Errorf("Device does not exist") }
         if len(args) >= 3 && args[0] == "link" && args[1] == "show" { return []byte("state UP"), nil }
         if len(args) >= 3 && args[0] == "-o" && args[1] == "link" && args[2] == "show" {
@@ -70,7 +75,7 @@ func TestPreflight_BlockIfUP_Option(t *testing.T) {
     osd := pfOS{}
     cfg := pfCfg{}
     rb := pfRB{}
-    naming := services.NewInterfaceNamingService(fs, ex)
+    naming := services.NewInterfaceNamingService(fs, ex, adapters.NewLinkLister(ex))
     logger := logrus.New(); logger.SetLevel(logrus.ErrorLevel)
 
     uc := NewConfigureNetworkUseCaseWithDetector(
@@ -87,3 +92,47 @@ func TestPreflight_BlockIfUP_Option(t *testing.T) {
     require.NotNil(t, out)
     require.Equal(t, 1, out.FailedCount)
 }
+
+// pfBondExec reports a bond whose first member is already enslaved to multinic0 and whose
+// second member is still a free, DOWN port.
+type pfBondExec struct{ pfExec }
+func (e pfBondExec) Execute(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+    return e.ExecuteWithTimeout(ctx, time.Second, cmd, args...)
+}
+func (e pfBondExec) ExecuteWithTimeout(ctx context.Context, d time.Duration, cmd string, args ...string) ([]byte, error) {
+    if cmd == "ip" && len(args) >= 3 && args[0] == "-o" && args[1] == "link" && args[2] == "show" {
+        return []byte("3: eth1: <BROADCAST,MULTICAST,SLAVE,UP> mtu 1500 master multinic0 state UP    link/ether 02:00:00:00:00:11 brd ff:ff:ff:ff:ff:ff\n" +
+            "4: eth2: <BROADCAST,MULTICAST> mtu 1500 state DOWN    link/ether 02:00:00:00:00:12 brd ff:ff:ff:ff:ff:ff\n" +
+            "5: multinic0: <BROADCAST,MULTICAST,MASTER,UP> mtu 1500 state UP    link/ether 02:00:00:00:00:11 brd ff:ff:ff:ff:ff:ff"), nil
+    }
+    if cmd == "ip" && len(args) >= 3 && args[0] == "link" && args[1] == "show" {
+        return []byte("state DOWN"), nil
+    }
+    return e.pfExec.ExecuteWithTimeout(ctx, d, cmd, args...)
+}
+
+func TestPreflight_BondMembers(t *testing.T) {
+    ni, err := entities.NewNetworkInterface(1, "02:00:00:00:00:11", "node", "10.0.0.2", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    require.NoError(t, ni.SetBond([]string{"02:00:00:00:00:11", "02:00:00:00:00:12"}, "", 0, ""))
+
+    fs := pfFS{}
+    logger := logrus.New(); logger.SetLevel(logrus.ErrorLevel)
+    naming := services.NewInterfaceNamingService(fs, pfBondExec{}, adapters.NewLinkLister(pfBondExec{}))
+    uc := NewConfigureNetworkUseCaseWithDetector(
+        &pfRepo{ifaces: []entities.NetworkInterface{*ni}}, pfCfg{}, pfRB{}, naming, fs, pfOS{}, logger,
+        1,
+        services.NewDriftDetector(fs, logger, naming),
+        time.Second,
+        0,
+        2.0,
+    )
+
+    require.NoError(t, uc.preflightCheck(context.Background(), *ni))
+
+    // a member that is not on the node blocks the whole bond
+    missing, err := entities.NewNetworkInterface(2, "02:00:00:00:00:11", "node", "10.0.0.3", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    require.NoError(t, missing.SetBond([]string{"02:00:00:00:00:11", "02:00:00:00:00:99"}, "", 0, ""))
+    require.Error(t, uc.preflightCheck(context.Background(), *missing))
+}
@@ -2,9 +2,9 @@ package usecases
 
 import (
     "context"
-    "fmt"
     "path/filepath"
 
+    "multinic-agent/internal/domain/constants"
     "multinic-agent/internal/domain/entities"
     "multinic-agent/internal/domain/interfaces"
 )
@@ -35,7 +35,7 @@ func (uc *ConfigureNetworkUseCase) checkNetplanNeedProcessing(ctx context.Contex
     configPath := uc.driftDetector.FindNetplanFileForInterface(uc.configurer.GetConfigDir(), interfaceName.String())
     if configPath == "" {
         // 파일이 없으면 새로 생성할 경로 설정
-        configPath = filepath.Join(uc.configurer.GetConfigDir(), fmt.Sprintf("9%d-%s.yaml", interfaceName.Index(), interfaceName.String()))
+        configPath = filepath.Join(uc.configurer.GetConfigDir(), constants.ConfigFileName(interfaceName.Index(), interfaceName.String(), ".yaml"))
     }
 
     // 파일이 존재하지 않거나, 드리프트가 발생했거나, 아직 설정되지 않은 경우 처리
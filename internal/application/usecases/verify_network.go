@@ -0,0 +1,280 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"multinic-agent/internal/domain/constants"
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+	"multinic-agent/internal/domain/interfaces"
+	"multinic-agent/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 검증 결과로 보고하는 링크 상태
+const (
+	VerifyStateUp        = "Up"
+	VerifyStateNoCarrier = "NoCarrier"
+	VerifyStateDown      = "Down"
+	VerifyStateNotFound  = "NotFound"
+	VerifyStateUnknown   = "Unknown"
+)
+
+// VerifyNetworkInput은 네트워크 검증 유스케이스의 입력 데이터입니다
+type VerifyNetworkInput struct {
+	NodeName string
+}
+
+// VerifyNetworkOutput은 네트워크 검증 유스케이스의 출력 데이터입니다.
+// Drifts가 비어 있으면 노드가 spec과 일치합니다.
+type VerifyNetworkOutput struct {
+	Interfaces []InterfaceVerification `json:"interfaces"`
+	Drifts     []InterfaceDrift        `json:"drifts"`
+}
+
+// InterfaceVerification은 인터페이스 하나의 실제 상태입니다.
+// Rules/Routes/ConfigFile은 로그에만 남깁니다 (termination message는 4KiB로 제한됨).
+type InterfaceVerification struct {
+	ID            int      `json:"id"`
+	MAC           string   `json:"mac"`
+	Name          string   `json:"name,omitempty"`
+	State         string   `json:"state"`
+	MTU           int      `json:"mtu,omitempty"`
+	Addresses     []string `json:"addresses,omitempty"`
+	Rules         []string `json:"-"`
+	Routes        []string `json:"-"`
+	ConfigFile    string   `json:"-"`
+	ConfigPresent bool     `json:"configPresent"`
+	ConfigDrift   bool     `json:"configDrift"`
+}
+
+// InterfaceDrift는 spec과 다른 인터페이스와 그 이유입니다 (controller drift report와 같은 형식)
+type InterfaceDrift struct {
+	ID     int    `json:"id"`
+	MAC    string `json:"mac"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// VerifyNetworkUseCase는 노드 설정을 변경하지 않고 실제 상태를 spec과 비교하는 유스케이스입니다
+type VerifyNetworkUseCase struct {
+	repository    interfaces.NetworkInterfaceRepository
+	inspector     interfaces.NetworkInspector
+	configurer    interfaces.NetworkConfigurer
+	namingService *services.InterfaceNamingService
+	driftDetector *services.DriftDetector
+	fileSystem    interfaces.FileSystem
+	osDetector    interfaces.OSDetector
+	logger        *logrus.Logger
+}
+
+// NewVerifyNetworkUseCase는 새로운 VerifyNetworkUseCase를 생성합니다
+func NewVerifyNetworkUseCase(
+	repo interfaces.NetworkInterfaceRepository,
+	inspector interfaces.NetworkInspector,
+	configurer interfaces.NetworkConfigurer,
+	naming *services.InterfaceNamingService,
+	drift *services.DriftDetector,
+	fs interfaces.FileSystem,
+	osDetector interfaces.OSDetector,
+	logger *logrus.Logger,
+) *VerifyNetworkUseCase {
+	return &VerifyNetworkUseCase{
+		repository:    repo,
+		inspector:     inspector,
+		configurer:    configurer,
+		namingService: naming,
+		driftDetector: drift,
+		fileSystem:    fs,
+		osDetector:    osDetector,
+		logger:        logger,
+	}
+}
+
+// Execute는 노드의 모든 인터페이스를 검사하고 spec과 다른 항목을 Drifts로 반환합니다.
+// 드리프트는 에러가 아니며, 설정 조회 실패처럼 검사 자체를 할 수 없을 때만 에러를 반환합니다.
+func (uc *VerifyNetworkUseCase) Execute(ctx context.Context, input VerifyNetworkInput) (*VerifyNetworkOutput, error) {
+	osType, err := uc.osDetector.DetectOS()
+	if err != nil {
+		return nil, errors.NewSystemError("failed to detect OS type", err)
+	}
+
+	allInterfaces, err := uc.repository.GetAllNodeInterfaces(ctx, input.NodeName)
+	if err != nil {
+		return nil, errors.NewSystemError("failed to get node interfaces", err)
+	}
+
+	// 적용 시와 같은 이름을 얻기 위해 동일하게 사전 배정 (메모리 예약만 하며 시스템은 바꾸지 않음)
+	if _, err := uc.namingService.ReserveNamesForInterfaces(allInterfaces); err != nil {
+		uc.logger.WithError(err).Warn("Failed to reserve names for interfaces - proceeding without preallocation")
+	}
+
+	output := &VerifyNetworkOutput{Interfaces: []InterfaceVerification{}, Drifts: []InterfaceDrift{}}
+	for _, iface := range allInterfaces {
+		v, reasons := uc.verifyInterface(ctx, iface, osType)
+		output.Interfaces = append(output.Interfaces, v)
+
+		fields := logrus.Fields{
+			"interface_id": v.ID,
+			"mac_address":  v.MAC,
+			"interface":    v.Name,
+			"state":        v.State,
+			"mtu":          v.MTU,
+			"addresses":    v.Addresses,
+			"rules":        v.Rules,
+			"routes":       v.Routes,
+			"config_file":  v.ConfigFile,
+		}
+		if len(reasons) == 0 {
+			uc.logger.WithFields(fields).Debug("Interface matches spec")
+			continue
+		}
+		drift := InterfaceDrift{ID: v.ID, MAC: v.MAC, Name: v.Name, Reason: strings.Join(reasons, ", ")}
+		output.Drifts = append(output.Drifts, drift)
+		uc.logger.WithFields(fields).WithField("reason", drift.Reason).Warn("Interface drifted from spec")
+	}
+	return output, nil
+}
+
+// verifyInterface는 인터페이스 하나의 실제 상태와 spec과 다른 이유 목록을 반환합니다
+func (uc *VerifyNetworkUseCase) verifyInterface(ctx context.Context, iface entities.NetworkInterface, osType interfaces.OSType) (InterfaceVerification, []string) {
+	v := InterfaceVerification{ID: iface.ID(), MAC: iface.MacAddress(), State: VerifyStateNotFound}
+
+	name, err := uc.namingService.GenerateNextNameForMAC(iface.MacAddress())
+	if err != nil {
+		return v, []string{fmt.Sprintf("no interface name: %v", err)}
+	}
+	v.Name = name.String()
+	if !uc.namingService.InterfaceExists(v.Name) {
+		// 포트는 있지만 multinic 이름으로 바뀌지 않은 경우를 구분해 보고
+		if found, err := uc.namingService.FindInterfaceNameByMAC(iface.MacAddress()); err == nil && found != "" {
+			return v, []string{fmt.Sprintf("interface is %s, not renamed to %s", found, v.Name)}
+		}
+		return v, []string{"interface not found"}
+	}
+
+	var reasons []string
+	state, err := uc.inspector.Inspect(ctx, iface, v.Name)
+	if err != nil {
+		// 읽기 실패는 드리프트로 보지 않음 (다음 검증에서 다시 확인)
+		uc.logger.WithError(err).WithField("interface", v.Name).Warn("Failed to inspect interface state")
+		v.State = VerifyStateUnknown
+	} else {
+		v.MTU, v.Addresses, v.Rules, v.Routes = state.MTU, state.Addresses, state.Rules, state.Routes
+		reasons = append(reasons, runtimeDrift(&v, iface, state)...)
+	}
+
+	v.ConfigFile, v.ConfigPresent, v.ConfigDrift = uc.checkConfigFile(iface, *name, osType)
+	switch {
+	case !v.ConfigPresent:
+		reasons = append(reasons, "config file missing")
+	case v.ConfigDrift:
+		reasons = append(reasons, "config file differs from spec")
+	}
+	return v, reasons
+}
+
+// runtimeDrift는 링크 상태와 MTU, 정적 주소, 정책 라우팅 rule/route를 spec과 비교합니다
+func runtimeDrift(v *InterfaceVerification, iface entities.NetworkInterface, state *interfaces.InterfaceState) []string {
+	var reasons []string
+	switch {
+	case state.Up && state.LowerUp:
+		v.State = VerifyStateUp
+	case state.Up:
+		v.State = VerifyStateNoCarrier
+		reasons = append(reasons, "no carrier")
+	default:
+		v.State = VerifyStateDown
+		reasons = append(reasons, "link down")
+	}
+	if iface.MTU() > 0 && state.MTU != iface.MTU() {
+		reasons = append(reasons, fmt.Sprintf("mtu %d, expected %d", state.MTU, iface.MTU()))
+	}
+
+	// DHCP 패밀리는 spec에 주소가 없으므로 정적 주소만 비교됨
+	addrs := iface.Addresses()
+	for _, a := range addrs {
+		if !containsString(state.Addresses, a.WithPrefix()) {
+			reasons = append(reasons, fmt.Sprintf("address %s missing", a.WithPrefix()))
+		}
+	}
+	if state.PolicyTable == 0 {
+		return reasons
+	}
+	// VRF는 l3mdev rule로 격리되므로 source rule은 정책 라우팅 모드에서만 확인
+	if iface.VRF() == nil {
+		for _, a := range addrs {
+			if !hasSourceRule(state.Rules, a.Address()) {
+				reasons = append(reasons, fmt.Sprintf("rule from %s table %d missing", a.Address(), state.PolicyTable))
+			}
+		}
+	}
+	seen := map[string]bool{}
+	for _, a := range addrs {
+		if seen[a.Network()] {
+			continue
+		}
+		seen[a.Network()] = true
+		if !hasRouteTo(state.Routes, a.Network()) {
+			reasons = append(reasons, fmt.Sprintf("route %s table %d missing", a.Network(), state.PolicyTable))
+		}
+	}
+	return reasons
+}
+
+// checkConfigFile은 영속 설정 파일의 경로, 존재 여부, 내용 드리프트를 반환합니다.
+// 파일 위치는 checkNeedProcessing과 같은 규칙으로 찾지만, 링크 상태는 runtimeDrift가 따로 보므로 내용만 비교합니다.
+func (uc *VerifyNetworkUseCase) checkConfigFile(iface entities.NetworkInterface, name entities.InterfaceName, osType interfaces.OSType) (string, bool, bool) {
+	dir := uc.configurer.GetConfigDir()
+	if osType == interfaces.OSTypeRHEL {
+		path := uc.driftDetector.FindIfcfgFile(dir, name.String())
+		if path == "" {
+			return "", false, false
+		}
+		return path, true, uc.driftDetector.IsIfcfgFileDrift(iface, path)
+	}
+	path := uc.driftDetector.FindNetplanFileForInterface(dir, name.String())
+	if path == "" {
+		path = filepath.Join(dir, constants.ConfigFileName(name.Index(), name.String(), ".yaml"))
+	}
+	if !uc.fileSystem.Exists(path) {
+		return path, false, false
+	}
+	return path, true, uc.driftDetector.IsNetplanFileDrift(iface, path)
+}
+
+// hasSourceRule은 "from <addr> lookup <table>" rule이 있는지 확인합니다 (/32, /128은 출력에서 생략됨)
+func hasSourceRule(rules []string, addr string) bool {
+	for _, rule := range rules {
+		fields := strings.Fields(rule)
+		for i := 0; i+1 < len(fields); i++ {
+			if fields[i] == "from" && strings.SplitN(fields[i+1], "/", 2)[0] == addr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasRouteTo는 table에 network 목적지 route가 있는지 확인합니다
+func hasRouteTo(routes []string, network string) bool {
+	for _, route := range routes {
+		if fields := strings.Fields(route); len(fields) > 0 && fields[0] == network {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/interfaces"
+	"multinic-agent/internal/domain/services"
+	"multinic-agent/internal/infrastructure/adapters"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vfFS holds the /sys/class/net entries and netplan files of the verify test node
+type vfFS struct{ files map[string][]byte }
+
+func (f vfFS) ReadFile(path string) ([]byte, error) {
+	if b, ok := f.files[path]; ok {
+		return b, nil
+	}
+	return nil, os.ErrNotExist
+}
+func (f vfFS) WriteFile(string, []byte, os.FileMode) error {
+	return fmt.Errorf("verify must not write")
+}
+func (f vfFS) Exists(path string) bool            { _, ok := f.files[path]; return ok }
+func (f vfFS) MkdirAll(string, os.FileMode) error { return nil }
+func (f vfFS) Remove(string) error                { return fmt.Errorf("verify must not remove") }
+func (f vfFS) ListFiles(dir string) ([]string, error) {
+	var names []string
+	for path := range f.files {
+		if strings.HasPrefix(path, dir+"/") {
+			names = append(names, strings.TrimPrefix(path, dir+"/"))
+		}
+	}
+	return names, nil
+}
+
+// vfExec reports multinic0 and multinic2 already renamed and a third port still named eth1
+type vfExec struct{}
+
+func (vfExec) Execute(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+	return vfExec{}.ExecuteWithTimeout(ctx, time.Second, cmd, args...)
+}
+func (vfExec) ExecuteWithTimeout(_ context.Context, _ time.Duration, cmd string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{cmd}, args...), " ")
+	switch line {
+	case "ip -o link show":
+		return []byte("3: multinic0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 state UP    link/ether 02:00:00:00:00:01 brd ff:ff:ff:ff:ff:ff\n" +
+			"4: eth1: <BROADCAST,MULTICAST> mtu 1500 state DOWN    link/ether 02:00:00:00:00:02 brd ff:ff:ff:ff:ff:ff\n" +
+			"5: multinic2: <BROADCAST,MULTICAST,UP> mtu 1500 state DOWN    link/ether 02:00:00:00:00:03 brd ff:ff:ff:ff:ff:ff"), nil
+	case "ip addr show multinic0":
+		return []byte("3: multinic0: <UP>    link/ether 02:00:00:00:00:01 brd ff:ff:ff:ff:ff:ff"), nil
+	case "ip addr show multinic2":
+		return []byte("5: multinic2: <UP>    link/ether 02:00:00:00:00:03 brd ff:ff:ff:ff:ff:ff"), nil
+	}
+	return nil, fmt.Errorf("unexpected command: %s", line)
+}
+
+// vfInspector returns canned runtime states by interface name
+type vfInspector map[string]*interfaces.InterfaceState
+
+func (i vfInspector) Inspect(_ context.Context, _ entities.NetworkInterface, name string) (*interfaces.InterfaceState, error) {
+	if st, ok := i[name]; ok {
+		return st, nil
+	}
+	return nil, fmt.Errorf("no state for %s", name)
+}
+
+func TestVerifyNetworkUseCase_ReportsDriftPerInterface(t *testing.T) {
+	var ifaces []entities.NetworkInterface
+	for i, spec := range []struct{ mac, ip string }{
+		{"02:00:00:00:00:01", "10.0.0.11"},
+		{"02:00:00:00:00:02", "10.0.0.12"},
+		{"02:00:00:00:00:03", "10.0.0.13"},
+	} {
+		ni, err := entities.NewNetworkInterface(i+1, spec.mac, "node", spec.ip, "10.0.0.0/24", 1500)
+		require.NoError(t, err)
+		ifaces = append(ifaces, *ni)
+	}
+
+	fs := vfFS{files: map[string][]byte{
+		"/sys/class/net/multinic0": nil,
+		"/sys/class/net/multinic2": nil,
+		"/etc/netplan/91-multinic0.yaml": []byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: "02:00:00:00:00:01"
+      dhcp4: false
+      addresses: ["10.0.0.11/24"]
+      mtu: 1500
+`),
+	}}
+	inspector := vfInspector{
+		"multinic0": {Up: true, LowerUp: true, MTU: 1500, Addresses: []string{"10.0.0.11/24"}, PolicyTable: 101,
+			Rules:  []string{"32765:\tfrom 10.0.0.11 lookup 101"},
+			Routes: []string{"10.0.0.0/24 dev multinic0 scope link src 10.0.0.11 metric 101"}},
+		"multinic2": {Up: true, MTU: 1500, Addresses: []string{"10.0.0.13/24"}, PolicyTable: 103,
+			Routes: []string{"10.0.0.0/24 dev multinic2 scope link src 10.0.0.13 metric 103"}},
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	naming := services.NewInterfaceNamingService(fs, vfExec{}, adapters.NewLinkLister(vfExec{}))
+	uc := NewVerifyNetworkUseCase(&pfRepo{ifaces: ifaces}, inspector, pfCfg{}, naming,
+		services.NewDriftDetector(fs, logger, naming), fs, pfOS{}, logger)
+
+	out, err := uc.Execute(context.Background(), VerifyNetworkInput{NodeName: "node"})
+	require.NoError(t, err)
+	require.Len(t, out.Interfaces, 3)
+
+	assert.Equal(t, VerifyStateUp, out.Interfaces[0].State)
+	assert.True(t, out.Interfaces[0].ConfigPresent)
+	assert.False(t, out.Interfaces[0].ConfigDrift)
+	assert.Equal(t, VerifyStateNotFound, out.Interfaces[1].State)
+	assert.Equal(t, VerifyStateNoCarrier, out.Interfaces[2].State)
+
+	require.Len(t, out.Drifts, 2, "the matching interface must not be reported: %+v", out.Drifts)
+	assert.Equal(t, InterfaceDrift{ID: 2, MAC: "02:00:00:00:00:02", Name: "multinic1", Reason: "interface is eth1, not renamed to multinic1"}, out.Drifts[0])
+	assert.Equal(t, "multinic2", out.Drifts[1].Name)
+	assert.Equal(t, "no carrier, rule from 10.0.0.13 table 103 missing, config file missing", out.Drifts[1].Reason)
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+    "fmt"
+    "net"
+    "sort"
+    "strings"
+
+    domainerrors "multinic-agent/internal/domain/errors"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    "k8s.io/client-go/tools/cache"
+)
+
+const (
+    macIndex = "multinic.io/mac"
+    ipIndex  = "multinic.io/ip"
+
+    // ConditionConflict is set while another MultiNicNodeConfig claims the same MAC or IP
+    ConditionConflict = "Conflict"
+)
+
+// ConflictIndexers returns the informer indexers used by ConflictIndex (MAC -> CR, IP -> CR)
+func ConflictIndexers() cache.Indexers {
+    return cache.Indexers{
+        macIndex: func(obj interface{}) ([]string, error) {
+            cr, ok := obj.(*multinicv1alpha1.MultiNicNodeConfig)
+            if !ok { return nil, nil }
+            return claimedMACs(cr), nil
+        },
+        ipIndex: func(obj interface{}) ([]string, error) {
+            cr, ok := obj.(*multinicv1alpha1.MultiNicNodeConfig)
+            if !ok { return nil, nil }
+            return claimedIPs(cr), nil
+        },
+    }
+}
+
+// ConflictIndex finds MultiNicNodeConfigs that claim a MAC or static IP already claimed by
+// another CR. The older CR (creationTimestamp, then name) keeps the claim, so a MGMT bug that
+// reuses an address blocks only the newcomer instead of re-applying the node that owns it.
+type ConflictIndex struct {
+    indexer cache.Indexer
+    synced  func() bool
+}
+
+// NewConflictIndex wraps an informer indexer that has ConflictIndexers registered
+func NewConflictIndex(indexer cache.Indexer, synced func() bool) *ConflictIndex {
+    return &ConflictIndex{indexer: indexer, synced: synced}
+}
+
+// NewConflictIndexFromList builds a point-in-time index (poll mode)
+func NewConflictIndexFromList(items []multinicv1alpha1.MultiNicNodeConfig) *ConflictIndex {
+    indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, ConflictIndexers())
+    for i := range items {
+        _ = indexer.Add(&items[i])
+    }
+    return &ConflictIndex{indexer: indexer}
+}
+
+// Synced reports whether the index holds every CR (always true for a list-built index)
+func (x *ConflictIndex) Synced() bool {
+    return x.synced == nil || x.synced()
+}
+
+// Check returns a CONFLICT error describing the claims cr loses to older CRs, or nil
+func (x *ConflictIndex) Check(cr *multinicv1alpha1.MultiNicNodeConfig) error {
+    var msgs []string
+    for _, idx := range []struct{ name, what string; keys []string }{
+        {macIndex, "MAC", claimedMACs(cr)},
+        {ipIndex, "IP", claimedIPs(cr)},
+    } {
+        for _, key := range idx.keys {
+            objs, err := x.indexer.ByIndex(idx.name, key)
+            if err != nil { continue }
+            var owners []string
+            for _, o := range objs {
+                other, ok := o.(*multinicv1alpha1.MultiNicNodeConfig)
+                if !ok || (other.Namespace == cr.Namespace && other.Name == cr.Name) { continue }
+                if claimsFirst(other, cr) {
+                    owners = append(owners, other.Namespace+"/"+other.Name)
+                }
+            }
+            if len(owners) > 0 {
+                sort.Strings(owners)
+                msgs = append(msgs, fmt.Sprintf("%s %s is claimed by %s", idx.what, key, strings.Join(owners, ",")))
+            }
+        }
+    }
+    if len(msgs) == 0 { return nil }
+    return domainerrors.NewConflictError(strings.Join(msgs, "; "))
+}
+
+// claimsFirst reports whether a owns a shared claim over b
+func claimsFirst(a, b *multinicv1alpha1.MultiNicNodeConfig) bool {
+    if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+        return a.CreationTimestamp.Before(&b.CreationTimestamp)
+    }
+    return a.Name < b.Name
+}
+
+// claimedMACs returns the canonical interface and bond member MACs of cr
+func claimedMACs(cr *multinicv1alpha1.MultiNicNodeConfig) []string {
+    seen := map[string]bool{}
+    var out []string
+    add := func(mac string) {
+        if hw, err := net.ParseMAC(strings.TrimSpace(mac)); err == nil {
+            mac = hw.String()
+        } else {
+            mac = strings.ToLower(strings.TrimSpace(mac))
+        }
+        if mac != "" && !seen[mac] { seen[mac] = true; out = append(out, mac) }
+    }
+    for _, it := range cr.Spec.Interfaces {
+        add(it.MacAddress)
+        if it.Bond != nil {
+            for _, m := range it.Bond.Members { add(m) }
+        }
+    }
+    return out
+}
+
+// claimedIPs returns the static addresses (primary, additional, VLAN) declared in cr
+func claimedIPs(cr *multinicv1alpha1.MultiNicNodeConfig) []string {
+    seen := map[string]bool{}
+    var out []string
+    add := func(addr string) {
+        ip := net.ParseIP(strings.TrimSpace(addr))
+        if ip == nil { return }
+        if s := ip.String(); !seen[s] { seen[s] = true; out = append(out, s) }
+    }
+    for _, it := range cr.Spec.Interfaces {
+        add(it.Address)
+        for _, a := range it.Addresses { add(a.Address) }
+        for _, v := range it.VLANs { add(v.Address) }
+    }
+    return out
+}
+
+// hasConflict reports whether cr currently carries a true Conflict condition
+func hasConflict(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    for _, cond := range cr.Status.Conditions {
+        if cond.Type == ConditionConflict && cond.Status == "True" { return true }
+    }
+    return false
+}
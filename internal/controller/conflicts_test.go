@@ -0,0 +1,78 @@
+package controller
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func conflictCR(name string, created time.Time, mac, addr string) *multinicv1alpha1.MultiNicNodeConfig {
+    cr := makeNodeCR("multinic-system", name, name, "")
+    cr.CreationTimestamp = metav1.NewTime(created)
+    cr.Spec.Interfaces = []multinicv1alpha1.InterfaceSpec{{ID: 1, MacAddress: mac, Address: addr, CIDR: "10.0.0.0/24", MTU: 1450}}
+    return cr
+}
+
+func TestConflictIndex_NewerCRLosesClaim(t *testing.T) {
+    base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    older := conflictCR("worker-1", base, "02:00:00:00:01:01", "10.0.0.10")
+    newer := conflictCR("worker-2", base.Add(time.Minute), "02:00:00:00:01:01", "10.0.0.11")
+    other := conflictCR("worker-3", base.Add(2*time.Minute), "02:00:00:00:01:03", "10.0.0.11")
+    idx := NewConflictIndexFromList([]multinicv1alpha1.MultiNicNodeConfig{*older, *newer, *other})
+
+    if err := idx.Check(older); err != nil { t.Fatalf("older CR must keep its claim, got %v", err) }
+    err := idx.Check(newer)
+    if err == nil || !strings.Contains(err.Error(), "MAC 02:00:00:00:01:01 is claimed by multinic-system/worker-1") || !strings.Contains(err.Error(), "CON001") {
+        t.Fatalf("expected MAC conflict for newer CR, got %v", err)
+    }
+    if err := idx.Check(other); err == nil || !strings.Contains(err.Error(), "IP 10.0.0.11 is claimed by multinic-system/worker-2") {
+        t.Fatalf("expected IP conflict, got %v", err)
+    }
+}
+
+func TestConflictIndex_BondMembersAndMACCase(t *testing.T) {
+    base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    bond := conflictCR("worker-1", base, "02:00:00:00:01:01", "10.0.0.10")
+    bond.Spec.Interfaces[0].Kind = "bond"
+    bond.Spec.Interfaces[0].Bond = &multinicv1alpha1.BondSpec{Members: []string{"02:00:00:00:01:01", "02:00:00:00:01:02"}}
+    port := conflictCR("worker-2", base.Add(time.Minute), "02:00:00:00:01:02", "10.0.0.20")
+    port.Spec.Interfaces[0].MacAddress = "02:00:00:00:01:02"
+    idx := NewConflictIndexFromList([]multinicv1alpha1.MultiNicNodeConfig{*bond, *port})
+
+    if err := idx.Check(port); err == nil { t.Fatalf("expected conflict with bond member") }
+}
+
+func TestReconcile_ConflictBlocksJob(t *testing.T) {
+    ns := "multinic-system"
+    base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    older := conflictCR("worker-1", base, "02:00:00:00:01:01", "10.0.0.10")
+    newer := conflictCR("worker-2", base.Add(time.Minute), "02:00:00:00:01:02", "10.0.0.10")
+    mnc := multinicfake.NewSimpleClientset(older, newer)
+    kclient := k8sfake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}})
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: ns,
+        Conflicts: NewConflictIndexFromList([]multinicv1alpha1.MultiNicNodeConfig{*older, *newer})}
+
+    if err := c.Reconcile(context.Background(), ns, "worker-2"); err != nil { t.Fatalf("reconcile error: %v", err) }
+
+    jobs, _ := kclient.BatchV1().Jobs(ns).List(context.Background(), metav1.ListOptions{})
+    if len(jobs.Items) != 0 { t.Fatalf("expected no job for conflicting CR, got %d", len(jobs.Items)) }
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs(ns).Get(context.Background(), "worker-2", metav1.GetOptions{})
+    if got.Status.State != multinicv1alpha1.StatePending || !hasConflict(got) || got.Status.ObservedGeneration != 0 {
+        t.Fatalf("expected Pending with Conflict condition, got state=%q conditions=%#v", got.Status.State, got.Status.Conditions)
+    }
+
+    // conflict resolved: the CR is scheduled on the next reconcile
+    c.Conflicts = NewConflictIndexFromList([]multinicv1alpha1.MultiNicNodeConfig{*newer})
+    if err := c.Reconcile(context.Background(), ns, "worker-2"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if _, err := kclient.BatchV1().Jobs(ns).Get(context.Background(), "multinic-agent-worker-2-g0", metav1.GetOptions{}); err != nil {
+        t.Fatalf("expected job after conflict resolved: %v", err)
+    }
+}
@@ -0,0 +1,335 @@
+package controller
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "sort"
+    "strings"
+    "time"
+
+    "multinic-agent/internal/domain/constants"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    policyv1 "k8s.io/api/policy/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/types"
+)
+
+// Conditions recorded while a disruptive change is applied under spec.disruptionPolicy
+const (
+    ConditionDisruptionExpected = "DisruptionExpected"
+    ConditionNodeCordoned       = "NodeCordoned"
+    ConditionPodsEvicted        = "PodsEvicted"
+)
+
+// Disruption condition reasons
+const (
+    DisruptionReasonPredicted            = "DisruptiveChange"
+    DisruptionReasonCordoned             = "Cordoned"
+    DisruptionReasonAlreadyUnschedulable = "AlreadyUnschedulable"
+    DisruptionReasonUncordoned           = "Uncordoned"
+    DisruptionReasonEvicted              = "Evicted"
+    DisruptionReasonEvictionPending      = "EvictionPending"
+)
+
+// CordonedByAnnotation on a Node names the node config ("namespace/name") that cordoned it;
+// only that config uncordons the node again
+const CordonedByAnnotation = "multinic.io/cordoned-by"
+
+// AppliedInterfacesAnnotation on an apply Job holds the interfaces it configures (JSON); it
+// becomes status.appliedInterfaces when the Job succeeds
+const AppliedInterfacesAnnotation = "multinic.io/applied-interfaces"
+
+// drainPollInterval is how often a CR waiting for evicted pods is looked at again
+const drainPollInterval = 10 * time.Second
+
+// interfacePrefixOf returns the name prefix the agent Job for eff uses
+func interfacePrefixOf(eff effectiveConfig) string {
+    if eff.Defaults.InterfacePrefix != "" { return eff.Defaults.InterfacePrefix }
+    return constants.InterfacePrefix()
+}
+
+// jobInterfacePrefix returns the INTERFACE_PREFIX an agent Job ran with
+func jobInterfacePrefix(job *batchv1.Job) string {
+    for _, ctr := range job.Spec.Template.Spec.Containers {
+        for _, e := range ctr.Env {
+            if e.Name == "INTERFACE_PREFIX" && e.Value != "" { return e.Value }
+        }
+    }
+    return constants.InterfacePrefix()
+}
+
+// interfacePrefixFor returns the prefix of the Job Reconcile schedules for cr on node
+// (policy defaults apply; a failing policy lookup keeps the controller setting)
+func (c *Controller) interfacePrefixFor(cr *multinicv1alpha1.MultiNicNodeConfig, node *corev1.Node) string {
+    var policies []*multinicv1alpha1.MultiNicClusterPolicy
+    if c.Policies != nil {
+        policies, _ = matchingPolicies(c.Policies, node)
+    }
+    return interfacePrefixOf(buildEffectiveConfig(cr, policies))
+}
+
+// plannedInterfaces returns what the agent Job for eff configures, in status.appliedInterfaces form
+func plannedInterfaces(eff effectiveConfig) []multinicv1alpha1.AppliedInterface {
+    prefix := interfacePrefixOf(eff)
+    out := make([]multinicv1alpha1.AppliedInterface, 0, len(eff.Spec.Interfaces))
+    for i, it := range eff.Spec.Interfaces {
+        ai := multinicv1alpha1.AppliedInterface{
+            MacAddress: strings.ToLower(strings.TrimSpace(it.MacAddress)),
+            Name:       fmt.Sprintf("%s%d", prefix, i),
+            MTU:        it.MTU,
+        }
+        if it.Address != "" { ai.Addresses = append(ai.Addresses, it.Address) }
+        for _, a := range it.Addresses {
+            if a.Address != "" { ai.Addresses = append(ai.Addresses, a.Address) }
+        }
+        out = append(out, ai)
+    }
+    return out
+}
+
+// predictDisruption compares the planned interfaces with the ones the last successful Job
+// configured and describes every change that may interrupt traffic on the node. The controller
+// does not see link states, so an interface that was never configured counts as a rename of an
+// UP link (the agent renames it from its kernel name, bringing it down if the rename fails).
+func predictDisruption(applied, planned []multinicv1alpha1.AppliedInterface, prefix string) []string {
+    prev := make(map[string]multinicv1alpha1.AppliedInterface, len(applied))
+    for _, a := range applied { prev[strings.ToLower(a.MacAddress)] = a }
+
+    var reasons []string
+    for _, p := range planned {
+        a, ok := prev[p.MacAddress]
+        if !ok {
+            reasons = append(reasons, fmt.Sprintf("%s (%s) is renamed from its kernel name", p.Name, p.MacAddress))
+            continue
+        }
+        delete(prev, p.MacAddress)
+        if a.Name != "" && !managedName(a.Name, prefix) {
+            reasons = append(reasons, fmt.Sprintf("%s (%s) is renamed to %s", a.Name, p.MacAddress, p.Name))
+        }
+        if a.MTU > 0 && p.MTU > 0 && p.MTU < a.MTU {
+            reasons = append(reasons, fmt.Sprintf("mtu of %s decreases from %d to %d", p.Name, a.MTU, p.MTU))
+        }
+        keep := map[string]bool{}
+        for _, addr := range p.Addresses { keep[addr] = true }
+        for _, addr := range a.Addresses {
+            if !keep[addr] { reasons = append(reasons, fmt.Sprintf("address %s is removed from %s", addr, p.Name)) }
+        }
+    }
+    removed := make([]string, 0, len(prev))
+    for mac, a := range prev { removed = append(removed, fmt.Sprintf("%s (%s) is removed", a.Name, mac)) }
+    sort.Strings(removed)
+    return append(reasons, removed...)
+}
+
+// managedName reports whether name is <prefix>N
+func managedName(name, prefix string) bool {
+    rest, ok := strings.CutPrefix(name, prefix)
+    if !ok || rest == "" { return false }
+    for _, r := range rest {
+        if r < '0' || r > '9' { return false }
+    }
+    return true
+}
+
+// appliedInterfacesOf reads AppliedInterfacesAnnotation of an apply Job (nil when absent)
+func appliedInterfacesOf(annotations map[string]string) []multinicv1alpha1.AppliedInterface {
+    raw := annotations[AppliedInterfacesAnnotation]
+    if raw == "" { return nil }
+    var out []multinicv1alpha1.AppliedInterface
+    if err := json.Unmarshal([]byte(raw), &out); err != nil { return nil }
+    return out
+}
+
+// disruptionConditions returns the disruption conditions of conds (to carry them across state changes)
+func disruptionConditions(conds []multinicv1alpha1.Condition) []multinicv1alpha1.Condition {
+    var out []multinicv1alpha1.Condition
+    for _, c := range conds {
+        switch c.Type {
+        case ConditionDisruptionExpected, ConditionNodeCordoned, ConditionPodsEvicted:
+            out = append(out, c)
+        }
+    }
+    return out
+}
+
+func conditionOf(cr *multinicv1alpha1.MultiNicNodeConfig, condType string) *multinicv1alpha1.Condition {
+    for i := range cr.Status.Conditions {
+        if cr.Status.Conditions[i].Type == condType { return &cr.Status.Conditions[i] }
+    }
+    return nil
+}
+
+func disruptionCondition(condType, status, reason, message string) multinicv1alpha1.Condition {
+    now := metav1.Now()
+    return multinicv1alpha1.Condition{Type: condType, Status: status, Reason: reason, Message: message, LastTransitionTime: &now}
+}
+
+// sameConditions compares conditions without their transition times
+func sameConditions(a, b []multinicv1alpha1.Condition) bool {
+    if len(a) != len(b) { return false }
+    for i := range a {
+        if a[i].Type != b[i].Type || a[i].Status != b[i].Status || a[i].Reason != b[i].Reason || a[i].Message != b[i].Message { return false }
+    }
+    return true
+}
+
+// prepareDisruption은 spec.disruptionPolicy에 따라 노드를 cordon하고 선택된 Pod를 evict한다.
+// 모든 Pod가 빠지면 ready=true와 함께 Job 상태에 이어 붙일 조건을 반환하고, 아직 남아 있으면
+// CR을 Pending으로 두고 조건을 기록한다 (호출자는 drainPollInterval 뒤 다시 확인).
+func (c *Controller) prepareDisruption(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, node *corev1.Node, reasons []string) ([]multinicv1alpha1.Condition, bool, error) {
+    dp := cr.Spec.DisruptionPolicy
+    summary := strings.Join(reasons, "; ")
+    conds := []multinicv1alpha1.Condition{disruptionCondition(ConditionDisruptionExpected, "True", DisruptionReasonPredicted, summary)}
+    if prev := conditionOf(cr, ConditionDisruptionExpected); prev == nil || prev.Message != summary {
+        c.recordEvent(cr, node.Name, corev1.EventTypeNormal, EventReasonDisruptiveChange, "disruptive change: %s", summary)
+    }
+
+    if dp.Cordon {
+        reason, err := c.cordon(ctx, cr, node)
+        if err != nil { return nil, false, err }
+        msg := "node marked unschedulable"
+        if reason == DisruptionReasonAlreadyUnschedulable { msg = "node was already unschedulable; it is left cordoned afterwards" }
+        conds = append(conds, disruptionCondition(ConditionNodeCordoned, "True", reason, msg))
+    }
+
+    ready := true
+    if dp.EvictPodSelector != nil {
+        remaining, blocked, err := c.evictPods(ctx, node.Name, dp.EvictPodSelector)
+        if err != nil { return nil, false, err }
+        if len(remaining) == 0 {
+            conds = append(conds, disruptionCondition(ConditionPodsEvicted, "True", DisruptionReasonEvicted, "no selected pods left on the node"))
+        } else {
+            ready = false
+            msg := "waiting for pods to terminate: " + strings.Join(remaining, ", ")
+            if len(blocked) > 0 { msg += "; eviction refused by a PodDisruptionBudget: " + strings.Join(blocked, ", ") }
+            conds = append(conds, disruptionCondition(ConditionPodsEvicted, "False", DisruptionReasonEvictionPending, msg))
+        }
+    }
+
+    if ready {
+        if dp.EvictPodSelector != nil {
+            c.recordEvent(cr, node.Name, corev1.EventTypeNormal, EventReasonPodsEvicted, "no selected pods left on node %s", node.Name)
+        }
+        return conds, true, nil
+    }
+    if sameConditions(conds, cr.Status.Conditions) { return conds, false, nil }
+    if evicted := conds[len(conds)-1]; strings.Contains(evicted.Message, "PodDisruptionBudget") {
+        c.recordEvent(cr, node.Name, corev1.EventTypeWarning, EventReasonEvictionBlocked, "%s", evicted.Message)
+    }
+    log.Printf("[%s/%s] job deferred: %s", cr.Namespace, cr.Name, conds[len(conds)-1].Message)
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StatePending
+        st.Conditions = conds
+        st.LastUpdated = &now
+    })
+    return conds, false, nil
+}
+
+// cordon marks node unschedulable on behalf of cr. A node that someone else cordoned is left
+// alone (reason AlreadyUnschedulable) so that it is not uncordoned after the Job.
+func (c *Controller) cordon(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, node *corev1.Node) (string, error) {
+    owner := cr.Namespace + "/" + cr.Name
+    if node.Spec.Unschedulable {
+        if node.Annotations[CordonedByAnnotation] == owner { return DisruptionReasonCordoned, nil }
+        return DisruptionReasonAlreadyUnschedulable, nil
+    }
+    patch, _ := json.Marshal(map[string]interface{}{
+        "metadata": map[string]interface{}{"annotations": map[string]string{CordonedByAnnotation: owner}},
+        "spec":     map[string]interface{}{"unschedulable": true},
+    })
+    if _, err := c.Client.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+        return "", fmt.Errorf("failed to cordon node %s: %w", node.Name, err)
+    }
+    log.Printf("node %s cordoned for %s", node.Name, owner)
+    c.recordEvent(cr, node.Name, corev1.EventTypeNormal, EventReasonNodeCordoned, "node %s cordoned before a disruptive change", node.Name)
+    return DisruptionReasonCordoned, nil
+}
+
+// uncordon releases a node cordoned by cr; it reports whether the node was released
+func (c *Controller) uncordon(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string) (bool, error) {
+    node, err := c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+    if apierrors.IsNotFound(err) { return false, nil }
+    if err != nil { return false, err }
+    if node.Annotations[CordonedByAnnotation] != cr.Namespace+"/"+cr.Name { return false, nil }
+    patch, _ := json.Marshal(map[string]interface{}{
+        "metadata": map[string]interface{}{"annotations": map[string]interface{}{CordonedByAnnotation: nil}},
+        "spec":     map[string]interface{}{"unschedulable": false},
+    })
+    if _, err := c.Client.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+        return false, fmt.Errorf("failed to uncordon node %s: %w", nodeName, err)
+    }
+    log.Printf("node %s uncordoned", nodeName)
+    c.recordEvent(cr, nodeName, corev1.EventTypeNormal, EventReasonNodeUncordoned, "node %s uncordoned", nodeName)
+    return true, nil
+}
+
+// releaseDisruption uncordons the node after a successful Job and returns the disruption
+// conditions of cr updated accordingly
+func (c *Controller) releaseDisruption(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string) []multinicv1alpha1.Condition {
+    conds := disruptionConditions(cr.Status.Conditions)
+    released, err := c.uncordon(ctx, cr, nodeName)
+    if err != nil {
+        // the node stays cordoned; the next successful Job or the CR deletion retries
+        log.Printf("[%s/%s] %v", cr.Namespace, cr.Name, err)
+        return conds
+    }
+    if !released { return conds }
+    for i := range conds {
+        if conds[i].Type == ConditionNodeCordoned {
+            conds[i] = disruptionCondition(ConditionNodeCordoned, "False", DisruptionReasonUncordoned, "node uncordoned after the job succeeded")
+        }
+    }
+    return conds
+}
+
+// evictPods evicts the pods on nodeName matching selector (like kubectl drain, DaemonSet and
+// mirror pods are skipped). It returns the pods that are still on the node and those whose
+// eviction a PodDisruptionBudget refused.
+func (c *Controller) evictPods(ctx context.Context, nodeName string, selector *metav1.LabelSelector) ([]string, []string, error) {
+    sel, err := metav1.LabelSelectorAsSelector(selector)
+    if err != nil { return nil, nil, fmt.Errorf("invalid disruptionPolicy.evictPodSelector: %w", err) }
+    pods, err := c.Client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: sel.String(), FieldSelector: "spec.nodeName=" + nodeName})
+    if err != nil { return nil, nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err) }
+
+    var remaining, blocked []string
+    for i := range pods.Items {
+        pod := &pods.Items[i]
+        if pod.Spec.NodeName != nodeName || !evictable(pod) { continue }
+        key := pod.Namespace + "/" + pod.Name
+        if pod.DeletionTimestamp != nil {
+            remaining = append(remaining, key)
+            continue
+        }
+        eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+        switch err := c.Client.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction); {
+        case err == nil:
+            log.Printf("evicted pod %s from node %s", key, nodeName)
+            remaining = append(remaining, key)
+        case apierrors.IsNotFound(err):
+        case apierrors.IsTooManyRequests(err):
+            remaining = append(remaining, key)
+            blocked = append(blocked, key)
+        default:
+            return nil, nil, fmt.Errorf("failed to evict pod %s: %w", key, err)
+        }
+    }
+    return remaining, blocked, nil
+}
+
+// evictable skips finished pods, mirror (static) pods and DaemonSet pods, which the
+// DaemonSet controller would recreate on the cordoned node anyway
+func evictable(pod *corev1.Pod) bool {
+    if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed { return false }
+    if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok { return false }
+    for _, ref := range pod.OwnerReferences {
+        if ref.Kind == "DaemonSet" { return false }
+    }
+    return true
+}
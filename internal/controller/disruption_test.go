@@ -0,0 +1,178 @@
+package controller
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    policyv1 "k8s.io/api/policy/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+    k8stesting "k8s.io/client-go/testing"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func TestPredictDisruption(t *testing.T) {
+    applied := []multinicv1alpha1.AppliedInterface{
+        {MacAddress: "02:00:00:00:01:01", Name: "multinic0", MTU: 9000, Addresses: []string{"10.0.0.10"}},
+        {MacAddress: "02:00:00:00:01:02", Name: "multinic1", MTU: 1500, Addresses: []string{"10.0.1.10"}},
+    }
+    // adding an address and raising the MTU do not interrupt traffic
+    safe := []multinicv1alpha1.AppliedInterface{
+        {MacAddress: "02:00:00:00:01:01", Name: "multinic0", MTU: 9000, Addresses: []string{"10.0.0.10", "10.0.0.11"}},
+        {MacAddress: "02:00:00:00:01:02", Name: "multinic1", MTU: 9000, Addresses: []string{"10.0.1.10"}},
+    }
+    if got := predictDisruption(applied, safe, "multinic"); len(got) != 0 { t.Fatalf("expected no disruption, got %v", got) }
+
+    planned := []multinicv1alpha1.AppliedInterface{
+        {MacAddress: "02:00:00:00:01:01", Name: "multinic0", MTU: 1500, Addresses: []string{"10.0.0.20"}},
+        {MacAddress: "02:00:00:00:01:03", Name: "multinic1", MTU: 1500},
+    }
+    got := strings.Join(predictDisruption(applied, planned, "multinic"), "; ")
+    for _, want := range []string{
+        "mtu of multinic0 decreases from 9000 to 1500",
+        "address 10.0.0.10 is removed from multinic0",
+        "multinic1 (02:00:00:00:01:03) is renamed from its kernel name",
+        "multinic1 (02:00:00:00:01:02) is removed",
+    } {
+        if !strings.Contains(got, want) { t.Fatalf("missing %q in %q", want, got) }
+    }
+
+    // a new interface prefix renames every configured link
+    if got := predictDisruption(applied[:1], applied[:1], "tenant"); len(got) != 1 || !strings.Contains(got[0], "is renamed to multinic0") {
+        t.Fatalf("expected a rename, got %v", got)
+    }
+}
+
+func disruptionFixture(unschedulable bool) (*multinicv1alpha1.MultiNicNodeConfig, *corev1.Node, []runtime.Object) {
+    cr := rolloutCR("worker-1", multinicv1alpha1.StateConfigured)
+    cr.Generation = 2
+    cr.Spec.Interfaces[0].MTU = 1500
+    cr.Spec.DisruptionPolicy = &multinicv1alpha1.DisruptionPolicy{
+        Cordon:           true,
+        EvictPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sriov-consumer"}},
+    }
+    cr.Status.AppliedInterfaces = []multinicv1alpha1.AppliedInterface{{MacAddress: "02:00:00:00:01:01", Name: "multinic0", MTU: 9000}}
+    node := rolloutNode("worker-1", nil)
+    node.Spec.Unschedulable = unschedulable
+    pods := []runtime.Object{
+        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "apps", Labels: map[string]string{"app": "sriov-consumer"}}, Spec: corev1.PodSpec{NodeName: "worker-1"}},
+        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "consumer-ds", Namespace: "apps", Labels: map[string]string{"app": "sriov-consumer"}, OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds"}}}, Spec: corev1.PodSpec{NodeName: "worker-1"}},
+        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "apps", Labels: map[string]string{"app": "web"}}, Spec: corev1.PodSpec{NodeName: "worker-1"}},
+    }
+    return cr, node, pods
+}
+
+// evictions delete the pod later; the reactor records them and the test removes the pod
+func recordEvictions(kclient *k8sfake.Clientset) *[]string {
+    var evicted []string
+    kclient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+        if action.GetSubresource() != "eviction" { return false, nil, nil }
+        ev := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+        evicted = append(evicted, ev.Namespace+"/"+ev.Name)
+        return true, nil, nil
+    })
+    return &evicted
+}
+
+func crCondition(t *testing.T, mnc *multinicfake.Clientset, condType string) multinicv1alpha1.Condition {
+    t.Helper()
+    got, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-1", metav1.GetOptions{})
+    if err != nil { t.Fatalf("get CR: %v", err) }
+    if cond := conditionOf(got, condType); cond != nil { return *cond }
+    return multinicv1alpha1.Condition{}
+}
+
+func TestReconcile_DisruptiveChangeCordonsAndDrains(t *testing.T) {
+    cr, node, pods := disruptionFixture(false)
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(append(pods, node)...)
+    evicted := recordEvictions(kclient)
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system"}
+    ctx := context.Background()
+
+    // the selected pod is evicted; the Job waits until it is gone
+    err := c.Reconcile(ctx, "multinic-system", "worker-1")
+    var requeue *RequeueAfter
+    if !errors.As(err, &requeue) || requeue.Delay != drainPollInterval { t.Fatalf("expected a drain requeue, got %v", err) }
+    if len(*evicted) != 1 || (*evicted)[0] != "apps/consumer" { t.Fatalf("expected only apps/consumer to be evicted, got %v", *evicted) }
+    if jobExists(t, kclient, "worker-1") { t.Fatalf("no job may start before the pods are gone") }
+    n, _ := kclient.CoreV1().Nodes().Get(ctx, "worker-1", metav1.GetOptions{})
+    if !n.Spec.Unschedulable || n.Annotations[CordonedByAnnotation] != "multinic-system/worker-1" { t.Fatalf("expected the node to be cordoned by the CR, got %+v", n.Spec) }
+    if cond := crCondition(t, mnc, ConditionPodsEvicted); cond.Status != "False" || cond.Reason != DisruptionReasonEvictionPending {
+        t.Fatalf("expected PodsEvicted=False/EvictionPending, got %+v", cond)
+    }
+    if cond := crCondition(t, mnc, ConditionDisruptionExpected); !strings.Contains(cond.Message, "mtu of multinic0 decreases from 9000 to 1500") {
+        t.Fatalf("unexpected DisruptionExpected condition %+v", cond)
+    }
+
+    _ = kclient.CoreV1().Pods("apps").Delete(ctx, "consumer", metav1.DeleteOptions{})
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    jobs, _ := kclient.BatchV1().Jobs("multinic-system").List(ctx, metav1.ListOptions{})
+    if len(jobs.Items) != 1 { t.Fatalf("expected the job once the node is drained, got %d", len(jobs.Items)) }
+    if cond := crCondition(t, mnc, ConditionNodeCordoned); cond.Status != "True" { t.Fatalf("NodeCordoned must stay while the job runs, got %+v", cond) }
+
+    // success uncordons the node and records what the job applied
+    job := jobs.Items[0]
+    job.Status = batchv1.JobStatus{Succeeded: 1}
+    if err := c.ProcessJob(ctx, "multinic-system", &job); err != nil { t.Fatalf("process job: %v", err) }
+    n, _ = kclient.CoreV1().Nodes().Get(ctx, "worker-1", metav1.GetOptions{})
+    if n.Spec.Unschedulable || n.Annotations[CordonedByAnnotation] != "" { t.Fatalf("expected the node to be uncordoned") }
+    if cond := crCondition(t, mnc, ConditionNodeCordoned); cond.Status != "False" || cond.Reason != DisruptionReasonUncordoned {
+        t.Fatalf("expected NodeCordoned=False/Uncordoned, got %+v", cond)
+    }
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-1", metav1.GetOptions{})
+    if len(got.Status.AppliedInterfaces) != 1 || got.Status.AppliedInterfaces[0].MTU != 1500 { t.Fatalf("unexpected appliedInterfaces %+v", got.Status.AppliedInterfaces) }
+}
+
+func TestReconcile_DisruptionKeepsForeignCordon(t *testing.T) {
+    cr, node, _ := disruptionFixture(true)
+    cr.Spec.DisruptionPolicy.EvictPodSelector = nil
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(node)
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system"}
+    ctx := context.Background()
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if cond := crCondition(t, mnc, ConditionNodeCordoned); cond.Reason != DisruptionReasonAlreadyUnschedulable {
+        t.Fatalf("expected AlreadyUnschedulable, got %+v", cond)
+    }
+    jobs, _ := kclient.BatchV1().Jobs("multinic-system").List(ctx, metav1.ListOptions{})
+    if len(jobs.Items) != 1 { t.Fatalf("expected a job, got %d", len(jobs.Items)) }
+    job := jobs.Items[0]
+    job.Status = batchv1.JobStatus{Succeeded: 1}
+    if err := c.ProcessJob(ctx, "multinic-system", &job); err != nil { t.Fatalf("process job: %v", err) }
+    n, _ := kclient.CoreV1().Nodes().Get(ctx, "worker-1", metav1.GetOptions{})
+    if !n.Spec.Unschedulable { t.Fatalf("a node cordoned by someone else must stay cordoned") }
+}
+
+// a node config held back while its node is cordoned keeps the conditions that uncordon it later
+func TestReconcile_HoldKeepsDisruptionConditions(t *testing.T) {
+    cr, node, pods := disruptionFixture(false)
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(append(pods, node)...)
+    recordEvictions(kclient)
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system"}
+    ctx := context.Background()
+
+    var requeue *RequeueAfter
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); !errors.As(err, &requeue) { t.Fatalf("expected a drain requeue, got %v", err) }
+
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-1", metav1.GetOptions{})
+    got.Annotations = map[string]string{PausedAnnotation: "true"}
+    if _, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Update(ctx, got, metav1.UpdateOptions{}); err != nil { t.Fatalf("update CR: %v", err) }
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if cond := crCondition(t, mnc, ConditionPaused); cond.Status != "True" { t.Fatalf("expected the CR to be paused, got %+v", cond) }
+    if cond := crCondition(t, mnc, ConditionNodeCordoned); cond.Status != "True" { t.Fatalf("pausing must keep NodeCordoned, got %+v", cond) }
+    if cond := crCondition(t, mnc, ConditionDisruptionExpected); cond.Status != "True" { t.Fatalf("pausing must keep DisruptionExpected, got %+v", cond) }
+
+    // a failure summary keeps them as well
+    if err := c.ApplyTerminationSummary(ctx, "multinic-system", "worker-1", "job-1", `{"failures":[{"id":1,"reason":"link down"}]}`); err != nil { t.Fatalf("apply summary: %v", err) }
+    if cond := crCondition(t, mnc, ConditionNodeCordoned); cond.Status != "True" { t.Fatalf("a failure summary must keep NodeCordoned, got %+v", cond) }
+}
@@ -0,0 +1,320 @@
+package controller
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "strconv"
+    "strings"
+    "time"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    apiequality "k8s.io/apimachinery/pkg/api/equality"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Where the controller learns about drift on each resync
+const (
+    // DriftSourceJob launches a read-only verify Job per node
+    DriftSourceJob = "job"
+    // DriftSourceDaemonSet reads the report the DaemonSet agent writes to DriftReportAnnotation
+    DriftSourceDaemonSet = "daemonset"
+)
+
+// DriftReportAnnotation carries the last drift report of the DaemonSet agent (driftReport JSON)
+const DriftReportAnnotation = multinicv1alpha1.DriftReportAnnotation
+
+// ConditionDrifted is set while the node no longer matches the last applied config
+const ConditionDrifted = "Drifted"
+
+// Drifted condition reasons
+const (
+    DriftReasonDetected = "DriftDetected"
+    DriftReasonResolved = "DriftResolved"
+)
+
+// interfaceDrift is one drifted interface reported by a verify Job or the DaemonSet agent
+type interfaceDrift struct {
+    ID     int    `json:"id"`
+    MAC    string `json:"mac"`
+    Name   string `json:"name"`
+    Reason string `json:"reason"`
+}
+
+// interfaceObservation is the actual state (Up, NoCarrier, Down, NotFound, Unknown) the agent
+// found for one interface; it fills interfaceStatuses[].actualState
+type interfaceObservation struct {
+    ID    int    `json:"id"`
+    MAC   string `json:"mac"`
+    Name  string `json:"name"`
+    State string `json:"state"`
+}
+
+// driftReport is the part of the verify termination summary (and of DriftReportAnnotation)
+// the controller reads; an empty drifts list means the node matches its config
+type driftReport struct {
+    Interfaces []interfaceObservation `json:"interfaces"`
+    Drifts     []interfaceDrift       `json:"drifts"`
+    Timestamp  string                 `json:"timestamp"`
+}
+
+// agentRejectsAction is what an agent without the verify action exits with; the verify Job
+// falls back to the container log for its termination message, so the controller can see it
+const agentRejectsAction = "unsupported AGENT_ACTION"
+
+// verifyJobName is the per-node name of the verify Job (one resync at a time per node)
+func verifyJobName(nodeName string) string {
+    return fmt.Sprintf("multinic-agent-verify-%s", nodeName)
+}
+
+// resyncable reports whether cr is settled on its current spec, so that a drift check is meaningful
+func resyncable(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    if cr.DeletionTimestamp != nil || cr.Generation != cr.Status.ObservedGeneration { return false }
+    return cr.Status.State == multinicv1alpha1.StateConfigured || cr.Status.State == multinicv1alpha1.StateDrifted
+}
+
+// Resync는 Configured/Drifted CR마다 노드가 아직 마지막으로 적용한 설정과 같은지 확인한다.
+// DriftSource가 job이면 verify Job을 띄우고(결과는 ProcessJob에서 반영), daemonset이면 agent가
+// CR annotation에 남긴 보고를 바로 반영한다. ResyncInterval마다 watcher/poll 루프가 호출한다.
+func (c *Controller) Resync(ctx context.Context, namespace string) error {
+    if c.DriftSource != DriftSourceDaemonSet && c.verifyUnsupported.Load() {
+        log.Printf("drift resync skipped: agent image %s does not support the verify action", c.AgentImage)
+        return nil
+    }
+    list, err := c.nodeConfigs(namespace).List(ctx, metav1.ListOptions{})
+    if err != nil { return err }
+    for i := range list.Items {
+        cr := &list.Items[i]
+        if !resyncable(cr) { continue }
+        if c.DriftSource == DriftSourceDaemonSet {
+            c.observeDriftReport(ctx, cr)
+            continue
+        }
+        if err := c.launchVerifyJob(ctx, cr); err != nil {
+            log.Printf("[%s/%s] verify job not started: %v", cr.Namespace, cr.Name, err)
+        }
+    }
+    return nil
+}
+
+// launchVerifyJob starts the verify Job of cr's node unless another agent Job still runs there
+func (c *Controller) launchVerifyJob(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig) error {
+    nodeName := nodeNameOf(cr)
+    jobs, err := c.Client.BatchV1().Jobs(cr.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=multinic-agent,multinic.io/node-name=" + nodeName})
+    if err != nil { return err }
+    for i := range jobs.Items {
+        if jobs.Items[i].Status.Succeeded == 0 && jobs.Items[i].Status.Failed == 0 { return nil }
+    }
+
+    node, err := c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+    if err != nil { return fmt.Errorf("failed to get node %s: %w", nodeName, err) }
+    var policies []*multinicv1alpha1.MultiNicClusterPolicy
+    if c.Policies != nil {
+        if policies, err = matchingPolicies(c.Policies, node); err != nil { return err }
+    }
+    // the node is compared against the same effective spec its last apply Job received
+    eff := buildEffectiveConfig(cr, policies)
+    job := BuildAgentJob(node.Status.NodeInfo.OSImage, JobParams{
+        Namespace:           cr.Namespace,
+        Name:                verifyJobName(nodeName),
+        Image:               c.AgentImage,
+        PullPolicy:          c.ImagePullPolicy,
+        ServiceAccountName:  c.ServiceAccount,
+        NodeName:            nodeName,
+        NodeCRNamespace:     c.NodeCRNamespace,
+        TTLSecondsAfterDone: c.JobTTLSeconds,
+        Action:              "verify",
+        InterfacePrefix:     eff.Defaults.InterfacePrefix,
+        NodeConfigSpec:      eff.specJSON(),
+        Options:             overlayOptions(c.NetworkOptions, eff.Defaults.Options),
+        OwnerReferences:     []metav1.OwnerReference{nodeConfigOwnerRef(cr)},
+    })
+    if _, err := c.Client.BatchV1().Jobs(cr.Namespace).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+        // the previous verify Job is finished but not processed yet
+        if apierrors.IsAlreadyExists(err) { return nil }
+        return err
+    }
+    log.Printf("verify job created: %s/%s for node=%s", cr.Namespace, job.Name, nodeName)
+    return nil
+}
+
+// processVerifyJob은 끝난 verify Job의 종료 요약을 CR의 Drifted 상태에 반영하고 Job을 지운다.
+func (c *Controller) processVerifyJob(ctx context.Context, namespace string, job *batchv1.Job) error {
+    if job.Status.Succeeded == 0 && job.Status.Failed == 0 { return nil }
+    defer c.scheduleJobDeletion(ctx, namespace, job.Name)
+
+    cr, err := c.nodeConfigs(namespace).Get(ctx, job.Labels["multinic.io/node-name"], metav1.GetOptions{})
+    if err != nil { return nil }
+    // a change scheduled meanwhile gets its own Job, which decides the state
+    if !resyncable(cr) { return nil }
+    nodeName := nodeNameOf(cr)
+    if job.Status.Failed > 0 {
+        // an older agent image cannot verify: every further verify Job would fail the same way
+        if msg := c.getJobTerminationMessage(ctx, namespace, job.Name); strings.Contains(msg, agentRejectsAction) {
+            c.verifyUnsupported.Store(true)
+            log.Printf("verify job %s/%s: agent image %s does not support the verify action; verify jobs stopped", namespace, job.Name, c.AgentImage)
+            c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonVerifyUnsupported,
+                "agent image %s does not support the verify action; upgrade the agent or use driftSource=daemonset", c.AgentImage)
+            return nil
+        }
+        log.Printf("verify job failed: %s/%s", namespace, job.Name)
+        c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonVerifyFailed, "verify job %s failed; drift state unknown", job.Name)
+        return nil
+    }
+    msg := c.getJobTerminationMessage(ctx, namespace, job.Name)
+    var rep driftReport
+    if err := json.Unmarshal([]byte(msg), &rep); err != nil {
+        log.Printf("verify job %s/%s left no readable summary: %q", namespace, job.Name, msg)
+        return nil
+    }
+    c.applyDrift(ctx, cr, nodeName, rep, "verify job "+job.Name)
+    return nil
+}
+
+// observeDriftReport applies the DaemonSet agent report of cr. A report that is not newer than
+// the last status write describes an earlier state of the node and is skipped.
+func (c *Controller) observeDriftReport(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig) {
+    raw := cr.Annotations[DriftReportAnnotation]
+    if raw == "" { return }
+    var rep driftReport
+    if err := json.Unmarshal([]byte(raw), &rep); err != nil {
+        log.Printf("[%s/%s] unreadable %s annotation: %v", cr.Namespace, cr.Name, DriftReportAnnotation, err)
+        return
+    }
+    at, err := time.Parse(time.RFC3339, rep.Timestamp)
+    if err != nil { return }
+    if cr.Status.LastUpdated != nil && !at.After(cr.Status.LastUpdated.Time) { return }
+    c.applyDrift(ctx, cr, nodeNameOf(cr), rep, "agent report")
+}
+
+// applyDrift moves cr to Drifted when the report has drifts and back to Configured once a later
+// check finds nothing. Every report refreshes actualState/lastChecked of the interfaces; an
+// unchanged drift only does that, without a new event or transition.
+func (c *Controller) applyDrift(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string, rep driftReport, source string) {
+    checked := metav1.Now()
+    if at, err := time.Parse(time.RFC3339, rep.Timestamp); err == nil { checked = metav1.NewTime(at) }
+    drifts := rep.Drifts
+    statuses := observedInterfaceStatuses(driftInterfaceStatuses(cr, drifts), rep.Interfaces, checked)
+
+    if len(drifts) == 0 {
+        if cr.Status.State != multinicv1alpha1.StateDrifted {
+            c.recordObservation(ctx, cr, statuses)
+            return
+        }
+        log.Printf("[%s/%s] drift resolved (%s)", cr.Namespace, cr.Name, source)
+        c.recordEvent(cr, nodeName, corev1.EventTypeNormal, EventReasonDriftResolved, "%s found the node matching its config again", source)
+        _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+            now := metav1.Now()
+            st.State = multinicv1alpha1.StateConfigured
+            st.Conditions = append([]multinicv1alpha1.Condition{{Type: "Ready", Status: "True", Reason: DriftReasonResolved}}, disruptionConditions(cr.Status.Conditions)...)
+            st.InterfaceStatuses = statuses
+            st.LastUpdated = &now
+        })
+        return
+    }
+
+    msg := driftMessage(drifts)
+    if cond := conditionOf(cr, ConditionDrifted); cr.Status.State == multinicv1alpha1.StateDrifted && cond != nil && cond.Message == msg {
+        c.recordObservation(ctx, cr, statuses)
+        return
+    }
+    log.Printf("[%s/%s] drift detected (%s): %s", cr.Namespace, cr.Name, source, msg)
+    c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonDriftDetected, "%s: %s", source, msg)
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StateDrifted
+        st.Conditions = append([]multinicv1alpha1.Condition{
+            {Type: "Ready", Status: "False", Reason: DriftReasonDetected},
+            {Type: ConditionDrifted, Status: "True", Reason: DriftReasonDetected, Message: msg, LastTransitionTime: &now},
+        }, disruptionConditions(cr.Status.Conditions)...)
+        st.InterfaceStatuses = statuses
+        st.LastUpdated = &now
+    })
+}
+
+// recordObservation writes refreshed interface statuses without touching state or lastUpdated,
+// which the DaemonSet report check compares against
+func (c *Controller) recordObservation(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, statuses []multinicv1alpha1.InterfaceStatus) {
+    if apiequality.Semantic.DeepEqual(cr.Status.InterfaceStatuses, statuses) { return }
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        st.InterfaceStatuses = statuses
+    })
+}
+
+func driftMessage(drifts []interfaceDrift) string {
+    parts := make([]string, 0, len(drifts))
+    for _, d := range drifts {
+        name := d.Name
+        if name == "" { name = d.MAC }
+        parts = append(parts, name+": "+d.Reason)
+    }
+    return strings.Join(parts, "; ")
+}
+
+// driftInterfaceStatuses marks the drifted entries of the current interfaceStatuses (matched by
+// MAC, then name) and returns the others to Configured
+func driftInterfaceStatuses(cr *multinicv1alpha1.MultiNicNodeConfig, drifts []interfaceDrift) []multinicv1alpha1.InterfaceStatus {
+    statuses := make([]multinicv1alpha1.InterfaceStatus, len(cr.Status.InterfaceStatuses))
+    copy(statuses, cr.Status.InterfaceStatuses)
+    for i := range statuses {
+        st := &statuses[i]
+        drifted := false
+        for _, d := range drifts {
+            mac := strings.ToLower(strings.TrimSpace(d.MAC))
+            if (mac != "" && strings.EqualFold(st.MacAddress, mac)) || (mac == "" && d.Name != "" && d.Name == st.Name) {
+                st.Status, st.Reason, st.Message = "Drifted", DriftReasonDetected, d.Reason
+                drifted = true
+                break
+            }
+        }
+        if !drifted && st.Status == "Drifted" {
+            st.Status, st.Reason, st.Message = "Configured", DriftReasonResolved, ""
+        }
+    }
+    return statuses
+}
+
+// observedInterfaceStatuses sets actualState and lastChecked of the statuses the report observed
+// (matched by MAC, then name); interfaces missing from the report keep their last observation
+func observedInterfaceStatuses(statuses []multinicv1alpha1.InterfaceStatus, observed []interfaceObservation, checked metav1.Time) []multinicv1alpha1.InterfaceStatus {
+    for i := range statuses {
+        st := &statuses[i]
+        for _, o := range observed {
+            mac := strings.TrimSpace(o.MAC)
+            if (mac != "" && strings.EqualFold(st.MacAddress, mac)) || (mac == "" && o.Name != "" && o.Name == st.Name) {
+                at := checked
+                st.ActualState, st.LastChecked = o.State, &at
+                break
+            }
+        }
+    }
+    return statuses
+}
+
+// remediating reports whether Reconcile re-applies cr unchanged to repair drift: a Drifted CR
+// when RemediateDrift is on, or the remediation Job it already started
+func (c *Controller) remediating(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    if !c.RemediateDrift { return false }
+    switch cr.Status.State {
+    case multinicv1alpha1.StateDrifted:
+        return true
+    case multinicv1alpha1.StateInProgress:
+        cond := conditionOf(cr, "InProgress")
+        return cond != nil && cond.Reason == EventReasonDriftRemediation
+    }
+    return false
+}
+
+// remediationJobName keeps the name of a running remediation Job and otherwise makes a new one,
+// because the generation-aware name of the last apply Job may still be taken
+func (c *Controller) remediationJobName(cr *multinicv1alpha1.MultiNicNodeConfig, base string) string {
+    if cr.Status.State == multinicv1alpha1.StateInProgress && strings.HasPrefix(cr.Status.LastJobName, base+"-r") {
+        return cr.Status.LastJobName
+    }
+    return base + "-r" + strconv.FormatInt(c.now().Unix(), 36)
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func driftCR(state multinicv1alpha1.NodeConfigState) *multinicv1alpha1.MultiNicNodeConfig {
+    cr := rolloutCR("worker-1", state)
+    cr.Status.InterfaceStatuses = []multinicv1alpha1.InterfaceStatus{{Name: "multinic0", MacAddress: "02:00:00:00:01:01", Status: "Configured", Reason: "JobSucceeded"}}
+    return cr
+}
+
+// verifyPod is the finished pod of the verify Job with its termination summary
+func verifyPod(msg string) *corev1.Pod {
+    return &corev1.Pod{
+        ObjectMeta: metav1.ObjectMeta{Name: "verify-pod", Namespace: "multinic-system", Labels: map[string]string{"job-name": verifyJobName("worker-1")}},
+        Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: msg}}}}},
+    }
+}
+
+func getCR(t *testing.T, mnc *multinicfake.Clientset) *multinicv1alpha1.MultiNicNodeConfig {
+    t.Helper()
+    got, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-1", metav1.GetOptions{})
+    if err != nil { t.Fatalf("get CR: %v", err) }
+    return got
+}
+
+func TestResync_VerifyJobFlipsDrifted(t *testing.T) {
+    mnc := multinicfake.NewSimpleClientset(driftCR(multinicv1alpha1.StateConfigured), rolloutCR("worker-2", multinicv1alpha1.StatePending))
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil), rolloutNode("worker-2", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system"}
+    ctx := context.Background()
+
+    if err := c.Resync(ctx, "multinic-system"); err != nil { t.Fatalf("resync error: %v", err) }
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, verifyJobName("worker-1"), metav1.GetOptions{})
+    if err != nil { t.Fatalf("expected a verify job: %v", err) }
+    if job.Labels["multinic.io/action"] != "verify" { t.Fatalf("unexpected action label %q", job.Labels["multinic.io/action"]) }
+    if jobExists(t, kclient, "worker-2") { t.Fatalf("a Pending node config must not be verified") }
+
+    // a verify Job still running holds back the next one
+    if err := c.Resync(ctx, "multinic-system"); err != nil { t.Fatalf("resync error: %v", err) }
+    jobs, _ := kclient.BatchV1().Jobs("multinic-system").List(ctx, metav1.ListOptions{})
+    if len(jobs.Items) != 1 { t.Fatalf("expected one verify job, got %d", len(jobs.Items)) }
+
+    _, _ = kclient.CoreV1().Pods("multinic-system").Create(ctx, verifyPod(`{"interfaces":[{"id":1,"mac":"02:00:00:00:01:01","name":"multinic0","state":"NoCarrier"}],"drifts":[{"id":1,"mac":"02:00:00:00:01:01","name":"multinic0","reason":"no carrier, address 10.0.0.10/24 missing"}],"timestamp":"2026-10-16T12:00:00Z"}`), metav1.CreateOptions{})
+    job.Status = batchv1.JobStatus{Succeeded: 1}
+    if err := c.ProcessJob(ctx, "multinic-system", job); err != nil { t.Fatalf("process job: %v", err) }
+    got := getCR(t, mnc)
+    if got.Status.State != multinicv1alpha1.StateDrifted { t.Fatalf("expected Drifted, got %s", got.Status.State) }
+    if cond := conditionOf(got, ConditionDrifted); cond == nil || !strings.Contains(cond.Message, "multinic0: no carrier, address 10.0.0.10/24 missing") {
+        t.Fatalf("unexpected Drifted condition %+v", cond)
+    }
+    if st := got.Status.InterfaceStatuses[0]; st.Status != "Drifted" { t.Fatalf("expected the interface to be Drifted, got %+v", st) }
+    if st := got.Status.InterfaceStatuses[0]; st.ActualState != "NoCarrier" || st.LastChecked == nil || !st.LastChecked.Time.Equal(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)) {
+        t.Fatalf("expected actualState/lastChecked from the report, got %+v", st)
+    }
+    if _, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, job.Name, metav1.GetOptions{}); err == nil { t.Fatalf("the verify job must be deleted") }
+
+    // without remediation a Drifted config is left alone
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if jobExists(t, kclient, "worker-1") { t.Fatalf("no apply job without drift remediation") }
+
+    // a clean check returns it to Configured
+    _ = kclient.CoreV1().Pods("multinic-system").Delete(ctx, "verify-pod", metav1.DeleteOptions{})
+    _, _ = kclient.CoreV1().Pods("multinic-system").Create(ctx, verifyPod(`{"interfaces":[{"id":1,"mac":"02:00:00:00:01:01","name":"multinic0","state":"Up"}],"drifts":[],"timestamp":"2026-10-16T12:05:00Z"}`), metav1.CreateOptions{})
+    if err := c.ProcessJob(ctx, "multinic-system", job); err != nil { t.Fatalf("process job: %v", err) }
+    got = getCR(t, mnc)
+    if got.Status.State != multinicv1alpha1.StateConfigured || got.Status.InterfaceStatuses[0].Status != "Configured" {
+        t.Fatalf("expected Configured again, got %s %+v", got.Status.State, got.Status.InterfaceStatuses)
+    }
+    if st := got.Status.InterfaceStatuses[0]; st.ActualState != "Up" { t.Fatalf("expected actualState Up, got %+v", st) }
+
+    // a later clean check on a Configured node only refreshes the observation
+    lastUpdated := got.Status.LastUpdated
+    _ = kclient.CoreV1().Pods("multinic-system").Delete(ctx, "verify-pod", metav1.DeleteOptions{})
+    _, _ = kclient.CoreV1().Pods("multinic-system").Create(ctx, verifyPod(`{"interfaces":[{"id":1,"mac":"02:00:00:00:01:01","name":"multinic0","state":"Up"}],"drifts":[],"timestamp":"2026-10-16T12:10:00Z"}`), metav1.CreateOptions{})
+    if err := c.ProcessJob(ctx, "multinic-system", job); err != nil { t.Fatalf("process job: %v", err) }
+    got = getCR(t, mnc)
+    if st := got.Status.InterfaceStatuses[0]; st.LastChecked == nil || !st.LastChecked.Time.Equal(time.Date(2026, 10, 16, 12, 10, 0, 0, time.UTC)) {
+        t.Fatalf("expected lastChecked to advance, got %+v", st)
+    }
+    if !got.Status.LastUpdated.Equal(lastUpdated) { t.Fatalf("an observation must not bump lastUpdated") }
+}
+
+func TestReconcile_RemediatesDrift(t *testing.T) {
+    cr := driftCR(multinicv1alpha1.StateDrifted)
+    cr.Status.LastJobName = "multinic-agent-worker-1-g1"
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil))
+    now := time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC)
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", RemediateDrift: true, Now: func() time.Time { return now }}
+    ctx := context.Background()
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    got := getCR(t, mnc)
+    if got.Status.State != multinicv1alpha1.StateInProgress || !strings.HasPrefix(got.Status.LastJobName, "multinic-agent-worker-1-g1-r") {
+        t.Fatalf("expected a remediation job, got %s %s", got.Status.State, got.Status.LastJobName)
+    }
+    if cond := conditionOf(got, "InProgress"); cond == nil || cond.Reason != EventReasonDriftRemediation { t.Fatalf("unexpected InProgress condition %+v", cond) }
+
+    // the running remediation keeps its Job
+    now = now.Add(time.Minute)
+    if err := c.Reconcile(ctx, "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    jobs, _ := kclient.BatchV1().Jobs("multinic-system").List(ctx, metav1.ListOptions{})
+    if len(jobs.Items) != 1 || jobs.Items[0].Name != got.Status.LastJobName { t.Fatalf("expected the single remediation job, got %d", len(jobs.Items)) }
+
+    jobs.Items[0].Status = batchv1.JobStatus{Succeeded: 1}
+    if err := c.ProcessJob(ctx, "multinic-system", &jobs.Items[0]); err != nil { t.Fatalf("process job: %v", err) }
+    if got := getCR(t, mnc); got.Status.State != multinicv1alpha1.StateConfigured { t.Fatalf("expected Configured after remediation, got %s", got.Status.State) }
+}
+
+func TestResync_DaemonSetDriftReport(t *testing.T) {
+    cr := driftCR(multinicv1alpha1.StateConfigured)
+    last := metav1.NewTime(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+    cr.Status.LastUpdated = &last
+    // a report from before the last apply is stale
+    cr.Annotations = map[string]string{DriftReportAnnotation: `{"drifts":[{"mac":"02:00:00:00:01:01","reason":"link down"}],"timestamp":"2026-10-16T11:00:00Z"}`}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", DriftSource: DriftSourceDaemonSet}
+    ctx := context.Background()
+
+    if err := c.Resync(ctx, "multinic-system"); err != nil { t.Fatalf("resync error: %v", err) }
+    if got := getCR(t, mnc); got.Status.State != multinicv1alpha1.StateConfigured { t.Fatalf("a stale report must be ignored, got %s", got.Status.State) }
+    if jobExists(t, kclient, "worker-1") { t.Fatalf("daemonset mode starts no verify job") }
+
+    got := getCR(t, mnc)
+    got.Annotations[DriftReportAnnotation] = `{"drifts":[{"mac":"02:00:00:00:01:01","reason":"link down"}],"timestamp":"2026-10-16T13:00:00Z"}`
+    _, _ = mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Update(ctx, got, metav1.UpdateOptions{})
+    if err := c.Resync(ctx, "multinic-system"); err != nil { t.Fatalf("resync error: %v", err) }
+    got = getCR(t, mnc)
+    if cond := conditionOf(got, ConditionDrifted); got.Status.State != multinicv1alpha1.StateDrifted || cond == nil || cond.Message != "02:00:00:00:01:01: link down" {
+        t.Fatalf("expected Drifted from the agent report, got %s %+v", got.Status.State, cond)
+    }
+}
+
+// an agent image without the verify action fails every verify Job; the controller stops launching them
+func TestResync_StopsWhenAgentRejectsVerify(t *testing.T) {
+    mnc := multinicfake.NewSimpleClientset(driftCR(multinicv1alpha1.StateConfigured))
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:old", NodeCRNamespace: "multinic-system"}
+    ctx := context.Background()
+
+    if err := c.Resync(ctx, "multinic-system"); err != nil { t.Fatalf("resync error: %v", err) }
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, verifyJobName("worker-1"), metav1.GetOptions{})
+    if err != nil { t.Fatalf("expected a verify job: %v", err) }
+    if p := job.Spec.Template.Spec.Containers[0].TerminationMessagePolicy; p != corev1.TerminationMessageFallbackToLogsOnError {
+        t.Fatalf("expected the verify job to fall back to its log, got %q", p)
+    }
+
+    _, _ = kclient.CoreV1().Pods("multinic-system").Create(ctx, verifyPod(`{"level":"fatal","msg":"Failed to run application","error":"unsupported AGENT_ACTION \"verify\""}`), metav1.CreateOptions{})
+    job.Status = batchv1.JobStatus{Failed: 1}
+    if err := c.ProcessJob(ctx, "multinic-system", job); err != nil { t.Fatalf("process job: %v", err) }
+    if got := getCR(t, mnc); got.Status.State != multinicv1alpha1.StateConfigured { t.Fatalf("a failed verify must not change the state, got %s", got.Status.State) }
+
+    if err := c.Resync(ctx, "multinic-system"); err != nil { t.Fatalf("resync error: %v", err) }
+    if jobExists(t, kclient, "worker-1") { t.Fatalf("no verify job may start once the agent rejected the action") }
+}
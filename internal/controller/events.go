@@ -0,0 +1,83 @@
+package controller
+
+import (
+    "fmt"
+    "strings"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicscheme "multinic-agent/pkg/generated/clientset/versioned/scheme"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/apimachinery/pkg/types"
+    utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+    "k8s.io/client-go/kubernetes"
+    clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+    typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+    "k8s.io/client-go/tools/record"
+)
+
+// Event reasons recorded on the MultiNicNodeConfig and its Node
+const (
+    EventReasonJobScheduled       = "JobScheduled"
+    EventReasonSpecChanged        = "SpecChanged"
+    EventReasonPolicyChanged      = "PolicyChanged"
+    EventReasonJobSucceeded       = "JobSucceeded"
+    EventReasonJobFailed          = "JobFailed"
+    EventReasonJobFailedPartial   = "JobFailedPartial"
+    EventReasonInterfaceFailed    = "InterfaceFailed"
+    EventReasonInstanceIDMismatch = "InstanceIDMismatch"
+    EventReasonAddressConflict    = "AddressConflict"
+    EventReasonCleanupStarted     = "CleanupStarted"
+    EventReasonCleanupFinished    = "CleanupFinished"
+    EventReasonCleanupFailed      = "CleanupFailed"
+    EventReasonDisruptiveChange   = "DisruptiveChange"
+    EventReasonNodeCordoned       = "NodeCordoned"
+    EventReasonPodsEvicted        = "PodsEvicted"
+    EventReasonEvictionBlocked    = "EvictionBlocked"
+    EventReasonNodeUncordoned     = "NodeUncordoned"
+    EventReasonDriftDetected      = "DriftDetected"
+    EventReasonDriftResolved      = "DriftResolved"
+    EventReasonDriftRemediation   = "DriftRemediation"
+    EventReasonVerifyFailed       = "VerifyFailed"
+    EventReasonVerifyUnsupported  = "VerifyUnsupported"
+)
+
+// NewEventRecorder returns a recorder that writes core/v1 Events as multinic-controller,
+// and a function that stops its broadcaster
+func NewEventRecorder(client kubernetes.Interface) (record.EventRecorder, func()) {
+    scheme := runtime.NewScheme()
+    utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+    utilruntime.Must(multinicscheme.AddToScheme(scheme))
+    broadcaster := record.NewBroadcaster()
+    broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+    return broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "multinic-controller"}), broadcaster.Shutdown
+}
+
+// nodeRef refers to a Node the way the kubelet does (UID = node name)
+func nodeRef(nodeName string) *corev1.ObjectReference {
+    return &corev1.ObjectReference{Kind: "Node", APIVersion: "v1", Name: nodeName, UID: types.UID(nodeName)}
+}
+
+// recordEvent은 CR과 노드 양쪽에 같은 이벤트를 남긴다. Recorder가 없으면 아무것도 하지 않는다.
+func (c *Controller) recordEvent(cr *multinicv1alpha1.MultiNicNodeConfig, nodeName, eventType, reason, messageFmt string, args ...interface{}) {
+    if c.Recorder == nil { return }
+    if cr != nil { c.Recorder.Eventf(cr, eventType, reason, messageFmt, args...) }
+    if nodeName != "" {
+        msg := fmt.Sprintf(messageFmt, args...)
+        if cr != nil { msg = fmt.Sprintf("%s/%s: %s", cr.Namespace, cr.Name, msg) }
+        c.Recorder.Event(nodeRef(nodeName), eventType, reason, msg)
+    }
+}
+
+// recordInterfaceFailures는 종료 요약의 실패 항목마다 errorType/reason을 담은 Warning 이벤트를 남긴다.
+func (c *Controller) recordInterfaceFailures(cr *multinicv1alpha1.MultiNicNodeConfig, nodeName, jobName string, failures []jobFailure) {
+    for _, f := range failures {
+        name := strings.TrimSpace(f.Name)
+        if name == "" { name = "-" }
+        errorType := f.ErrorType
+        if errorType == "" { errorType = "unknown" }
+        c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonInterfaceFailed,
+            "interface %s (id=%d mac=%s) failed in job %s: errorType=%s reason=%s", name, f.ID, f.MAC, jobName, errorType, f.Reason)
+    }
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+    "k8s.io/client-go/tools/record"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+// drainEvents returns the events recorded so far ("<type> <reason> <message>")
+func drainEvents(r *record.FakeRecorder) []string {
+    var out []string
+    for {
+        select {
+        case e := <-r.Events:
+            out = append(out, e)
+        default:
+            return out
+        }
+    }
+}
+
+func countEvents(events []string, prefix string) int {
+    n := 0
+    for _, e := range events {
+        if strings.HasPrefix(e, prefix) { n++ }
+    }
+    return n
+}
+
+func TestReconcile_RecordsJobScheduledOnCRAndNode(t *testing.T) {
+    mnc := multinicfake.NewSimpleClientset(makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", ""))
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    rec := record.NewFakeRecorder(20)
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system", Recorder: rec}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    events := drainEvents(rec)
+    // a CR without observedGeneration is a spec change; one event on the CR and one on the Node
+    if countEvents(events, "Normal SpecChanged scheduled agent job multinic-agent-worker-node-01-g0") != 1 ||
+        countEvents(events, "Normal SpecChanged multinic-system/worker-node-01: scheduled agent job") != 1 {
+        t.Fatalf("expected SpecChanged events on CR and node, got %v", events)
+    }
+}
+
+func TestReconcile_RecordsInstanceIDMismatch(t *testing.T) {
+    mnc := multinicfake.NewSimpleClientset(makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "11111111-2222-3333-4444-555555555555"))
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS", SystemUUID: "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"}}},
+    )
+    rec := record.NewFakeRecorder(20)
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Recorder: rec}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-node-01"); err == nil { t.Fatalf("expected instance-id mismatch error") }
+    if events := drainEvents(rec); countEvents(events, "Warning InstanceIDMismatch") != 2 {
+        t.Fatalf("expected InstanceIDMismatch on CR and node, got %v", events)
+    }
+}
+
+func TestProcessJob_RecordsInterfaceFailures(t *testing.T) {
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.Spec.Interfaces = append(cr.Spec.Interfaces, multinicv1alpha1.InterfaceSpec{ID: 2, MacAddress: "02:00:00:00:01:02"})
+    mnc := multinicfake.NewSimpleClientset(cr)
+    msg := `{"failures":[{"id":2,"mac":"02:00:00:00:01:02","name":"multinic1","errorType":"LinkDown","reason":"carrier lost"}]}`
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Pod{
+            ObjectMeta: metav1.ObjectMeta{Name: "job-1-abcde", Namespace: "multinic-system", Labels: map[string]string{"job-name": "job-1"}},
+            Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: msg}}}}},
+        },
+    )
+    rec := record.NewFakeRecorder(20)
+    c := &Controller{MultiNic: mnc, Client: kclient, Recorder: rec}
+    job := &batchv1.Job{
+        ObjectMeta: metav1.ObjectMeta{Name: "job-1", Namespace: "multinic-system", Labels: map[string]string{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": "worker-node-01"}},
+        Status:     batchv1.JobStatus{Failed: 1},
+    }
+
+    if err := c.ProcessJob(context.Background(), "multinic-system", job); err != nil { t.Fatalf("process job error: %v", err) }
+    events := drainEvents(rec)
+    if countEvents(events, "Warning JobFailedPartial job job-1 failed") != 1 {
+        t.Fatalf("expected a JobFailedPartial event, got %v", events)
+    }
+    if countEvents(events, "Warning InterfaceFailed interface multinic1 (id=2 mac=02:00:00:00:01:02) failed in job job-1: errorType=LinkDown reason=carrier lost") != 1 {
+        t.Fatalf("expected an InterfaceFailed event with errorType and reason, got %v", events)
+    }
+}
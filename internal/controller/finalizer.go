@@ -0,0 +1,100 @@
+package controller
+
+import (
+    "context"
+    "fmt"
+    "log"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    corev1 "k8s.io/api/core/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CleanupFinalizer keeps a deleted MultiNicNodeConfig until the cleanup Job removed its interfaces from the node
+const CleanupFinalizer = "multinic.io/cleanup"
+
+// cleanupJobName is the per-node name of the cleanup Job (one at a time per node)
+func cleanupJobName(nodeName string) string {
+    return fmt.Sprintf("multinic-agent-cleanup-%s", nodeName)
+}
+
+// nodeConfigOwnerRef makes cr the controller owner of its apply Jobs, so that they are
+// garbage collected with the CR
+func nodeConfigOwnerRef(cr *multinicv1alpha1.MultiNicNodeConfig) metav1.OwnerReference {
+    return *metav1.NewControllerRef(cr, multinicv1alpha1.SchemeGroupVersion.WithKind("MultiNicNodeConfig"))
+}
+
+func hasFinalizer(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    for _, f := range cr.Finalizers {
+        if f == CleanupFinalizer { return true }
+    }
+    return false
+}
+
+// ensureFinalizer adds CleanupFinalizer to cr (CRs created before the finalizer existed get it on their next reconcile)
+func (c *Controller) ensureFinalizer(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig) (*multinicv1alpha1.MultiNicNodeConfig, error) {
+    if hasFinalizer(cr) { return cr, nil }
+    obj := cr.DeepCopy()
+    obj.Finalizers = append(obj.Finalizers, CleanupFinalizer)
+    updated, err := c.nodeConfigs(cr.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+    if err != nil { return nil, fmt.Errorf("failed to add finalizer to %s/%s: %w", cr.Namespace, cr.Name, err) }
+    return updated, nil
+}
+
+// removeFinalizer releases cr so that the API server can complete its deletion
+func (c *Controller) removeFinalizer(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig) error {
+    obj := cr.DeepCopy()
+    obj.Finalizers = nil
+    for _, f := range cr.Finalizers {
+        if f != CleanupFinalizer { obj.Finalizers = append(obj.Finalizers, f) }
+    }
+    if _, err := c.nodeConfigs(cr.Namespace).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+        if apierrors.IsNotFound(err) { return nil }
+        return fmt.Errorf("failed to remove finalizer from %s/%s: %w", cr.Namespace, cr.Name, err)
+    }
+    log.Printf("finalizer removed: %s/%s", cr.Namespace, cr.Name)
+    return nil
+}
+
+// finalize는 삭제 중인 CR의 노드에서 cleanup Job을 실행하고, Job이 성공한 뒤에만 finalizer를 제거한다.
+// Job 이벤트가 CR 키를 다시 큐에 넣으므로 대기 중에는 에러 없이 반환한다.
+func (c *Controller) finalize(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig) error {
+    if !hasFinalizer(cr) { return nil }
+    namespace := cr.Namespace
+    nodeName := cr.Spec.NodeName
+    if nodeName == "" { nodeName = cr.Name }
+
+    jobName := cleanupJobName(nodeName)
+    job, err := c.Client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+    switch {
+    case apierrors.IsNotFound(err):
+        if err := c.LaunchCleanupJob(ctx, cr, nodeName); err != nil {
+            // a node that no longer exists has nothing left to clean up
+            if apierrors.IsNotFound(err) {
+                log.Printf("node %s not found - releasing %s/%s without cleanup", nodeName, namespace, cr.Name)
+                return c.removeFinalizer(ctx, cr)
+            }
+            return err
+        }
+        c.recordEvent(cr, nodeName, corev1.EventTypeNormal, EventReasonCleanupStarted, "started cleanup job %s", jobName)
+        return nil
+    case err != nil:
+        return err
+    case job.Status.Succeeded > 0:
+        log.Printf("cleanup job succeeded: %s/%s", namespace, jobName)
+        c.recordEvent(cr, nodeName, corev1.EventTypeNormal, EventReasonCleanupFinished, "cleanup job %s succeeded; releasing finalizer", jobName)
+        // a node cordoned for a disruptive change of this CR is not left behind
+        if _, err := c.uncordon(ctx, cr, nodeName); err != nil { return err }
+        if err := c.removeFinalizer(ctx, cr); err != nil { return err }
+        c.scheduleJobDeletion(ctx, namespace, jobName)
+        return nil
+    case job.Status.Failed > 0:
+        c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonCleanupFailed, "cleanup job %s failed; retrying", jobName)
+        // the retry creates a fresh cleanup Job once this one is gone
+        _ = c.deleteJob(ctx, namespace, jobName)
+        return fmt.Errorf("cleanup job %s/%s failed", namespace, jobName)
+    }
+    return nil
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+    "context"
+    "testing"
+
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func TestReconcile_AddsFinalizerAndOwnsJob(t *testing.T) {
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.UID = "cr-uid-1"
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system"}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-node-01", metav1.GetOptions{})
+    if !hasFinalizer(got) { t.Fatalf("expected finalizer %s, got %v", CleanupFinalizer, got.Finalizers) }
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(context.Background(), "multinic-agent-worker-node-01-g0", metav1.GetOptions{})
+    if err != nil { t.Fatalf("job not found: %v", err) }
+    if len(job.OwnerReferences) != 1 { t.Fatalf("expected one owner reference, got %v", job.OwnerReferences) }
+    ref := job.OwnerReferences[0]
+    if ref.Kind != "MultiNicNodeConfig" || ref.Name != "worker-node-01" || ref.UID != "cr-uid-1" || ref.Controller == nil || !*ref.Controller {
+        t.Fatalf("unexpected owner reference: %+v", ref)
+    }
+}
+
+func TestReconcile_DeletedCR_RemovesFinalizerAfterCleanupJob(t *testing.T) {
+    ctx := context.Background()
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    now := metav1.Now()
+    cr.DeletionTimestamp = &now
+    cr.Finalizers = []string{CleanupFinalizer}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system"}
+    crs := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system")
+
+    // first pass launches the cleanup Job and keeps the finalizer
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, "multinic-agent-cleanup-worker-node-01", metav1.GetOptions{})
+    if err != nil { t.Fatalf("cleanup job not created: %v", err) }
+    if job.Labels["multinic.io/action"] != "cleanup" { t.Fatalf("expected cleanup action label, got %v", job.Labels) }
+    if len(job.OwnerReferences) != 0 { t.Fatalf("cleanup job must not be owned by the CR: %v", job.OwnerReferences) }
+    if got, _ := crs.Get(ctx, "worker-node-01", metav1.GetOptions{}); !hasFinalizer(got) {
+        t.Fatalf("finalizer removed before the cleanup job succeeded")
+    }
+
+    // a running Job keeps the finalizer as well
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if got, _ := crs.Get(ctx, "worker-node-01", metav1.GetOptions{}); !hasFinalizer(got) {
+        t.Fatalf("finalizer removed while the cleanup job is running")
+    }
+
+    job.Status.Succeeded = 1
+    if _, err := kclient.BatchV1().Jobs("multinic-system").UpdateStatus(ctx, job, metav1.UpdateOptions{}); err != nil { t.Fatalf("update job: %v", err) }
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if got, _ := crs.Get(ctx, "worker-node-01", metav1.GetOptions{}); got != nil && hasFinalizer(got) {
+        t.Fatalf("expected finalizer to be removed after the cleanup job succeeded, got %v", got.Finalizers)
+    }
+}
+
+func TestReconcile_DeletedCR_FailedCleanupJobIsRetried(t *testing.T) {
+    ctx := context.Background()
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    now := metav1.Now()
+    cr.DeletionTimestamp = &now
+    cr.Finalizers = []string{CleanupFinalizer}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system"}
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    job, _ := kclient.BatchV1().Jobs("multinic-system").Get(ctx, "multinic-agent-cleanup-worker-node-01", metav1.GetOptions{})
+    job.Status.Failed = 1
+    _, _ = kclient.BatchV1().Jobs("multinic-system").UpdateStatus(ctx, job, metav1.UpdateOptions{})
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err == nil { t.Fatalf("expected an error so that the key is retried") }
+    if got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-node-01", metav1.GetOptions{}); !hasFinalizer(got) {
+        t.Fatalf("finalizer must be kept when the cleanup job failed")
+    }
+    // the retry starts a new cleanup Job
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, "multinic-agent-cleanup-worker-node-01", metav1.GetOptions{})
+    if err != nil || job.Status.Failed != 0 { t.Fatalf("expected a fresh cleanup job, got %+v (err=%v)", job, err) }
+}
+
+func TestReconcile_DeletedCR_MissingNodeReleasesFinalizer(t *testing.T) {
+    ctx := context.Background()
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    now := metav1.Now()
+    cr.DeletionTimestamp = &now
+    cr.Finalizers = []string{CleanupFinalizer}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    c := &Controller{MultiNic: mnc, Client: k8sfake.NewSimpleClientset(), AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system"}
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-node-01", metav1.GetOptions{}); got != nil && hasFinalizer(got) {
+        t.Fatalf("expected the finalizer to be released for a node that no longer exists")
+    }
+}
@@ -2,6 +2,11 @@ package controller
 
 import (
     "fmt"
+    "strconv"
+
+    "multinic-agent/internal/domain/constants"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
     batchv1 "k8s.io/api/batch/v1"
     corev1 "k8s.io/api/core/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,7 +45,18 @@ type JobParams struct {
     NodeName            string
     NodeCRNamespace     string
     TTLSecondsAfterDone *int32
-    Action              string // "" | "cleanup"
+    Action              string // "" | "cleanup" | "verify"
+    // InterfacePrefix overrides the controller INTERFACE_PREFIX ("" = controller setting)
+    InterfacePrefix     string
+    // NodeConfigSpec is the effective spec JSON with cluster policy defaults merged in
+    // ("" = the agent reads the MultiNicNodeConfig itself)
+    NodeConfigSpec      string
+    // Options overrides the agent NETWORK_* settings; nil fields keep the agent defaults
+    Options             *multinicv1alpha1.NetworkOptions
+    // OwnerReferences are set on the Job (apply Jobs are owned by their MultiNicNodeConfig)
+    OwnerReferences     []metav1.OwnerReference
+    // Annotations are set on the Job (apply Jobs carry the interfaces they configure)
+    Annotations         map[string]string
 }
 
 // BuildAgentJob builds a Job manifest targeting a specific node with OS-aware mounts.
@@ -91,10 +107,36 @@ func BuildAgentJob(osImage string, p JobParams) *batchv1.Job {
     // 에이전트 헬스/프로브 포트: 8080 충돌을 피하기 위해 18080 사용
     const healthPort int32 = 18080
 
+    prefix := p.InterfacePrefix
+    if prefix == "" {
+        prefix = constants.InterfacePrefix()
+    }
+    env := []corev1.EnvVar{
+        {Name: "RUN_MODE", Value: "job"},
+        {Name: "DATA_SOURCE", Value: "nodecr"},
+        {Name: "NODE_CR_NAMESPACE", Value: p.NodeCRNamespace},
+        {Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+        {Name: "LOG_LEVEL", Value: "info"},
+        {Name: "POLL_INTERVAL", Value: "30s"},
+        // Agent health/metrics port override (avoid 8080 conflicts)
+        {Name: "HEALTH_PORT", Value: fmt.Sprintf("%d", healthPort)},
+        // optional action: cleanup
+        {Name: "AGENT_ACTION", Value: p.Action},
+        // agent must name and number interfaces the same way the controller reports them
+        {Name: "INTERFACE_PREFIX", Value: prefix},
+        {Name: "MAX_INTERFACES", Value: strconv.Itoa(constants.MaxInterfaces())},
+    }
+    if p.NodeConfigSpec != "" {
+        env = append(env, corev1.EnvVar{Name: "NODE_CONFIG_SPEC", Value: p.NodeConfigSpec})
+    }
+    env = append(env, optionsEnv(p.Options)...)
+
     job := &batchv1.Job{
         ObjectMeta: metav1.ObjectMeta{
-            Name:      p.Name,
-            Namespace: p.Namespace,
+            Name:            p.Name,
+            Namespace:       p.Namespace,
+            OwnerReferences: p.OwnerReferences,
+            Annotations:     p.Annotations,
             Labels: map[string]string{
                 "app.kubernetes.io/name":       "multinic-agent",
                 "app.kubernetes.io/managed-by": "multinic-controller",
@@ -124,18 +166,7 @@ func BuildAgentJob(osImage string, p JobParams) *batchv1.Job {
                             ImagePullPolicy: p.PullPolicy,
                             // 종료 전 대기를 강제하기 위해 셸 래퍼로 실행
                             Command:         []string{"./multinic-agent"},
-                            Env: []corev1.EnvVar{
-                                {Name: "RUN_MODE", Value: "job"},
-                                {Name: "DATA_SOURCE", Value: "nodecr"},
-                                {Name: "NODE_CR_NAMESPACE", Value: p.NodeCRNamespace},
-                                {Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
-                                {Name: "LOG_LEVEL", Value: "info"},
-                                {Name: "POLL_INTERVAL", Value: "30s"},
-                                // Agent health/metrics port override (avoid 8080 conflicts)
-                                {Name: "HEALTH_PORT", Value: fmt.Sprintf("%d", healthPort)},
-                                // optional action: cleanup
-                                {Name: "AGENT_ACTION", Value: p.Action},
-                            },
+                            Env:             env,
                             // 주의: hostNetwork=true 환경에서 ContainerPort를 정의하면
                             // 스케줄러가 호스트 포트 충돌을 검사하여 스케줄링이 실패할 수 있음.
                             // 프로브는 정수 포트 참조로 동작하므로 ContainerPort 선언 없이 유지합니다.
@@ -168,9 +199,46 @@ func BuildAgentJob(osImage string, p JobParams) *batchv1.Job {
 			},
         },
     }
+    if action == "verify" {
+        // a verify Job that exits with an error writes no summary; the log tail says why
+        job.Spec.Template.Spec.Containers[0].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+    }
     return job
 }
 
+// optionsEnv maps the set fields of o onto the agent NETWORK_* environment
+func optionsEnv(o *multinicv1alpha1.NetworkOptions) []corev1.EnvVar {
+    if o == nil { return nil }
+    var env []corev1.EnvVar
+    addBool := func(name string, v *bool) {
+        if v != nil { env = append(env, corev1.EnvVar{Name: name, Value: strconv.FormatBool(*v)}) }
+    }
+    addInt := func(name string, v *int32) {
+        if v != nil { env = append(env, corev1.EnvVar{Name: name, Value: strconv.Itoa(int(*v))}) }
+    }
+    addBool("NETWORK_POLICY_ROUTING_ENABLED", o.PolicyRoutingEnabled)
+    addInt("NETWORK_ROUTING_TABLE_BASE", o.RoutingTableBase)
+    addInt("NETWORK_ROUTE_METRIC", o.RouteMetric)
+    addBool("NETWORK_NOPREFIXROUTE", o.UseNoPrefixRoute)
+    addBool("NETWORK_SET_ARP_SYSCTLS", o.SetArpSysctls)
+    addBool("NETWORK_SET_RP_FILTER_LOOSE", o.SetLooseRPFilter)
+    return env
+}
+
+// overlayOptions returns base with the fields set in over replacing it (nil when both are nil)
+func overlayOptions(base, over *multinicv1alpha1.NetworkOptions) *multinicv1alpha1.NetworkOptions {
+    if over == nil { return base }
+    if base == nil { return over }
+    out := base.DeepCopy()
+    if over.PolicyRoutingEnabled != nil { out.PolicyRoutingEnabled = over.PolicyRoutingEnabled }
+    if over.RoutingTableBase != nil { out.RoutingTableBase = over.RoutingTableBase }
+    if over.RouteMetric != nil { out.RouteMetric = over.RouteMetric }
+    if over.UseNoPrefixRoute != nil { out.UseNoPrefixRoute = over.UseNoPrefixRoute }
+    if over.SetArpSysctls != nil { out.SetArpSysctls = over.SetArpSysctls }
+    if over.SetLooseRPFilter != nil { out.SetLooseRPFilter = over.SetLooseRPFilter }
+    return out
+}
+
 func hostPathType(t corev1.HostPathType) *corev1.HostPathType { return &t }
 
 // helpers
@@ -4,6 +4,8 @@ import (
     batchv1 "k8s.io/api/batch/v1"
     corev1 "k8s.io/api/core/v1"
     "testing"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
 )
 
 func TestOSFamilyFromOSImage(t *testing.T) {
@@ -44,12 +46,33 @@ func TestBuildAgentJob_RHEL_MountsNMOnly(t *testing.T) {
     assertJobBasics(t, job)
     mounts := job.Spec.Template.Spec.Containers[0].VolumeMounts
     vols := job.Spec.Template.Spec.Volumes
-    if len(mounts) != 1 || mounts[0].MountPath != "/etc/NetworkManager/system-connections" {
-        t.Fatalf("expected one nm-connections mount; got %#v", mounts)
+    // besides the keyfiles the RHEL job writes the .link rename files under /etc/systemd/network
+    if len(mounts) != 2 || mounts[0].MountPath != "/etc/NetworkManager/system-connections" || mounts[1].MountPath != "/etc/systemd/network" {
+        t.Fatalf("expected nm-connections and systemd-network mounts; got %#v", mounts)
+    }
+    if len(vols) != 2 || vols[0].HostPath == nil || vols[0].HostPath.Path != "/etc/NetworkManager/system-connections" {
+        t.Fatalf("expected nm-connections and systemd-network volumes; got %#v", vols)
+    }
+}
+
+func TestBuildAgentJob_PassesNetworkOptions(t *testing.T) {
+    off := false
+    base, metric := int32(100), int32(100)
+    policyMetric := int32(300)
+    controllerOpts := &multinicv1alpha1.NetworkOptions{PolicyRoutingEnabled: &off, RoutingTableBase: &base, RouteMetric: &metric}
+    opts := overlayOptions(controllerOpts, &multinicv1alpha1.NetworkOptions{RouteMetric: &policyMetric})
+    if *controllerOpts.RouteMetric != 100 { t.Fatalf("overlay must not modify the controller options") }
+
+    job := BuildAgentJob("Ubuntu 22.04.4 LTS", JobParams{Namespace: "multinic-system", Name: "test", Image: "multinic-agent:dev", NodeName: "node-1", NodeCRNamespace: "multinic-system", Options: opts})
+    want := map[string]string{
+        "NETWORK_POLICY_ROUTING_ENABLED": "false",
+        "NETWORK_ROUTING_TABLE_BASE":     "100",
+        "NETWORK_ROUTE_METRIC":           "300",
     }
-    if len(vols) != 1 || vols[0].HostPath == nil || vols[0].HostPath.Path != "/etc/NetworkManager/system-connections" {
-        t.Fatalf("expected one nm-connections volume; got %#v", vols)
+    for name, value := range want {
+        if got, ok := jobEnv(job, name); !ok || got != value { t.Fatalf("expected %s=%s, got %q", name, value, got) }
     }
+    if _, ok := jobEnv(job, "NETWORK_NOPREFIXROUTE"); ok { t.Fatalf("unset options must not be passed") }
 }
 
 func assertJobBasics(t *testing.T, job *batchv1.Job) {
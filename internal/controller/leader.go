@@ -0,0 +1,83 @@
+package controller
+
+import (
+    "context"
+    "errors"
+    "log"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promauto"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/client-go/kubernetes"
+    "k8s.io/client-go/tools/leaderelection"
+    "k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// LeaderElectionConfig configures the Lease that lets only one controller replica reconcile
+type LeaderElectionConfig struct {
+    Enabled        bool
+    LeaseName      string
+    LeaseNamespace string
+    // Identity is the holder written to the Lease (pod name)
+    Identity       string
+    LeaseDuration  time.Duration
+    RenewDeadline  time.Duration
+    RetryPeriod    time.Duration
+}
+
+// ErrLeadershipLost is returned when the lease was lost while the context is still alive
+var ErrLeadershipLost = errors.New("leader election lost")
+
+// leaderGauge reports whether this replica runs the reconcile loop
+var leaderGauge = promauto.NewGauge(prometheus.GaugeOpts{
+    Name: "multinic_controller_is_leader",
+    Help: "1 when this controller replica holds the leader lease (or leader election is disabled)",
+})
+
+// RunWithLeaderElection runs run while this replica holds the Lease. run gets a context that is
+// cancelled when leadership ends; the Lease is released once run returns after ctx is cancelled.
+// Without leader election run is called directly.
+func RunWithLeaderElection(ctx context.Context, client kubernetes.Interface, cfg LeaderElectionConfig, run func(ctx context.Context)) error {
+    if !cfg.Enabled {
+        leaderGauge.Set(1)
+        defer leaderGauge.Set(0)
+        run(ctx)
+        return nil
+    }
+    lock := &resourcelock.LeaseLock{
+        LeaseMeta:  metav1.ObjectMeta{Name: cfg.LeaseName, Namespace: cfg.LeaseNamespace},
+        Client:     client.CoordinationV1(),
+        LockConfig: resourcelock.ResourceLockConfig{Identity: cfg.Identity},
+    }
+    le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+        Lock:            lock,
+        Name:            cfg.LeaseName,
+        LeaseDuration:   cfg.LeaseDuration,
+        RenewDeadline:   cfg.RenewDeadline,
+        RetryPeriod:     cfg.RetryPeriod,
+        ReleaseOnCancel: true,
+        Callbacks: leaderelection.LeaderCallbacks{
+            OnStartedLeading: func(ctx context.Context) {
+                log.Printf("leader election: %s acquired lease %s/%s", cfg.Identity, cfg.LeaseNamespace, cfg.LeaseName)
+                leaderGauge.Set(1)
+                run(ctx)
+            },
+            OnStoppedLeading: func() {
+                leaderGauge.Set(0)
+                log.Printf("leader election: %s stopped leading", cfg.Identity)
+            },
+            OnNewLeader: func(identity string) {
+                if identity != cfg.Identity { log.Printf("leader election: current leader is %s", identity) }
+            },
+        },
+    })
+    if err != nil { return err }
+    log.Printf("leader election: %s waiting for lease %s/%s", cfg.Identity, cfg.LeaseNamespace, cfg.LeaseName)
+    le.Run(ctx)
+    if ctx.Err() == nil {
+        // informers and queues of the lost term cannot be resumed safely; let the pod restart
+        return ErrLeadershipLost
+    }
+    return nil
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunWithLeaderElection_RunsOnLeaderAndReleasesLease(t *testing.T) {
+    client := k8sfake.NewSimpleClientset()
+    cfg := LeaderElectionConfig{
+        Enabled: true, LeaseName: "multinic-controller", LeaseNamespace: "multinic-system", Identity: "controller-0",
+        LeaseDuration: 2 * time.Second, RenewDeadline: time.Second, RetryPeriod: 100 * time.Millisecond,
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    started := make(chan struct{})
+    done := make(chan error, 1)
+    go func() {
+        done <- RunWithLeaderElection(ctx, client, cfg, func(ctx context.Context) {
+            close(started)
+            <-ctx.Done()
+        })
+    }()
+
+    select {
+    case <-started:
+    case <-time.After(5 * time.Second):
+        t.Fatalf("run was not started on the leader")
+    }
+    if v := testutil.ToFloat64(leaderGauge); v != 1 { t.Fatalf("expected leader gauge 1, got %v", v) }
+    lease, err := client.CoordinationV1().Leases("multinic-system").Get(context.Background(), "multinic-controller", metav1.GetOptions{})
+    if err != nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "controller-0" {
+        t.Fatalf("expected lease held by controller-0, got %v (err=%v)", lease, err)
+    }
+
+    cancel()
+    if err := <-done; err != nil { t.Fatalf("unexpected error on shutdown: %v", err) }
+    lease, _ = client.CoordinationV1().Leases("multinic-system").Get(context.Background(), "multinic-controller", metav1.GetOptions{})
+    if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+        t.Fatalf("expected the lease to be released on shutdown, holder=%q", *lease.Spec.HolderIdentity)
+    }
+    if v := testutil.ToFloat64(leaderGauge); v != 0 { t.Fatalf("expected leader gauge 0 after shutdown, got %v", v) }
+}
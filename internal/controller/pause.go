@@ -0,0 +1,98 @@
+package controller
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "strings"
+    "time"
+
+    "multinic-agent/internal/domain/entities"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PausedAnnotation ("true") freezes new agent Jobs for a node config until it is removed
+const PausedAnnotation = "multinic.io/paused"
+
+// ConditionPaused holds a CR in Pending while it is paused or outside its maintenance window
+const ConditionPaused = "Paused"
+
+// Paused condition reasons
+const (
+    PauseReasonAnnotation    = "PausedByAnnotation"
+    PauseReasonOutsideWindow = "OutsideMaintenanceWindow"
+    PauseReasonInvalidWindow = "InvalidMaintenanceWindow"
+)
+
+// RequeueAfter is returned by Reconcile when the CR has to be looked at again at a known time
+// (e.g. when its maintenance window opens). It is not a failure: the watcher requeues the key
+// after Delay without backoff.
+type RequeueAfter struct {
+    Delay  time.Duration
+    Reason string
+}
+
+func (r *RequeueAfter) Error() string {
+    return fmt.Sprintf("requeue after %s: %s", r.Delay, r.Reason)
+}
+
+// pause explains why no Job may start now; resumeAt is zero when only a CR change resumes it
+type pause struct {
+    reason   string
+    message  string
+    resumeAt time.Time
+}
+
+// now returns the controller clock (tests pin it)
+func (c *Controller) now() time.Time {
+    if c.Now != nil { return c.Now() }
+    return time.Now()
+}
+
+// pauseFor는 annotation과 spec.maintenanceWindow를 보고 지금 Job을 만들 수 없으면 이유를 반환한다.
+func (c *Controller) pauseFor(cr *multinicv1alpha1.MultiNicNodeConfig) *pause {
+    if strings.EqualFold(strings.TrimSpace(cr.Annotations[PausedAnnotation]), "true") {
+        return &pause{reason: PauseReasonAnnotation, message: fmt.Sprintf("annotation %s=true", PausedAnnotation)}
+    }
+    mw := cr.Spec.MaintenanceWindow
+    if mw == nil { return nil }
+    window, err := entities.NewMaintenanceWindow(mw.Schedule, mw.Duration, mw.TimeZone)
+    if err != nil {
+        // admission rejects this; a config written before the webhook stays paused until fixed
+        return &pause{reason: PauseReasonInvalidWindow, message: err.Error()}
+    }
+    now := c.now()
+    if window.IsOpen(now) { return nil }
+    next, ok := window.NextOpen(now)
+    if !ok {
+        return &pause{reason: PauseReasonOutsideWindow, message: fmt.Sprintf("maintenance window %s never opens", window)}
+    }
+    return &pause{
+        reason:   PauseReasonOutsideWindow,
+        message:  fmt.Sprintf("maintenance window %s opens at %s", window, next.Format(time.RFC3339)),
+        resumeAt: next,
+    }
+}
+
+// markPaused holds cr in Pending with a Paused condition; observedGeneration is left alone so
+// that the pending change is applied once the pause ends
+func (c *Controller) markPaused(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string, p *pause) {
+    for _, cond := range cr.Status.Conditions {
+        if cond.Type == ConditionPaused && cond.Status == "True" && cond.Reason == p.reason && cond.Message == p.message {
+            return
+        }
+    }
+    log.Printf("[%s/%s] job paused: %s", cr.Namespace, cr.Name, p.message)
+    eventType := corev1.EventTypeNormal
+    if p.reason == PauseReasonInvalidWindow { eventType = corev1.EventTypeWarning }
+    c.recordEvent(cr, nodeName, eventType, p.reason, "job paused: %s", p.message)
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StatePending
+        st.Conditions = append([]multinicv1alpha1.Condition{{Type: ConditionPaused, Status: "True", Reason: p.reason, Message: p.message, LastTransitionTime: &now}}, disruptionConditions(cr.Status.Conditions)...)
+        st.LastUpdated = &now
+    })
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func pausedCondition(t *testing.T, mnc *multinicfake.Clientset, name string) (multinicv1alpha1.NodeConfigState, string) {
+    t.Helper()
+    got, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), name, metav1.GetOptions{})
+    if err != nil { t.Fatalf("get CR: %v", err) }
+    for _, cond := range got.Status.Conditions {
+        if cond.Type == ConditionPaused { return got.Status.State, cond.Reason }
+    }
+    return got.Status.State, ""
+}
+
+func TestReconcile_PausedAnnotationSkipsJob(t *testing.T) {
+    cr := rolloutCR("worker-1", "")
+    cr.Annotations = map[string]string{PausedAnnotation: "true"}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system"}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if jobExists(t, kclient, "worker-1") { t.Fatalf("a paused node config must not get a job") }
+    if state, reason := pausedCondition(t, mnc, "worker-1"); state != multinicv1alpha1.StatePending || reason != PauseReasonAnnotation {
+        t.Fatalf("expected Pending/%s, got %s/%s", PauseReasonAnnotation, state, reason)
+    }
+}
+
+func TestReconcile_MaintenanceWindow(t *testing.T) {
+    cr := rolloutCR("worker-1", "")
+    cr.Spec.MaintenanceWindow = &multinicv1alpha1.MaintenanceWindow{Schedule: "0 22 * * *", Duration: "2h"}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil))
+    now := time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC)
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Now: func() time.Time { return now }}
+
+    // an hour before the window: paused and woken up when it opens
+    err := c.Reconcile(context.Background(), "multinic-system", "worker-1")
+    var requeue *RequeueAfter
+    if !errors.As(err, &requeue) { t.Fatalf("expected RequeueAfter, got %v", err) }
+    if requeue.Delay != time.Hour+time.Second { t.Fatalf("expected the window to open in 1h, got %s", requeue.Delay) }
+    if jobExists(t, kclient, "worker-1") { t.Fatalf("no job may start outside the window") }
+    if _, reason := pausedCondition(t, mnc, "worker-1"); reason != PauseReasonOutsideWindow {
+        t.Fatalf("expected reason %s, got %q", PauseReasonOutsideWindow, reason)
+    }
+
+    now = now.Add(time.Hour + time.Second)
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "worker-1") { t.Fatalf("expected a job once the window is open") }
+    if _, reason := pausedCondition(t, mnc, "worker-1"); reason != "" { t.Fatalf("Paused condition must be cleared, got %q", reason) }
+}
+
+func TestWatcher_RequeueAfterIsNotARetry(t *testing.T) {
+    w := NewWatcher(&Controller{}, "multinic-system")
+    w.Reconcile = func(ctx context.Context, ns, name string) error {
+        return &RequeueAfter{Delay: time.Hour, Reason: "maintenance window"}
+    }
+    w.enqueue("multinic-system", "worker-1")
+    w.processNextItem(context.Background())
+    if got := w.Queue.NumRequeues("multinic-system/worker-1"); got != 0 { t.Fatalf("a scheduled wake-up must not count as a retry, got %d", got) }
+    if w.Queue.Len() != 0 { t.Fatalf("the key must come back only after the delay, got %d queued", w.Queue.Len()) }
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/json"
+    "fmt"
+    "log"
+    "sort"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    "multinic-agent/pkg/generated/clientset/versioned"
+    listers "multinic-agent/pkg/generated/listers/multinic/v1alpha1"
+
+    corev1 "k8s.io/api/core/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/labels"
+    "k8s.io/client-go/tools/cache"
+)
+
+// effectiveConfig is a node config with the matching MultiNicClusterPolicy defaults merged in
+type effectiveConfig struct {
+    Spec     multinicv1alpha1.MultiNicNodeConfigSpec
+    Defaults multinicv1alpha1.PolicyDefaults
+    // Policies are the names of the merged policies, highest priority first
+    Policies []string
+    // Rollout comes from RolloutPolicy, the highest priority policy that sets spec.rollout
+    Rollout       *multinicv1alpha1.RolloutPolicy
+    RolloutPolicy string
+}
+
+// policiesServed reports whether the MultiNicClusterPolicy CRD is installed. Helm does not add
+// new CRDs on upgrade, and an informer for a missing resource would never sync.
+func policiesServed(ctx context.Context, mnc versioned.Interface) bool {
+    _, err := mnc.MultinicV1alpha1().MultiNicClusterPolicies().List(ctx, metav1.ListOptions{Limit: 1})
+    if apierrors.IsNotFound(err) {
+        log.Printf("MultiNicClusterPolicy CRD not installed; cluster policies disabled")
+        return false
+    }
+    return true
+}
+
+// NewPolicyListerFromList builds a policy lister over a fixed list (polling mode has no informer cache)
+func NewPolicyListerFromList(items []multinicv1alpha1.MultiNicClusterPolicy) listers.MultiNicClusterPolicyLister {
+    idx := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+    for i := range items {
+        _ = idx.Add(&items[i])
+    }
+    return listers.NewMultiNicClusterPolicyLister(idx)
+}
+
+// matchingPolicies returns the policies whose nodeSelector matches node, highest priority first
+// (equal priorities are ordered by name so that the merge result is stable)
+func matchingPolicies(lister listers.MultiNicClusterPolicyLister, node *corev1.Node) ([]*multinicv1alpha1.MultiNicClusterPolicy, error) {
+    all, err := lister.List(labels.Everything())
+    if err != nil { return nil, err }
+    var out []*multinicv1alpha1.MultiNicClusterPolicy
+    for _, p := range all {
+        sel, err := metav1.LabelSelectorAsSelector(&p.Spec.NodeSelector)
+        if err != nil {
+            log.Printf("policy %s: invalid nodeSelector (ignored): %v", p.Name, err)
+            continue
+        }
+        if sel.Matches(labels.Set(node.Labels)) {
+            out = append(out, p)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if out[i].Spec.Priority != out[j].Spec.Priority {
+            return out[i].Spec.Priority > out[j].Spec.Priority
+        }
+        return out[i].Name < out[j].Name
+    })
+    return out, nil
+}
+
+// mergeDefaults folds policies (highest priority first) into one set of defaults: each field
+// is taken from the first policy that sets it
+func mergeDefaults(policies []*multinicv1alpha1.MultiNicClusterPolicy) multinicv1alpha1.PolicyDefaults {
+    var d multinicv1alpha1.PolicyDefaults
+    for _, p := range policies {
+        src := p.Spec.Defaults
+        if d.MTU == 0 { d.MTU = src.MTU }
+        if d.InterfacePrefix == "" { d.InterfacePrefix = src.InterfacePrefix }
+        if d.DNS == nil && src.DNS != nil { d.DNS = src.DNS.DeepCopy() }
+        if src.Options == nil { continue }
+        if d.Options == nil { d.Options = &multinicv1alpha1.NetworkOptions{} }
+        o, so := d.Options, src.Options
+        if o.PolicyRoutingEnabled == nil { o.PolicyRoutingEnabled = so.PolicyRoutingEnabled }
+        if o.RoutingTableBase == nil { o.RoutingTableBase = so.RoutingTableBase }
+        if o.RouteMetric == nil { o.RouteMetric = so.RouteMetric }
+        if o.UseNoPrefixRoute == nil { o.UseNoPrefixRoute = so.UseNoPrefixRoute }
+        if o.SetArpSysctls == nil { o.SetArpSysctls = so.SetArpSysctls }
+        if o.SetLooseRPFilter == nil { o.SetLooseRPFilter = so.SetLooseRPFilter }
+    }
+    // the pointers above still alias the listers' cache objects
+    return *d.DeepCopy()
+}
+
+// buildEffectiveConfig applies the policy defaults to the interfaces of cr that leave them unset.
+// Values set on the node config always win over a policy.
+func buildEffectiveConfig(cr *multinicv1alpha1.MultiNicNodeConfig, policies []*multinicv1alpha1.MultiNicClusterPolicy) effectiveConfig {
+    eff := effectiveConfig{Spec: *cr.Spec.DeepCopy(), Defaults: mergeDefaults(policies)}
+    for _, p := range policies {
+        eff.Policies = append(eff.Policies, p.Name)
+        if eff.Rollout == nil && p.Spec.Rollout != nil {
+            eff.Rollout, eff.RolloutPolicy = p.Spec.Rollout.DeepCopy(), p.Name
+        }
+    }
+    for i := range eff.Spec.Interfaces {
+        it := &eff.Spec.Interfaces[i]
+        if it.MTU == 0 { it.MTU = eff.Defaults.MTU }
+        if it.DNS == nil && eff.Defaults.DNS != nil { it.DNS = eff.Defaults.DNS.DeepCopy() }
+    }
+    return eff
+}
+
+// hash returns the status.observedSpecHash of the effective config. Without policies it is the
+// plain spec hash, so configs that were applied before any policy existed are not re-run.
+func (e effectiveConfig) hash(cr *multinicv1alpha1.MultiNicNodeConfig) string {
+    if len(e.Policies) == 0 {
+        return computeSpecHash(cr)
+    }
+    b, err := json.Marshal(struct {
+        Spec     multinicv1alpha1.MultiNicNodeConfigSpec `json:"spec"`
+        Defaults multinicv1alpha1.PolicyDefaults         `json:"defaults"`
+    }{e.Spec, e.Defaults})
+    if err != nil {
+        return ""
+    }
+    sum := sha256.Sum256(b)
+    return fmt.Sprintf("%x", sum)
+}
+
+// specJSON serializes the effective spec for the agent Job ("" when no policy applies:
+// the agent then reads the CR itself)
+func (e effectiveConfig) specJSON() string {
+    if len(e.Policies) == 0 {
+        return ""
+    }
+    b, err := json.Marshal(e.Spec)
+    if err != nil {
+        return ""
+    }
+    return string(b)
+}
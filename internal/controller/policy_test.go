@@ -0,0 +1,189 @@
+package controller
+
+import (
+    "context"
+    "encoding/json"
+    "testing"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func makePolicy(name string, priority int32, selector map[string]string, d multinicv1alpha1.PolicyDefaults) multinicv1alpha1.MultiNicClusterPolicy {
+    return multinicv1alpha1.MultiNicClusterPolicy{
+        ObjectMeta: metav1.ObjectMeta{Name: name},
+        Spec: multinicv1alpha1.MultiNicClusterPolicySpec{
+            NodeSelector: metav1.LabelSelector{MatchLabels: selector},
+            Priority:     priority,
+            Defaults:     d,
+        },
+    }
+}
+
+func jobEnv(job *batchv1.Job, name string) (string, bool) {
+    for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+        if e.Name == name { return e.Value, true }
+    }
+    return "", false
+}
+
+func TestMatchingPolicies_MergeByPriority(t *testing.T) {
+    metric := int32(200)
+    loose := false
+    lister := NewPolicyListerFromList([]multinicv1alpha1.MultiNicClusterPolicy{
+        makePolicy("zone-a", 10, map[string]string{"zone": "a"}, multinicv1alpha1.PolicyDefaults{MTU: 9000, Options: &multinicv1alpha1.NetworkOptions{RouteMetric: &metric}}),
+        makePolicy("base", 0, nil, multinicv1alpha1.PolicyDefaults{MTU: 1450, InterfacePrefix: "eth", Options: &multinicv1alpha1.NetworkOptions{SetLooseRPFilter: &loose}}),
+        makePolicy("zone-b", 20, map[string]string{"zone": "b"}, multinicv1alpha1.PolicyDefaults{MTU: 1400}),
+    })
+    node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"zone": "a"}}}
+
+    policies, err := matchingPolicies(lister, node)
+    if err != nil { t.Fatalf("match error: %v", err) }
+    if len(policies) != 2 || policies[0].Name != "zone-a" || policies[1].Name != "base" {
+        t.Fatalf("expected [zone-a base], got %d policies", len(policies))
+    }
+
+    cr := makeNodeCR("multinic-system", "worker-1", "worker-1", "")
+    cr.Spec.Interfaces = append(cr.Spec.Interfaces, multinicv1alpha1.InterfaceSpec{ID: 2, MacAddress: "02:00:00:00:01:02", MTU: 1500})
+    eff := buildEffectiveConfig(cr, policies)
+    if eff.Spec.Interfaces[0].MTU != 9000 || eff.Spec.Interfaces[1].MTU != 1500 {
+        t.Fatalf("expected policy MTU only for unset entries, got %d/%d", eff.Spec.Interfaces[0].MTU, eff.Spec.Interfaces[1].MTU)
+    }
+    if cr.Spec.Interfaces[0].MTU != 0 { t.Fatalf("node config must not be modified") }
+    o := eff.Defaults.Options
+    if eff.Defaults.InterfacePrefix != "eth" || o == nil || o.RouteMetric == nil || *o.RouteMetric != 200 || o.SetLooseRPFilter == nil || *o.SetLooseRPFilter {
+        t.Fatalf("unexpected merged defaults: %#v", eff.Defaults)
+    }
+    if eff.hash(cr) == computeSpecHash(cr) { t.Fatalf("expected effective hash to differ from the plain spec hash") }
+    if plain := buildEffectiveConfig(cr, nil); plain.hash(cr) != computeSpecHash(cr) || plain.specJSON() != "" {
+        t.Fatalf("without policies the plain spec hash must be kept")
+    }
+}
+
+func TestReconcile_AppliesClusterPolicy(t *testing.T) {
+    ctx := context.Background()
+    metric := int32(300)
+    policy := makePolicy("storage", 0, map[string]string{"role": "storage"}, multinicv1alpha1.PolicyDefaults{
+        MTU:     9000,
+        DNS:     &multinicv1alpha1.DNSSpec{Servers: []string{"10.0.0.53"}},
+        Options: &multinicv1alpha1.NetworkOptions{RouteMetric: &metric},
+    })
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.Generation = 1
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01", Labels: map[string]string{"role": "storage"}}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system",
+        Policies: NewPolicyListerFromList([]multinicv1alpha1.MultiNicClusterPolicy{policy})}
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    cr, _ = mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-node-01", metav1.GetOptions{})
+    if len(cr.Status.AppliedPolicies) != 1 || cr.Status.AppliedPolicies[0] != "storage" {
+        t.Fatalf("expected appliedPolicies [storage], got %v", cr.Status.AppliedPolicies)
+    }
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, cr.Status.LastJobName, metav1.GetOptions{})
+    if err != nil { t.Fatalf("job not found: %v", err) }
+
+    raw, ok := jobEnv(job, "NODE_CONFIG_SPEC")
+    if !ok { t.Fatalf("expected NODE_CONFIG_SPEC env") }
+    var spec multinicv1alpha1.MultiNicNodeConfigSpec
+    if err := json.Unmarshal([]byte(raw), &spec); err != nil { t.Fatalf("invalid spec env: %v", err) }
+    if spec.Interfaces[0].MTU != 9000 || spec.Interfaces[0].DNS == nil || spec.Interfaces[0].DNS.Servers[0] != "10.0.0.53" {
+        t.Fatalf("expected policy defaults in the effective spec, got %#v", spec.Interfaces[0])
+    }
+    if v, _ := jobEnv(job, "NETWORK_ROUTE_METRIC"); v != "300" { t.Fatalf("expected NETWORK_ROUTE_METRIC=300, got %q", v) }
+    if _, ok := jobEnv(job, "NETWORK_ROUTING_TABLE_BASE"); ok { t.Fatalf("unset options must keep the agent default") }
+
+    // a changed policy re-applies a Configured node although its spec did not change
+    cr.Status.State = multinicv1alpha1.StateConfigured
+    if _, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").UpdateStatus(ctx, cr, metav1.UpdateOptions{}); err != nil { t.Fatalf("update status: %v", err) }
+    policy.Spec.Defaults.MTU = 1450
+    c.Policies = NewPolicyListerFromList([]multinicv1alpha1.MultiNicClusterPolicy{policy})
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-node-01", metav1.GetOptions{})
+    if got.Status.State != multinicv1alpha1.StateInProgress || got.Status.LastJobName == job.Name {
+        t.Fatalf("expected a new Job after the policy change, got state=%q job=%q", got.Status.State, got.Status.LastJobName)
+    }
+    if got.Status.Conditions[0].Reason != "PolicyChanged" { t.Fatalf("expected PolicyChanged, got %q", got.Status.Conditions[0].Reason) }
+}
+
+// status.interfaceStatuses use the policy prefix the agent Job names the interfaces with
+func TestInterfaceStatuses_UsePolicyPrefix(t *testing.T) {
+    ctx := context.Background()
+    policy := makePolicy("storage", 0, nil, multinicv1alpha1.PolicyDefaults{InterfacePrefix: "stor"})
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.Generation = 1
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system",
+        Policies: NewPolicyListerFromList([]multinicv1alpha1.MultiNicClusterPolicy{policy})}
+    name := func(when string) {
+        t.Helper()
+        got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-node-01", metav1.GetOptions{})
+        if len(got.Status.InterfaceStatuses) != 1 || got.Status.InterfaceStatuses[0].Name != "stor0" {
+            t.Fatalf("%s: expected interface stor0, got %#v", when, got.Status.InterfaceStatuses)
+        }
+    }
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    name("InProgress")
+
+    cr, _ = mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-node-01", metav1.GetOptions{})
+    job, err := kclient.BatchV1().Jobs("multinic-system").Get(ctx, cr.Status.LastJobName, metav1.GetOptions{})
+    if err != nil { t.Fatalf("job not found: %v", err) }
+    job.Status.Succeeded = 1
+    if err := c.ProcessJob(ctx, "multinic-system", job); err != nil { t.Fatalf("process job error: %v", err) }
+    name("Configured")
+
+    if err := c.updateInterfaceStates(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("update interface states: %v", err) }
+    name("interface check")
+}
+
+// the cleanup Job removes interfaces by the policy prefix, also after the policy was deleted
+func TestLaunchCleanupJob_UsesPolicyPrefix(t *testing.T) {
+    ctx := context.Background()
+    base := int32(2000)
+    policy := makePolicy("storage", 0, nil, multinicv1alpha1.PolicyDefaults{InterfacePrefix: "stor", Options: &multinicv1alpha1.NetworkOptions{RoutingTableBase: &base}})
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.Generation = 1
+    mnc := multinicfake.NewSimpleClientset(cr)
+    kclient := k8sfake.NewSimpleClientset(
+        &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}},
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", NodeCRNamespace: "multinic-system",
+        Policies: NewPolicyListerFromList([]multinicv1alpha1.MultiNicClusterPolicy{policy})}
+    crs := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system")
+    jobs := kclient.BatchV1().Jobs("multinic-system")
+    assertCleanupEnv := func(when string) {
+        t.Helper()
+        job, err := jobs.Get(ctx, cleanupJobName("worker-node-01"), metav1.GetOptions{})
+        if err != nil { t.Fatalf("%s: cleanup job not found: %v", when, err) }
+        if v, _ := jobEnv(job, "INTERFACE_PREFIX"); v != "stor" { t.Fatalf("%s: expected INTERFACE_PREFIX=stor, got %q", when, v) }
+        if v, _ := jobEnv(job, "NETWORK_ROUTING_TABLE_BASE"); v != "2000" { t.Fatalf("%s: expected NETWORK_ROUTING_TABLE_BASE=2000, got %q", when, v) }
+        _ = jobs.Delete(ctx, job.Name, metav1.DeleteOptions{})
+    }
+
+    // a CR never scheduled takes the prefix of the matching policy
+    if err := c.LaunchCleanupJob(ctx, cr, "worker-node-01"); err != nil { t.Fatalf("launch cleanup: %v", err) }
+    assertCleanupEnv("matching policy")
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    cr, _ = crs.Get(ctx, "worker-node-01", metav1.GetOptions{})
+    if cr.Status.AppliedInterfacePrefix != "stor" { t.Fatalf("expected status.appliedInterfacePrefix=stor, got %q", cr.Status.AppliedInterfacePrefix) }
+
+    // the policy is gone by the time the CR is deleted; status still names what was applied
+    c.Policies = NewPolicyListerFromList(nil)
+    now := metav1.Now()
+    cr.DeletionTimestamp = &now
+    if _, err := crs.Update(ctx, cr, metav1.UpdateOptions{}); err != nil { t.Fatalf("update cr: %v", err) }
+    if err := c.Reconcile(ctx, "multinic-system", "worker-node-01"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    assertCleanupEnv("deleted policy")
+}
@@ -4,22 +4,30 @@ import (
     "context"
     "crypto/sha256"
     "encoding/json"
+    "errors"
     "fmt"
     "strings"
+    "sync"
+    "sync/atomic"
     "time"
 
+    "multinic-agent/internal/domain/constants"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    "multinic-agent/pkg/generated/clientset/versioned"
+    typedmultinicv1alpha1 "multinic-agent/pkg/generated/clientset/versioned/typed/multinic/v1alpha1"
+    listers "multinic-agent/pkg/generated/listers/multinic/v1alpha1"
+
+    batchv1 "k8s.io/api/batch/v1"
     corev1 "k8s.io/api/core/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-    "k8s.io/apimachinery/pkg/runtime/schema"
-    "k8s.io/client-go/dynamic"
     "k8s.io/client-go/kubernetes"
+    "k8s.io/client-go/tools/record"
     "log"
 )
 
 // Controller reconciles MultiNicNodeConfig into Jobs per node
 type Controller struct {
-    Dyn              dynamic.Interface
+    MultiNic         versioned.Interface
     Client           kubernetes.Interface
     AgentImage       string
     ImagePullPolicy  corev1.PullPolicy
@@ -27,66 +35,177 @@ type Controller struct {
     NodeCRNamespace  string
     JobTTLSeconds    *int32
     JobDeleteDelaySeconds int // optional grace period before deleting jobs (seconds)
+    // Conflicts blocks Jobs for CRs that reuse another CR's MAC/IP (nil = no cluster-wide check)
+    Conflicts        *ConflictIndex
+    // Policies lists the MultiNicClusterPolicies merged into node configs (nil = policies disabled)
+    Policies         listers.MultiNicClusterPolicyLister
+    // NetworkOptions are the controller's NETWORK_* settings handed to every agent Job
+    // (nil fields keep the agent defaults; cluster policies override them)
+    NetworkOptions   *multinicv1alpha1.NetworkOptions
+    // Recorder emits Events on the CR and its Node for lifecycle transitions (nil = no events)
+    Recorder         record.EventRecorder
+    // Rollout is the controller-wide wave limit (nil = every CR gets its Job at once);
+    // MultiNicClusterPolicy spec.rollout adds a limit per policy
+    Rollout          *multinicv1alpha1.RolloutPolicy
+    // rolloutMu serializes the rollout check with the InProgress write of the admitted CR
+    rolloutMu        sync.Mutex
+    // Now is the clock for maintenance windows (nil = time.Now)
+    Now              func() time.Time
+    // ResyncInterval is how often Configured CRs are checked for drift (0 = never)
+    ResyncInterval   time.Duration
+    // DriftSource is DriftSourceJob (verify Jobs, default) or DriftSourceDaemonSet (agent reports)
+    DriftSource      string
+    // RemediateDrift re-applies a Drifted CR with a new agent Job
+    RemediateDrift   bool
+    // verifyUnsupported is set once the agent image rejected AGENT_ACTION=verify
+    verifyUnsupported atomic.Bool
+}
+
+// nodeConfigs returns the typed MultiNicNodeConfig client for namespace
+func (c *Controller) nodeConfigs(namespace string) typedmultinicv1alpha1.MultiNicNodeConfigInterface {
+    return c.MultiNic.MultinicV1alpha1().MultiNicNodeConfigs(namespace)
 }
 
-var nodeCRGVR = schema.GroupVersionResource{Group: "multinic.io", Version: "v1alpha1", Resource: "multinicnodeconfigs"}
+// jobFailure is one entry of the agent termination summary "failures" list
+type jobFailure struct {
+    ID        int    `json:"id"`
+    MAC       string `json:"mac"`
+    Name      string `json:"name"`
+    ErrorType string `json:"errorType"`
+    Reason    string `json:"reason"`
+}
 
 // Reconcile은 MultiNicNodeConfig 기준으로 Job을 생성하고 CR 상태를 갱신한다.
 func (c *Controller) Reconcile(ctx context.Context, namespace, name string) error {
     // Debug: log.Printf("reconcile: ns=%s name=%s", namespace, name) - removed for cleaner output
-    u, err := c.Dyn.Resource(nodeCRGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+    cr, err := c.nodeConfigs(namespace).Get(ctx, name, metav1.GetOptions{})
     if err != nil {
         log.Printf("reconcile get CR error: %v", err)
         return err
     }
 
-    nodeName := nestedString(u, "spec", "nodeName")
+    // 삭제 중인 CR은 노드 정리(cleanup Job)가 끝난 뒤에만 finalizer를 놓는다
+    if cr.DeletionTimestamp != nil {
+        return c.finalize(ctx, cr)
+    }
+    if cr, err = c.ensureFinalizer(ctx, cr); err != nil { return err }
+
+    nodeName := cr.Spec.NodeName
     if nodeName == "" {
-        nodeName = u.GetName()
+        nodeName = cr.Name
     }
     
     // Detect spec change using metadata.generation vs status.observedGeneration
-    currentState, _, _ := unstructured.NestedString(u.Object, "status", "state")
-    specGen := u.GetGeneration()
-    observedGen, _, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+    currentState := cr.Status.State
+    specGen := cr.Generation
+    observedGen := cr.Status.ObservedGeneration
     specChanged := observedGen == 0 || specGen != observedGen
+
+    // cluster policies select nodes by label, so the Node is needed before the skip check
+    var node *corev1.Node
+    var policies []*multinicv1alpha1.MultiNicClusterPolicy
+    if c.Policies != nil {
+        if node, err = c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+            return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+        }
+        if policies, err = matchingPolicies(c.Policies, node); err != nil { return err }
+    }
+    eff := buildEffectiveConfig(cr, policies)
+    specHash := eff.hash(cr)
+    // adding, changing or removing a matching policy re-applies the node without a spec change
+    policyChanged := (len(policies) > 0 || len(cr.Status.AppliedPolicies) > 0) && specHash != cr.Status.ObservedSpecHash
+
+    // a Drifted CR is re-applied unchanged only when drift remediation is on
+    remediate := !specChanged && !policyChanged && c.remediating(cr)
+
     // If already in final state and spec hasn't changed, skip scheduling
-    if (currentState == "Configured" || currentState == "Failed") && !specChanged {
+    settled := currentState == multinicv1alpha1.StateConfigured || currentState == multinicv1alpha1.StateFailed || currentState == multinicv1alpha1.StateDrifted
+    if settled && !specChanged && !policyChanged && !remediate {
         // Debug: log.Printf("[%s] Already %s - skipping", name, currentState)
         return nil
     }
+
+    // 일시 중지 annotation 또는 유지보수 창 밖: 변경은 Pending으로 보류하고 창이 열릴 때 다시 처리한다
+    if p := c.pauseFor(cr); p != nil {
+        c.markPaused(ctx, cr, nodeName, p)
+        if p.resumeAt.IsZero() { return nil }
+        // a second of slack so that the window is open when the key comes back
+        return &RequeueAfter{Delay: p.resumeAt.Sub(c.now()) + time.Second, Reason: p.message}
+    }
     
+    // 다른 CR이 먼저 선점한 MAC/IP를 쓰는 CR은 충돌이 해소될 때까지 Job을 만들지 않는다
+    if c.Conflicts != nil {
+        if !c.Conflicts.Synced() {
+            // the initial pass after cache sync reconciles every CR again
+            return nil
+        }
+        if cerr := c.Conflicts.Check(cr); cerr != nil {
+            c.markConflict(ctx, cr, cerr)
+            return nil
+        }
+    }
+
     // Log interface details only when first processing (not on subsequent updates)
     if currentState == "" && observedGen == 0 {
-        c.logInterfaceDetails(u, nodeName)
+        c.logInterfaceDetails(cr, nodeName)
     }
     
-    // instance-id verification via spec.instanceId or label
-    instanceID := nestedString(u, "spec", "instanceId")
-    if instanceID == "" {
-        instanceID = u.GetLabels()["multinic.io/instance-id"]
+    if node == nil {
+        if node, err = c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+            return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+        }
     }
 
-    node, err := c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
-    if err != nil {
-        return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+    // Verify mapping if label provided
+    if !instanceIDMatches(cr, node) {
+        instanceID := instanceIDOf(cr)
+        c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonInstanceIDMismatch,
+            "instance-id %s does not match node systemUUID %s; no job scheduled", instanceID, node.Status.NodeInfo.SystemUUID)
+        return fmt.Errorf("instance-id mismatch: cr=%s node=%s", instanceID, node.Status.NodeInfo.SystemUUID)
+    }
+
+    // 롤아웃 웨이브: 슬롯이 없거나 실패 임계치에 걸렸으면 Pending으로 대기한다.
+    // 잠금은 슬롯을 차지하는 InProgress 기록까지만 유지한다 (Job 생성/정리는 잠금 밖에서)
+    unlock := func() {}
+    defer func() { unlock() }()
+    if scopes := c.rolloutScopes(eff); len(scopes) > 0 {
+        c.rolloutMu.Lock()
+        unlock = c.rolloutMu.Unlock
+        wait, err := c.rolloutGate(ctx, cr, node, scopes)
+        if err != nil { return err }
+        if wait != nil {
+            c.markRolloutWaiting(ctx, cr, nodeName, wait)
+            return nil
+        }
     }
 
-    // Verify mapping if label provided
-    if instanceID != "" {
-        sysUUID := normalizeUUID(node.Status.NodeInfo.SystemUUID)
-        if normalizeUUID(instanceID) != sysUUID {
-            return fmt.Errorf("instance-id mismatch: cr=%s node=%s", instanceID, node.Status.NodeInfo.SystemUUID)
+    // spec.disruptionPolicy: 트래픽을 끊을 수 있는 변경이면 Job 전에 노드를 cordon하고 선택된 Pod를 evict한다
+    planned := plannedInterfaces(eff)
+    var disruption []multinicv1alpha1.Condition
+    if dp := cr.Spec.DisruptionPolicy; dp != nil && (dp.Cordon || dp.EvictPodSelector != nil) {
+        if reasons := predictDisruption(cr.Status.AppliedInterfaces, planned, interfacePrefixOf(eff)); len(reasons) > 0 {
+            conds, ready, err := c.prepareDisruption(ctx, cr, node, reasons)
+            if err != nil { return err }
+            if !ready { return &RequeueAfter{Delay: drainPollInterval, Reason: "waiting for evicted pods"} }
+            disruption = conds
         }
     }
+    plannedJSON, _ := json.Marshal(planned)
 
     osImage := node.Status.NodeInfo.OSImage
 
     // Use generation-aware job name to avoid collisions with stale jobs
     gen := specGen
+    jobName := fmt.Sprintf("multinic-agent-%s-g%d", nodeName, gen)
+    if (len(policies) > 0 || len(cr.Status.AppliedPolicies) > 0) && len(specHash) >= 8 {
+        // a policy change keeps the generation; the effective spec hash keeps the name unique
+        jobName += "-" + specHash[:8]
+    }
+    if remediate { jobName = c.remediationJobName(cr, jobName) }
+    jobOptions := overlayOptions(c.NetworkOptions, eff.Defaults.Options)
     job := BuildAgentJob(osImage, JobParams{
         Namespace:          namespace,
-        Name:               fmt.Sprintf("multinic-agent-%s-g%d", nodeName, gen),
+        Name:               jobName,
         Image:              c.AgentImage,
         PullPolicy:         c.ImagePullPolicy,
         ServiceAccountName: c.ServiceAccount,
@@ -94,23 +213,32 @@ func (c *Controller) Reconcile(ctx context.Context, namespace, name string) erro
         NodeCRNamespace:    c.NodeCRNamespace,
         TTLSecondsAfterDone: c.JobTTLSeconds,
         Action:             "", // default apply
+        InterfacePrefix:    eff.Defaults.InterfacePrefix,
+        NodeConfigSpec:     eff.specJSON(),
+        Options:            jobOptions,
+        OwnerReferences:    []metav1.OwnerReference{nodeConfigOwnerRef(cr)},
+        Annotations:        map[string]string{AppliedInterfacesAnnotation: string(plannedJSON)},
     })
 
     // Mark CR as InProgress with interface details and record observedGeneration/spec hash
-    reason := "JobScheduled"
-    if specChanged { reason = "SpecChanged" }
-    interfaceStatuses := c.buildInterfaceStatuses(u, nodeName, "InProgress", reason)
-    _ = c.updateCRStatus(ctx, u, map[string]any{
-        "state":              "InProgress",
-        "observedGeneration": specGen,
-        "observedSpecHash":   computeSpecHash(u),
-        "lastJobName":        job.Name,
-        "conditions": []any{
-            map[string]any{"type": "InProgress", "status": "True", "reason": reason},
-        },
-        "interfaceStatuses": interfaceStatuses,
-        "lastUpdated": time.Now().Format(time.RFC3339),
+    reason := EventReasonJobScheduled
+    if specChanged { reason = EventReasonSpecChanged } else if policyChanged { reason = EventReasonPolicyChanged } else if remediate { reason = EventReasonDriftRemediation }
+    interfaceStatuses := c.buildInterfaceStatuses(cr, interfacePrefixOf(eff), nodeName, "InProgress", reason)
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StateInProgress
+        st.ObservedGeneration = specGen
+        st.ObservedSpecHash = specHash
+        st.LastJobName = job.Name
+        st.AppliedPolicies = eff.Policies
+        st.AppliedInterfacePrefix = interfacePrefixOf(eff)
+        st.AppliedOptions = jobOptions.DeepCopy()
+        st.Conditions = append([]multinicv1alpha1.Condition{{Type: "InProgress", Status: "True", Reason: reason}}, disruption...)
+        st.InterfaceStatuses = interfaceStatuses
+        st.LastUpdated = &now
     })
+    unlock()
+    unlock = func() {}
 
     // If a job with the same generation-aware name exists, skip creating
     if _, err := c.Client.BatchV1().Jobs(namespace).Get(ctx, job.Name, metav1.GetOptions{}); err == nil {
@@ -122,6 +250,7 @@ func (c *Controller) Reconcile(ctx context.Context, namespace, name string) erro
         return err
     }
     log.Printf("job created: %s/%s for node=%s osImage=%s", namespace, job.Name, nodeName, osImage)
+    c.recordEvent(cr, nodeName, corev1.EventTypeNormal, reason, "scheduled agent job %s (generation %d)", job.Name, specGen)
 
     // Proactively delete stale jobs for this node (different name)
     jobs, _ := c.Client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=multinic-agent,multinic.io/node-name=" + nodeName})
@@ -133,12 +262,36 @@ func (c *Controller) Reconcile(ctx context.Context, namespace, name string) erro
     return nil
 }
 
-func nestedString(u *unstructured.Unstructured, fields ...string) string {
-    v, found, _ := unstructured.NestedString(u.Object, fields...)
-    if !found {
-        return ""
+// markConflict holds cr in Pending with a Conflict condition. observedGeneration is left
+// untouched so that the CR is scheduled once the conflict is gone.
+func (c *Controller) markConflict(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, cerr error) {
+    msg := cerr.Error()
+    for _, cond := range cr.Status.Conditions {
+        if cond.Type == ConditionConflict && cond.Status == "True" && cond.Message == msg {
+            // already reported; skip the write so the status update does not retrigger us
+            return
+        }
     }
-    return v
+    log.Printf("[%s/%s] job blocked: %s", cr.Namespace, cr.Name, msg)
+    c.recordEvent(cr, "", corev1.EventTypeWarning, EventReasonAddressConflict, "job blocked: %s", msg)
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StatePending
+        st.Conditions = append([]multinicv1alpha1.Condition{{Type: ConditionConflict, Status: "True", Reason: "AddressConflict", Message: msg, LastTransitionTime: &now}}, disruptionConditions(cr.Status.Conditions)...)
+        st.LastUpdated = &now
+    })
+}
+
+// instanceIDOf returns the instance-id cr was written for: spec.instanceId, else the label
+func instanceIDOf(cr *multinicv1alpha1.MultiNicNodeConfig) string {
+    if cr.Spec.InstanceID != "" { return cr.Spec.InstanceID }
+    return cr.Labels["multinic.io/instance-id"]
+}
+
+// instanceIDMatches reports whether node is the machine cr was written for (no instance-id matches any node)
+func instanceIDMatches(cr *multinicv1alpha1.MultiNicNodeConfig, node *corev1.Node) bool {
+    id := instanceIDOf(cr)
+    return id == "" || normalizeUUID(id) == normalizeUUID(node.Status.NodeInfo.SystemUUID)
 }
 
 func normalizeUUID(s string) string {
@@ -148,13 +301,15 @@ func normalizeUUID(s string) string {
 
 // ProcessAll은 전체 CR을 순회하며 Job 생성과 상태 갱신을 수행한다.
 func (c *Controller) ProcessAll(ctx context.Context, namespace string) error {
-    list, err := c.Dyn.Resource(nodeCRGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+    list, err := c.nodeConfigs(namespace).List(ctx, metav1.ListOptions{})
     if err != nil { return err }
     for i := range list.Items {
-        name := list.Items[i].GetName()
+        name := list.Items[i].Name
         // Debug: processAll reconcile removed for cleaner output
         if err := c.Reconcile(ctx, namespace, name); err != nil {
-            return err
+            // paused CRs are looked at again by the next poll
+            var requeue *RequeueAfter
+            if !errors.As(err, &requeue) { return err }
         }
         
         // Also update interface states to keep CR status current
@@ -171,253 +326,166 @@ func (c *Controller) ProcessJobs(ctx context.Context, namespace string) error {
     jobs, err := c.Client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: "app.kubernetes.io/name=multinic-agent"})
     if err != nil { return err }
     for i := range jobs.Items {
-        job := &jobs.Items[i]
-        // Skip cleanup jobs for CR status updates; cleanup success/failure should not mark CR Failed/Configured
-        if action := job.Labels["multinic.io/action"]; strings.EqualFold(strings.TrimSpace(action), "cleanup") {
-            continue
+        if err := c.ProcessJob(ctx, namespace, &jobs.Items[i]); err != nil { return err }
+    }
+    return nil
+}
+
+// ProcessJob은 agent Job 하나의 완료 상태를 해당 노드 CR 상태(Configured/Failed)에 반영한다.
+func (c *Controller) ProcessJob(ctx context.Context, namespace string, job *batchv1.Job) error {
+    // Skip cleanup jobs for CR status updates; cleanup success/failure should not mark CR Failed/Configured
+    if action := job.Labels["multinic.io/action"]; strings.EqualFold(strings.TrimSpace(action), "cleanup") {
+        return nil
+    }
+    if job.Labels["multinic.io/action"] == "verify" { return c.processVerifyJob(ctx, namespace, job) }
+    nodeName := job.Labels["multinic.io/node-name"]
+    if nodeName == "" { return nil }
+    cr, err := c.nodeConfigs(namespace).Get(ctx, nodeName, metav1.GetOptions{})
+    action := job.Labels["multinic.io/action"]
+    if err != nil {
+        // CR missing (e.g., during cleanup). If cleanup job finished, delete it.
+        if action == "cleanup" {
+            if job.Status.Succeeded > 0 || job.Status.Failed > 0 {
+                c.scheduleJobDeletion(ctx, namespace, job.Name)
+            }
         }
-        nodeName := job.Labels["multinic.io/node-name"]
-        if nodeName == "" { continue }
-        u, err := c.Dyn.Resource(nodeCRGVR).Namespace(namespace).Get(ctx, nodeName, metav1.GetOptions{})
-        action := job.Labels["multinic.io/action"]
-        if err != nil {
-            // CR missing (e.g., during cleanup). If cleanup job finished, delete it.
+        return nil
+    }
+    // only the Job the CR last scheduled reports on it: an older generation's Job kept by the
+    // delete delay would otherwise mark a newer spec Configured with its own results
+    if cr.Status.LastJobName != "" && job.Name != cr.Status.LastJobName { return nil }
+
+    // Determine completion state
+    currentState := cr.Status.State
+    // a remediation Job moves the CR to InProgress first: an apply Job seen while Drifted is one
+    // that already ran (kept by the delete delay) and says nothing about the drift
+    if currentState == multinicv1alpha1.StateDrifted { return nil }
+    // statuses are named the way this Job named the interfaces (a policy may set the prefix)
+    prefix := jobInterfacePrefix(job)
+    if job.Status.Succeeded > 0 {
+        if currentState != multinicv1alpha1.StateConfigured {
+            action := job.Labels["multinic.io/action"]
             if action == "cleanup" {
-                if job.Status.Succeeded > 0 || job.Status.Failed > 0 {
-                    c.scheduleJobDeletion(ctx, namespace, job.Name)
-                }
+                // Cleanup job succeeded: do not overwrite CR state; just delete the job
+                log.Printf("cleanup job succeeded: %s/%s", namespace, job.Name)
+                c.scheduleJobDeletion(ctx, namespace, job.Name)
+                return nil
             }
-            continue
-        }
-
-        // Determine completion state
-        currentState, _, _ := unstructured.NestedString(u.Object, "status", "state")
-        if job.Status.Succeeded > 0 {
-            if currentState != "Configured" {
-                action := job.Labels["multinic.io/action"]
-                if action == "cleanup" {
-                    // Cleanup job succeeded: do not overwrite CR state; just delete the job
-                    log.Printf("cleanup job succeeded: %s/%s", namespace, job.Name)
-                    c.scheduleJobDeletion(ctx, namespace, job.Name)
-                    continue
-                }
-                log.Printf("job succeeded: %s/%s", namespace, job.Name)
-                // 종료 메시지(요약) 파싱: 실패가 있으면 부분 실패로 처리
-                handledPartial := false
-                if msg := c.getJobTerminationMessage(ctx, namespace, job.Name); strings.TrimSpace(msg) != "" {
-                    c.logJobSummary(msg)
-                    type failure struct { ID int `json:"id"`; MAC, Name, ErrorType, Reason string }
-                    var sum struct { Failures []failure `json:"failures"` }
-                    if err := json.Unmarshal([]byte(msg), &sum); err == nil && len(sum.Failures) > 0 {
-                        // 실패 목록 존재 → per-interface 상태 갱신, 전체는 Failed(JobFailedPartial)
-                        statuses := []any{}
-                        // build maps by id/mac
-                        failByID := map[int]failure{}
-                        failByMAC := map[string]failure{}
-                        for _, f := range sum.Failures { failByID[f.ID] = f; if strings.TrimSpace(f.MAC) != "" { failByMAC[strings.ToLower(strings.TrimSpace(f.MAC))] = f } }
-                        ifaces, found, _ := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-                        if found {
-                            statuses = make([]any, 0, len(ifaces))
-                            for i := range ifaces {
-                                ifaceMap, _ := ifaces[i].(map[string]any)
-                                name := fmt.Sprintf("multinic%d", i)
-                                id := getIntFromMap(ifaceMap, "id")
-                                mac := strings.ToLower(getStringFromMap(ifaceMap, "macAddress"))
-                                if f, ok := failByID[id]; ok && id != 0 {
-                                    statuses = append(statuses, map[string]any{
-                                        "name":           name,
-                                        "interfaceIndex": int64(i),
-                                        "id":             int64(f.ID),
-                                        "macAddress":     mac,
-                                        "status":         "Failed",
-                                        "reason":         "JobFailedPartial",
-                                        "message":        f.Reason,
-                                        "lastUpdated":    time.Now().Format(time.RFC3339),
-                                    })
-                                } else if f, ok := failByMAC[mac]; ok && mac != "" {
-                                    statuses = append(statuses, map[string]any{
-                                        "name":           name,
-                                        "interfaceIndex": int64(i),
-                                        "id":             int64(f.ID),
-                                        "macAddress":     mac,
-                                        "status":         "Failed",
-                                        "reason":         "JobFailedPartial",
-                                        "message":        f.Reason,
-                                        "lastUpdated":    time.Now().Format(time.RFC3339),
-                                    })
-                                } else {
-                                    statuses = append(statuses, map[string]any{
-                                        "name":           name,
-                                        "interfaceIndex": int64(i),
-                                        "id":             int64(id),
-                                        "macAddress":     mac,
-                                        "status":         "Configured",
-                                        "reason":         "JobSucceeded",
-                                        "lastUpdated":    time.Now().Format(time.RFC3339),
-                                    })
-                                }
-                            }
-                        }
-                        _ = c.updateCRStatus(ctx, u, map[string]any{
-                            "state": "Failed",
-                            "conditions": []any{ map[string]any{"type": "Ready", "status": "False", "reason": "JobFailedPartial"} },
-                            "interfaceStatuses": statuses,
-                            "lastUpdated": time.Now().Format(time.RFC3339),
-                        })
-                        handledPartial = true
-                    }
-                }
-                if !handledPartial {
-                    // 완전 성공 케이스: termination results가 있으면 실제 이름으로 반영
-                    statuses := []any{}
-                    usedResults := false
-                    if msg := c.getJobTerminationMessage(ctx, namespace, job.Name); strings.TrimSpace(msg) != "" {
-                        type result struct { ID int `json:"id"`; MAC, Name, Status string }
-                        var sum struct { Results []result `json:"results"` }
-                        if err := json.Unmarshal([]byte(msg), &sum); err == nil && len(sum.Results) > 0 {
-                            statuses = make([]any, 0, len(sum.Results))
-                            // spec 참조해 address/cidr/mtu 채우기
-                            ifaces, found, _ := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-                            for _, r := range sum.Results {
-                                name := strings.TrimSpace(r.Name)
-                                // 기본 필드
-                                st := map[string]any{
-                                    "name":         name,
-                                    "id":           int64(r.ID),
-                                    "macAddress":   strings.ToLower(strings.TrimSpace(r.MAC)),
-                                    "status":       "Configured",
-                                    "reason":       "JobSucceeded",
-                                    "lastUpdated":  time.Now().Format(time.RFC3339),
-                                }
-                                // spec에서 address/cidr/mtu 채움
-                                if found {
-                                    for i, it := range ifaces {
-                                        m, _ := it.(map[string]any)
-                                        id := getIntFromMap(m, "id")
-                                        mac := strings.ToLower(getStringFromMap(m, "macAddress"))
-                                        if id == r.ID || (mac != "" && mac == strings.ToLower(strings.TrimSpace(r.MAC))) {
-                                            st["interfaceIndex"] = int64(i)
-                                            st["address"] = getStringFromMap(m, "address")
-                                            st["cidr"] = getStringFromMap(m, "cidr")
-                                            st["mtu"] = int64(getIntFromMap(m, "mtu"))
-                                            if name == "" {
-                                                name = fmt.Sprintf("multinic%d", i)
-                                                st["name"] = name
-                                            }
-                                            break
-                                        }
-                                    }
-                                }
-                                if name == "" {
-                                    st["name"] = fmt.Sprintf("multinic%d", len(statuses))
-                                }
-                                statuses = append(statuses, st)
-                            }
-                            usedResults = true
-                        }
-                    }
-                    if !usedResults {
-                        statuses = c.buildInterfaceStatuses(u, nodeName, "Configured", "JobSucceeded")
-                    }
-                    _ = c.updateCRStatus(ctx, u, map[string]any{
-                        "state": "Configured",
-                        "conditions": []any{ map[string]any{"type": "Ready", "status": "True", "reason": "JobSucceeded"} },
-                        "interfaceStatuses": statuses,
-                        "lastUpdated": time.Now().Format(time.RFC3339),
+            log.Printf("job succeeded: %s/%s", namespace, job.Name)
+            // 종료 메시지(요약) 파싱: 실패가 있으면 부분 실패로 처리
+            handledPartial := false
+            if msg := c.getJobTerminationMessage(ctx, namespace, job.Name); strings.TrimSpace(msg) != "" {
+                c.logJobSummary(msg)
+                var sum struct { Failures []jobFailure `json:"failures"` }
+                if err := json.Unmarshal([]byte(msg), &sum); err == nil && len(sum.Failures) > 0 {
+                    // 실패 목록 존재 → per-interface 상태 갱신, 전체는 Failed(JobFailedPartial)
+                    statuses := failureInterfaceStatuses(cr, prefix, sum.Failures, "JobFailedPartial", "JobSucceeded")
+                    c.recordEvent(cr, nodeName, corev1.EventTypeWarning, EventReasonJobFailedPartial,
+                        "job %s finished with %d of %d interfaces failed", job.Name, len(sum.Failures), len(cr.Spec.Interfaces))
+                    c.recordInterfaceFailures(cr, nodeName, job.Name, sum.Failures)
+                    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+                        now := metav1.Now()
+                        st.State = multinicv1alpha1.StateFailed
+                        // the node stays cordoned until a later Job succeeds
+                        st.Conditions = append([]multinicv1alpha1.Condition{{Type: "Ready", Status: "False", Reason: "JobFailedPartial"}}, disruptionConditions(cr.Status.Conditions)...)
+                        st.InterfaceStatuses = statuses
+                        st.LastUpdated = &now
                     })
+                    handledPartial = true
                 }
-                // cleanup of succeeded job (optionally delay for log scraping)
-                c.scheduleJobDeletion(ctx, namespace, job.Name)
             }
-        } else if job.Status.Failed > 0 {
-            if currentState != "Failed" {
-                log.Printf("job failed: %s/%s", namespace, job.Name)
-                // 종료 메시지(요약)에서 실패한 인터페이스 상세를 로그로 남김 및 per-interface 상태 반영
-                reason := "JobFailed"
-                statuses := []any{}
+            if !handledPartial {
+                // 완전 성공 케이스: termination results가 있으면 실제 이름으로 반영
+                var statuses []multinicv1alpha1.InterfaceStatus
+                usedResults := false
                 if msg := c.getJobTerminationMessage(ctx, namespace, job.Name); strings.TrimSpace(msg) != "" {
-                    c.logJobSummary(msg)
-                    // Try to parse JSON summary and compute per-interface statuses
-                    type failure struct {
-                        ID        int    `json:"id"`
-                        MAC       string `json:"mac"`
-                        Name      string `json:"name"`
-                        ErrorType string `json:"errorType"`
-                        Reason    string `json:"reason"`
-                    }
-                    var sum struct { Failures []failure `json:"failures"` }
-                    if err := json.Unmarshal([]byte(msg), &sum); err == nil && len(sum.Failures) > 0 {
-                        // Build lookup maps by ID and MAC (normalized)
-                        failByID := map[int]failure{}
-                        failByMAC := map[string]failure{}
-                        for _, f := range sum.Failures {
-                            failByID[f.ID] = f
-                            if strings.TrimSpace(f.MAC) != "" {
-                                failByMAC[strings.ToLower(strings.TrimSpace(f.MAC))] = f
+                    type result struct { ID int `json:"id"`; MAC, Name, Status string }
+                    var sum struct { Results []result `json:"results"` }
+                    if err := json.Unmarshal([]byte(msg), &sum); err == nil && len(sum.Results) > 0 {
+                        statuses = make([]multinicv1alpha1.InterfaceStatus, 0, len(sum.Results))
+                        for _, r := range sum.Results {
+                            now := metav1.Now()
+                            mac := strings.ToLower(strings.TrimSpace(r.MAC))
+                            // 기본 필드
+                            st := multinicv1alpha1.InterfaceStatus{
+                                Name:        strings.TrimSpace(r.Name),
+                                ID:          int64(r.ID),
+                                MacAddress:  mac,
+                                Status:      "Configured",
+                                Reason:      "JobSucceeded",
+                                LastUpdated: &now,
                             }
-                        }
-                        // Enumerate spec interfaces and map by id/MAC (more reliable than name)
-                        ifaces, found, _ := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-                        if found {
-                            statuses = make([]any, 0, len(ifaces))
-                            for i := range ifaces {
-                                ifaceMap, _ := ifaces[i].(map[string]any)
-                                name := fmt.Sprintf("multinic%d", i)
-                                id := getIntFromMap(ifaceMap, "id")
-                                mac := strings.ToLower(getStringFromMap(ifaceMap, "macAddress"))
-                                if f, ok := failByID[id]; ok && id != 0 {
-                                    statuses = append(statuses, map[string]any{
-                                        "name":           name,
-                                        "interfaceIndex": int64(i),
-                                        "id":             int64(f.ID),
-                                        "macAddress":     mac,
-                                        "status":         "Failed",
-                                        "reason":         "JobFailed",
-                                        "message":        f.Reason,
-                                        "lastUpdated":    time.Now().Format(time.RFC3339),
-                                    })
-                                } else if f, ok := failByMAC[mac]; ok && mac != "" {
-                                    statuses = append(statuses, map[string]any{
-                                        "name":           name,
-                                        "interfaceIndex": int64(i),
-                                        "id":             int64(f.ID),
-                                        "macAddress":     mac,
-                                        "status":         "Failed",
-                                        "reason":         "JobFailed",
-                                        "message":        f.Reason,
-                                        "lastUpdated":    time.Now().Format(time.RFC3339),
-                                    })
-                                } else {
-                                    // Treat others as configured in a partial failure scenario
-                                    statuses = append(statuses, map[string]any{
-                                        "name":           name,
-                                        "interfaceIndex": int64(i),
-                                        "id":             int64(id),
-                                        "macAddress":     mac,
-                                        "status":         "Configured",
-                                        "reason":         "JobPartialSuccess",
-                                        "lastUpdated":    time.Now().Format(time.RFC3339),
-                                    })
+                            // spec에서 address/cidr/mtu 채움
+                            for i, it := range cr.Spec.Interfaces {
+                                if int(it.ID) == r.ID || (it.MacAddress != "" && strings.ToLower(it.MacAddress) == mac) {
+                                    st.InterfaceIndex = int64(i)
+                                    st.Address = it.Address
+                                    st.CIDR = it.CIDR
+                                    st.MTU = int64(it.MTU)
+                                    if st.Name == "" {
+                                        st.Name = fmt.Sprintf("%s%d", prefix, i)
+                                    }
+                                    break
                                 }
                             }
-                            if len(sum.Failures) < len(ifaces) { reason = "JobFailedPartial" }
+                            if st.Name == "" {
+                                st.Name = fmt.Sprintf("%s%d", prefix, len(statuses))
+                            }
+                            statuses = append(statuses, st)
                         }
+                        usedResults = true
                     }
                 }
-                // Fallback: if we couldn't compute per-interface, mark all as Failed
-                if len(statuses) == 0 {
-                    statuses = c.buildInterfaceStatuses(u, nodeName, "Failed", reason)
+                if !usedResults {
+                    statuses = c.buildInterfaceStatuses(cr, prefix, nodeName, "Configured", "JobSucceeded")
                 }
-                statusPatch := map[string]any{
-                    "state": "Failed",
-                    "conditions": []any{ map[string]any{"type": "Ready", "status": "False", "reason": reason} },
-                    "lastUpdated": time.Now().Format(time.RFC3339),
-                    "interfaceStatuses": statuses,
+                c.recordEvent(cr, nodeName, corev1.EventTypeNormal, EventReasonJobSucceeded, "job %s configured %d interfaces", job.Name, len(statuses))
+                disruption := c.releaseDisruption(ctx, cr, nodeName)
+                _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+                    now := metav1.Now()
+                    st.State = multinicv1alpha1.StateConfigured
+                    st.Conditions = append([]multinicv1alpha1.Condition{{Type: "Ready", Status: "True", Reason: "JobSucceeded"}}, disruption...)
+                    st.InterfaceStatuses = statuses
+                    if applied := appliedInterfacesOf(job.Annotations); applied != nil { st.AppliedInterfaces = applied }
+                    st.LastUpdated = &now
+                })
+            }
+            // cleanup of succeeded job (optionally delay for log scraping)
+            c.scheduleJobDeletion(ctx, namespace, job.Name)
+        }
+    } else if job.Status.Failed > 0 {
+        if currentState != multinicv1alpha1.StateFailed {
+            log.Printf("job failed: %s/%s", namespace, job.Name)
+            // 종료 메시지(요약)에서 실패한 인터페이스 상세를 로그로 남김 및 per-interface 상태 반영
+            reason := EventReasonJobFailed
+            var failures []jobFailure
+            var statuses []multinicv1alpha1.InterfaceStatus
+            if msg := c.getJobTerminationMessage(ctx, namespace, job.Name); strings.TrimSpace(msg) != "" {
+                c.logJobSummary(msg)
+                // Try to parse JSON summary and compute per-interface statuses
+                var sum struct { Failures []jobFailure `json:"failures"` }
+                if err := json.Unmarshal([]byte(msg), &sum); err == nil && len(sum.Failures) > 0 {
+                    // Map spec interfaces by id/MAC (more reliable than name)
+                    statuses = failureInterfaceStatuses(cr, prefix, sum.Failures, "JobFailed", "JobPartialSuccess")
+                    if len(cr.Spec.Interfaces) > 0 && len(sum.Failures) < len(cr.Spec.Interfaces) { reason = EventReasonJobFailedPartial }
+                    failures = sum.Failures
                 }
-                _ = c.updateCRStatus(ctx, u, statusPatch)
-                // Cleanup failed job as well (optionally delay)
-                c.scheduleJobDeletion(ctx, namespace, job.Name)
             }
+            c.recordEvent(cr, nodeName, corev1.EventTypeWarning, reason, "job %s failed", job.Name)
+            c.recordInterfaceFailures(cr, nodeName, job.Name, failures)
+            // Fallback: if we couldn't compute per-interface, mark all as Failed
+            if len(statuses) == 0 {
+                statuses = c.buildInterfaceStatuses(cr, prefix, nodeName, "Failed", reason)
+            }
+            _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+                now := metav1.Now()
+                st.State = multinicv1alpha1.StateFailed
+                st.Conditions = append([]multinicv1alpha1.Condition{{Type: "Ready", Status: "False", Reason: reason}}, disruptionConditions(cr.Status.Conditions)...)
+                st.LastUpdated = &now
+                st.InterfaceStatuses = statuses
+            })
+            // Cleanup failed job as well (optionally delay)
+            c.scheduleJobDeletion(ctx, namespace, job.Name)
         }
     }
     return nil
@@ -499,7 +567,10 @@ func (c *Controller) DeleteJobForNode(ctx context.Context, namespace, nodeName s
 
 // LaunchCleanupJob creates a cleanup-mode job for the given node
 // LaunchCleanupJob은 CR 삭제 시 인터페이스 정리를 위한 cleanup Job을 실행한다.
-func (c *Controller) LaunchCleanupJob(ctx context.Context, namespace, nodeName string) error {
+// foreground 삭제에서 CR보다 먼저 지워지지 않도록 cleanup Job에는 ownerReference를 두지 않는다.
+// 인터페이스 prefix와 옵션은 마지막 Job이 status에 남긴 값을 쓰므로, 그 사이 정책이 바뀌거나 지워져도 인터페이스가 남지 않는다.
+func (c *Controller) LaunchCleanupJob(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string) error {
+    namespace := cr.Namespace
     log.Printf("LaunchCleanupJob: starting cleanup job launch for node=%s namespace=%s", nodeName, namespace)
     
     node, err := c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
@@ -509,10 +580,20 @@ func (c *Controller) LaunchCleanupJob(ctx context.Context, namespace, nodeName s
     }
     osImage := node.Status.NodeInfo.OSImage
     log.Printf("LaunchCleanupJob: node=%s osImage=%s", nodeName, osImage)
+
+    var policies []*multinicv1alpha1.MultiNicClusterPolicy
+    if c.Policies != nil {
+        if policies, err = matchingPolicies(c.Policies, node); err != nil { return err }
+    }
+    eff := buildEffectiveConfig(cr, policies)
+    prefix, opts := interfacePrefixOf(eff), overlayOptions(c.NetworkOptions, eff.Defaults.Options)
+    if cr.Status.AppliedInterfacePrefix != "" {
+        prefix, opts = cr.Status.AppliedInterfacePrefix, cr.Status.AppliedOptions
+    }
     
     // use a distinct name to avoid colliding with the apply job
-    cleanupName := fmt.Sprintf("multinic-agent-cleanup-%s", nodeName)
-    log.Printf("LaunchCleanupJob: building job with name=%s", cleanupName)
+    cleanupName := cleanupJobName(nodeName)
+    log.Printf("LaunchCleanupJob: building job with name=%s prefix=%s", cleanupName, prefix)
     
     job := BuildAgentJob(osImage, JobParams{
         Namespace:           namespace,
@@ -524,6 +605,9 @@ func (c *Controller) LaunchCleanupJob(ctx context.Context, namespace, nodeName s
         NodeCRNamespace:     c.NodeCRNamespace,
         TTLSecondsAfterDone: c.JobTTLSeconds,
         Action:              "cleanup",
+        InterfacePrefix:     prefix,
+        NodeConfigSpec:      eff.specJSON(),
+        Options:             opts,
     })
     
     log.Printf("LaunchCleanupJob: checking if cleanup job already exists: %s/%s", namespace, cleanupName)
@@ -555,17 +639,14 @@ func (c *Controller) scheduleJobDeletion(ctx context.Context, namespace, name st
     }()
 }
 
-// updateCRStatus는 상태 필드를 PATCH로 갱신한다.
-func (c *Controller) updateCRStatus(ctx context.Context, u *unstructured.Unstructured, status map[string]any) error {
-    obj := u.DeepCopy()
-    // merge into status
-    current, _, _ := unstructured.NestedMap(obj.Object, "status")
-    if current == nil { current = map[string]any{} }
-    for k, v := range status { current[k] = v }
-    _ = unstructured.SetNestedMap(obj.Object, current, "status")
+// updateCRStatus는 mutate로 변경한 status를 status 서브리소스로 갱신한다.
+func (c *Controller) updateCRStatus(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, mutate func(*multinicv1alpha1.MultiNicNodeConfigStatus)) error {
+    obj := cr.DeepCopy()
+    // merge into status: fields not touched by mutate keep their current values
+    mutate(&obj.Status)
     
     // Try UpdateStatus first (proper way for status subresource)
-    client := c.Dyn.Resource(nodeCRGVR).Namespace(obj.GetNamespace())
+    client := c.nodeConfigs(obj.Namespace)
     if _, err := client.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
         // Fallback to regular Update if UpdateStatus fails
         log.Printf("UpdateStatus failed, trying regular Update: %v", err)
@@ -580,73 +661,70 @@ func (c *Controller) updateCRStatus(ctx context.Context, u *unstructured.Unstruc
 // ApplyTerminationSummary parses a termination summary JSON and updates CR per-interface status
 // ApplyTerminationSummary는 종료 메시지를 파싱해 인터페이스 상태를 부분 실패로 반영한다.
 func (c *Controller) ApplyTerminationSummary(ctx context.Context, namespace, nodeName, jobName, msg string) error {
-    u, err := c.Dyn.Resource(nodeCRGVR).Namespace(namespace).Get(ctx, nodeName, metav1.GetOptions{})
+    cr, err := c.nodeConfigs(namespace).Get(ctx, nodeName, metav1.GetOptions{})
     if err != nil { return err }
     // Parse failures
-    type failure struct { ID int `json:"id"`; MAC, Name, ErrorType, Reason string }
-    var sum struct { Failures []failure `json:"failures"` }
+    var sum struct { Failures []jobFailure `json:"failures"` }
     if err := json.Unmarshal([]byte(msg), &sum); err != nil { return nil }
-    // Build maps by ID/MAC
-    failByID := map[int]failure{}
-    failByMAC := map[string]failure{}
-    for _, f := range sum.Failures { failByID[f.ID] = f; if strings.TrimSpace(f.MAC) != "" { failByMAC[strings.ToLower(strings.TrimSpace(f.MAC))] = f } }
-    // Compute per-interface statuses
-    statuses := []any{}
-    ifaces, found, _ := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-    if found {
-        statuses = make([]any, 0, len(ifaces))
-        for i := range ifaces {
-            ifaceMap, _ := ifaces[i].(map[string]any)
-            name := fmt.Sprintf("multinic%d", i)
-            id := getIntFromMap(ifaceMap, "id")
-            mac := strings.ToLower(getStringFromMap(ifaceMap, "macAddress"))
-            if f, ok := failByID[id]; ok && id != 0 {
-                statuses = append(statuses, map[string]any{
-                    "name":           name,
-                    "interfaceIndex": int64(i),
-                    "id":             int64(f.ID),
-                    "macAddress":     mac,
-                    "status":         "Failed",
-                    "reason":         "JobFailed",
-                    "message":        f.Reason,
-                    "lastUpdated":    time.Now().Format(time.RFC3339),
-                })
-            } else if f, ok := failByMAC[mac]; ok && mac != "" {
-                statuses = append(statuses, map[string]any{
-                    "name":           name,
-                    "interfaceIndex": int64(i),
-                    "id":             int64(f.ID),
-                    "macAddress":     mac,
-                    "status":         "Failed",
-                    "reason":         "JobFailed",
-                    "message":        f.Reason,
-                    "lastUpdated":    time.Now().Format(time.RFC3339),
-                })
-            } else {
-                statuses = append(statuses, map[string]any{
-                    "name":           name,
-                    "interfaceIndex": int64(i),
-                    "id":             int64(id),
-                    "macAddress":     mac,
-                    "status":         "Configured",
-                    "reason":         "JobPartialSuccess",
-                    "lastUpdated":    time.Now().Format(time.RFC3339),
-                })
-            }
+    // Compute per-interface statuses, named with the Job's prefix when the Job is still there
+    prefix := constants.InterfacePrefix()
+    if job, err := c.Client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{}); err == nil { prefix = jobInterfacePrefix(job) }
+    statuses := failureInterfaceStatuses(cr, prefix, sum.Failures, "JobFailed", "JobPartialSuccess")
+    reason := "JobFailed"; if len(sum.Failures) < len(cr.Spec.Interfaces) { reason = "JobFailedPartial" }
+    return c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StateFailed
+        st.Conditions = append([]multinicv1alpha1.Condition{{Type: "Ready", Status: "False", Reason: reason}}, disruptionConditions(cr.Status.Conditions)...)
+        st.InterfaceStatuses = statuses
+        st.LastUpdated = &now
+        st.LastJobName = jobName
+    })
+}
+
+// failureInterfaceStatuses maps the summary failures onto spec.interfaces by id, then MAC.
+// Interfaces without a failure entry are reported Configured with okReason.
+func failureInterfaceStatuses(cr *multinicv1alpha1.MultiNicNodeConfig, prefix string, failures []jobFailure, failedReason, okReason string) []multinicv1alpha1.InterfaceStatus {
+    failByID := map[int]jobFailure{}
+    failByMAC := map[string]jobFailure{}
+    for _, f := range failures {
+        failByID[f.ID] = f
+        if strings.TrimSpace(f.MAC) != "" {
+            failByMAC[strings.ToLower(strings.TrimSpace(f.MAC))] = f
+        }
+    }
+    statuses := make([]multinicv1alpha1.InterfaceStatus, 0, len(cr.Spec.Interfaces))
+    for i, iface := range cr.Spec.Interfaces {
+        now := metav1.Now()
+        id := int(iface.ID)
+        mac := strings.ToLower(iface.MacAddress)
+        st := multinicv1alpha1.InterfaceStatus{
+            Name:           fmt.Sprintf("%s%d", prefix, i),
+            InterfaceIndex: int64(i),
+            ID:             int64(id),
+            MacAddress:     mac,
+            Status:         "Configured",
+            Reason:         okReason,
+            LastUpdated:    &now,
+        }
+        f, failed := failByID[id]
+        failed = failed && id != 0
+        if !failed && mac != "" {
+            f, failed = failByMAC[mac]
         }
+        if failed {
+            st.ID = int64(f.ID)
+            st.Status = "Failed"
+            st.Reason = failedReason
+            st.Message = f.Reason
+        }
+        statuses = append(statuses, st)
     }
-    reason := "JobFailed"; if len(sum.Failures) < len(ifaces) { reason = "JobFailedPartial" }
-    patch := map[string]any{ "state": "Failed", "conditions": []any{ map[string]any{"type": "Ready", "status": "False", "reason": reason} }, "interfaceStatuses": statuses, "lastUpdated": time.Now().Format(time.RFC3339), "lastJobName": jobName }
-    return c.updateCRStatus(ctx, u, patch)
+    return statuses
 }
 
 // computeSpecHash creates a SHA256 hash of the CR .spec for change tracking
-func computeSpecHash(u *unstructured.Unstructured) string {
-    spec, found, _ := unstructured.NestedMap(u.Object, "spec")
-    if !found || spec == nil {
-        return ""
-    }
-    b, err := json.Marshal(spec)
+func computeSpecHash(cr *multinicv1alpha1.MultiNicNodeConfig) string {
+    b, err := json.Marshal(cr.Spec)
     if err != nil {
         return ""
     }
@@ -656,105 +734,49 @@ func computeSpecHash(u *unstructured.Unstructured) string {
 
 // logInterfaceDetails logs detailed information about network interfaces from the CR
 // logInterfaceDetails는 최초 처리 시 인터페이스 목록을 로그로 남긴다.
-func (c *Controller) logInterfaceDetails(u *unstructured.Unstructured, nodeName string) {
-    // Extract interfaces array from spec
-    interfaces, found, err := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-    if !found || err != nil {
-        log.Printf("No interfaces found in CR %s/%s", u.GetNamespace(), u.GetName())
+func (c *Controller) logInterfaceDetails(cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string) {
+    if len(cr.Spec.Interfaces) == 0 {
+        log.Printf("No interfaces found in CR %s/%s", cr.Namespace, cr.Name)
         return
     }
 
-    log.Printf("=== Interface Details for Node: %s (CR: %s/%s) ===", nodeName, u.GetNamespace(), u.GetName())
+    log.Printf("=== Interface Details for Node: %s (CR: %s/%s) ===", nodeName, cr.Namespace, cr.Name)
     
-    for i, iface := range interfaces {
-        ifaceMap, ok := iface.(map[string]interface{})
-        if !ok {
-            continue
-        }
-        
-        // Extract interface details
-        id := getIntFromMap(ifaceMap, "id")
-        macAddress := getStringFromMap(ifaceMap, "macAddress")
-        ipAddress := getStringFromMap(ifaceMap, "address")
-        cidr := getStringFromMap(ifaceMap, "cidr")
-        mtu := getIntFromMap(ifaceMap, "mtu")
-        
+    for i, iface := range cr.Spec.Interfaces {
         log.Printf("  Interface[%d]: ID=%d, MAC=%s, IP=%s, CIDR=%s, MTU=%d", 
-            i, id, macAddress, ipAddress, cidr, mtu)
+            i, iface.ID, iface.MacAddress, iface.Address, iface.CIDR, iface.MTU)
     }
     log.Printf("=== End Interface Details ===")
 }
 
-// Helper functions for extracting values from interface maps
-func getStringFromMap(m map[string]interface{}, key string) string {
-    if val, ok := m[key]; ok {
-        if str, ok := val.(string); ok {
-            return str
-        }
-    }
-    return ""
-}
-
-func getIntFromMap(m map[string]interface{}, key string) int {
-    if val, ok := m[key]; ok {
-        if intVal, ok := val.(int); ok {
-            return intVal
-        }
-        if int64Val, ok := val.(int64); ok {
-            return int(int64Val)
-        }
-        if floatVal, ok := val.(float64); ok {
-            return int(floatVal)
-        }
-        // Debug: log unexpected type
-        log.Printf("DEBUG: getIntFromMap key=%s, unexpected type: %T, value: %v", key, val, val)
-    }
-    return 0
-}
-
 // buildInterfaceStatuses creates detailed status information for each interface in the CR
-// Returns a list where each entry includes the interface name (multinic0, multinic1, etc.)
+// Returns a list where each entry includes the interface name (<prefix>0, <prefix>1, etc.)
 // buildInterfaceStatuses는 spec.interfaces 기반으로 상태 배열을 생성한다.
-func (c *Controller) buildInterfaceStatuses(u *unstructured.Unstructured, nodeName, status, reason string) []any {
-    interfaces, found, err := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-    if !found || err != nil {
-        log.Printf("No interfaces found when building status for CR %s/%s", u.GetNamespace(), u.GetName())
-        return []any{}
+func (c *Controller) buildInterfaceStatuses(cr *multinicv1alpha1.MultiNicNodeConfig, prefix, nodeName, status, reason string) []multinicv1alpha1.InterfaceStatus {
+    if len(cr.Spec.Interfaces) == 0 {
+        log.Printf("No interfaces found when building status for CR %s/%s", cr.Namespace, cr.Name)
+        return []multinicv1alpha1.InterfaceStatus{}
     }
 
-    interfaceStatuses := make([]any, 0, len(interfaces))
+    interfaceStatuses := make([]multinicv1alpha1.InterfaceStatus, 0, len(cr.Spec.Interfaces))
     
-    for i, iface := range interfaces {
-        ifaceMap, ok := iface.(map[string]interface{})
-        if !ok {
-            continue
-        }
-        
-        // Extract interface details
-        id := getIntFromMap(ifaceMap, "id")
-        macAddress := getStringFromMap(ifaceMap, "macAddress")
-        ipAddress := getStringFromMap(ifaceMap, "address")
-        cidr := getStringFromMap(ifaceMap, "cidr")
-        mtu := getIntFromMap(ifaceMap, "mtu")
-        
+    for i, iface := range cr.Spec.Interfaces {
         // Generate interface name based on index (multinic0, multinic1, etc.)
-        interfaceName := fmt.Sprintf("multinic%d", i)
-
-        // Build interface status (convert int types to int64 for unstructured compatibility)
-        interfaceStatus := map[string]any{
-            "name":         interfaceName,
-            "interfaceIndex": int64(i),
-            "id":            int64(id),
-            "macAddress":    macAddress,
-            "address":       ipAddress,
-            "cidr":         cidr,
-            "mtu":          int64(mtu),
-            "status":       status,
-            "reason":       reason,
-            "lastUpdated":  time.Now().Format(time.RFC3339),
-        }
-
-        interfaceStatuses = append(interfaceStatuses, interfaceStatus)
+        interfaceName := fmt.Sprintf("%s%d", prefix, i)
+        now := metav1.Now()
+
+        interfaceStatuses = append(interfaceStatuses, multinicv1alpha1.InterfaceStatus{
+            Name:           interfaceName,
+            InterfaceIndex: int64(i),
+            ID:             int64(iface.ID),
+            MacAddress:     iface.MacAddress,
+            Address:        iface.Address,
+            CIDR:           iface.CIDR,
+            MTU:            int64(iface.MTU),
+            Status:         status,
+            Reason:         reason,
+            LastUpdated:    &now,
+        })
         
         // Log only final state changes to reduce noise
         if status == "Configured" && reason == "JobSucceeded" {
@@ -792,7 +814,7 @@ func (c *Controller) updateInterfaceStates(ctx context.Context, namespace, nodeN
     // Debug: log.Printf("updateInterfaceStates: checking interface states for node %s", nodeName)
     
     // Get the CR for this node
-    u, err := c.Dyn.Resource(nodeCRGVR).Namespace(namespace).Get(ctx, nodeName, metav1.GetOptions{})
+    cr, err := c.nodeConfigs(namespace).Get(ctx, nodeName, metav1.GetOptions{})
     if err != nil {
         // Debug: log.Printf("updateInterfaceStates: failed to get CR for node %s: %v", nodeName, err)
         return err
@@ -806,18 +828,13 @@ func (c *Controller) updateInterfaceStates(ctx context.Context, namespace, nodeN
     }
     
     // Build enhanced interface statuses with actual system state
-    interfaceStatuses := c.buildEnhancedInterfaceStatuses(u, node)
-    
-    // Update CR status with current interface states
-    currentState, _, _ := unstructured.NestedString(u.Object, "status", "state")
-    if currentState == "" {
-        currentState = "Unknown"
-    }
+    interfaceStatuses := c.buildEnhancedInterfaceStatuses(cr, node, c.interfacePrefixFor(cr, node))
     
-    _ = c.updateCRStatus(ctx, u, map[string]any{
-        "interfaceStatuses": interfaceStatuses,
-        "lastInterfaceCheck": time.Now().Format(time.RFC3339),
-        "nodeReady": c.isNodeReady(node),
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.InterfaceStatuses = interfaceStatuses
+        st.LastInterfaceCheck = &now
+        st.NodeReady = c.isNodeReady(node)
     })
     
     // Debug: log.Printf("updateInterfaceStates: updated interface states for node %s with %d interfaces", 
@@ -826,87 +843,42 @@ func (c *Controller) updateInterfaceStates(ctx context.Context, namespace, nodeN
     return nil
 }
 
-// buildEnhancedInterfaceStatuses creates detailed status with actual system state check
+// buildEnhancedInterfaceStatuses creates detailed status from the spec
 // Returns a list where each entry includes the interface name (multinic0, multinic1, etc.)
-// buildEnhancedInterfaceStatuses는 노드 상태를 반영한 interfaceStatuses를 생성한다.
-func (c *Controller) buildEnhancedInterfaceStatuses(u *unstructured.Unstructured, node *corev1.Node) []any {
-    interfaces, found, err := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-    if !found || err != nil {
-        return []any{}
-    }
-
-    interfaceStatuses := make([]any, 0, len(interfaces))
+// buildEnhancedInterfaceStatuses는 spec 필드는 다시 만들고, 관찰 결과(Job/verify가 채운 status,
+// actualState, lastChecked 등)는 같은 MAC의 기존 항목에서 이어받는다.
+func (c *Controller) buildEnhancedInterfaceStatuses(cr *multinicv1alpha1.MultiNicNodeConfig, node *corev1.Node, prefix string) []multinicv1alpha1.InterfaceStatus {
+    interfaceStatuses := make([]multinicv1alpha1.InterfaceStatus, 0, len(cr.Spec.Interfaces))
     
-    for i, iface := range interfaces {
-        ifaceMap, ok := iface.(map[string]interface{})
-        if !ok {
-            continue
-        }
-        
-        // Extract interface details
-        id := getIntFromMap(ifaceMap, "id")
-        macAddress := getStringFromMap(ifaceMap, "macAddress")
-        ipAddress := getStringFromMap(ifaceMap, "address")
-        cidr := getStringFromMap(ifaceMap, "cidr")
-        mtu := getIntFromMap(ifaceMap, "mtu")
-        
+    for i, iface := range cr.Spec.Interfaces {
         // Generate interface name based on index
-        interfaceName := fmt.Sprintf("multinic%d", i)
-        actualState := c.getActualInterfaceState(node, macAddress, interfaceName)
-        
-        // Build comprehensive interface status (convert int types to int64 for unstructured compatibility)
-        interfaceStatus := map[string]any{
-            "name":         interfaceName,
-            "interfaceIndex": int64(i),
-            "id":            int64(id),
-            "macAddress":    macAddress,
-            "address":       ipAddress,
-            "cidr":         cidr,
-            "mtu":          int64(mtu),
-            "actualState":   actualState,
-            "lastChecked":  time.Now().Format(time.RFC3339),
+        interfaceName := fmt.Sprintf("%s%d", prefix, i)
+        st := multinicv1alpha1.InterfaceStatus{}
+        for _, prev := range cr.Status.InterfaceStatuses {
+            if strings.EqualFold(prev.MacAddress, iface.MacAddress) {
+                st = prev
+                break
+            }
         }
-
-        interfaceStatuses = append(interfaceStatuses, interfaceStatus)
+        st.Name = interfaceName
+        st.InterfaceIndex = int64(i)
+        st.ID = int64(iface.ID)
+        st.MacAddress = iface.MacAddress
+        st.Address = iface.Address
+        st.CIDR = iface.CIDR
+        st.MTU = int64(iface.MTU)
+        // actualState는 verify 결과로만 채워진다 (노드가 NotReady면 그 결과도 믿을 수 없음)
+        if !c.isNodeReady(node) {
+            st.ActualState = "NodeNotReady"
+        } else if st.ActualState == "NodeNotReady" {
+            st.ActualState = ""
+        }
+        interfaceStatuses = append(interfaceStatuses, st)
     }
 
     return interfaceStatuses
 }
 
-// getActualInterfaceState checks the actual state of an interface on the node
-// getActualInterfaceState는 노드 실제 인터페이스 상태를 계산한다.
-func (c *Controller) getActualInterfaceState(node *corev1.Node, macAddress, interfaceName string) string {
-    // Check node conditions and capacity for network interface information
-    
-    // Check if node is ready
-    if !c.isNodeReady(node) {
-        return "NodeNotReady"
-    }
-    
-    // In a real implementation, you would:
-    // 1. Query node metrics or use a custom agent to check interface state
-    // 2. Check if the interface exists and is up
-    // 3. Verify IP configuration matches expected state
-    // 4. Check connectivity or other health metrics
-    
-    // For now, we'll return a placeholder based on node readiness
-    // This should be enhanced to actually check interface state
-    // Parameters macAddress and interfaceName would be used for detailed interface verification
-    
-    // Check node addresses to see if we can infer interface state
-    for _, addr := range node.Status.Addresses {
-        if addr.Type == corev1.NodeInternalIP {
-            // If node has internal IP, assume basic networking is working
-            // Future: use macAddress and interfaceName for specific interface checks
-            _ = macAddress    // suppress unused warning - would be used for MAC verification
-            _ = interfaceName // suppress unused warning - would be used for interface name checks
-            return "Configured"
-        }
-    }
-    
-    return "Unknown"
-}
-
 // isNodeReady checks if the node is in Ready condition
 // isNodeReady는 노드 Ready 조건을 확인한다.
 func (c *Controller) isNodeReady(node *corev1.Node) bool {
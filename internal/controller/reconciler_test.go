@@ -7,45 +7,35 @@ import (
     batchv1 "k8s.io/api/batch/v1"
     corev1 "k8s.io/api/core/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-    "k8s.io/apimachinery/pkg/runtime"
-    "k8s.io/apimachinery/pkg/runtime/schema"
-    dynamicfake "k8s.io/client-go/dynamic/fake"
     k8sfake "k8s.io/client-go/kubernetes/fake"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
 )
 
-func makeNodeCR(ns, name, nodeName, instanceID string) *unstructured.Unstructured {
-    u := &unstructured.Unstructured{
-        Object: map[string]interface{}{
-            "apiVersion": "multinic.io/v1alpha1",
-            "kind":       "MultiNicNodeConfig",
-            "metadata": map[string]interface{}{
-                "name":      name,
-                "namespace": ns,
-                "labels": map[string]interface{}{
-                    "multinic.io/instance-id": instanceID,
-                },
-            },
-            "spec": map[string]interface{}{
-                "nodeName": nodeName,
-                "interfaces": []interface{}{
-                    map[string]interface{}{"id": int64(1), "macAddress": "02:00:00:00:01:01"},
-                },
+func makeNodeCR(ns, name, nodeName, instanceID string) *multinicv1alpha1.MultiNicNodeConfig {
+    return &multinicv1alpha1.MultiNicNodeConfig{
+        ObjectMeta: metav1.ObjectMeta{
+            Name:      name,
+            Namespace: ns,
+            Labels:    map[string]string{"multinic.io/instance-id": instanceID},
+        },
+        Spec: multinicv1alpha1.MultiNicNodeConfigSpec{
+            NodeName: nodeName,
+            Interfaces: []multinicv1alpha1.InterfaceSpec{
+                {ID: 1, MacAddress: "02:00:00:00:01:01"},
             },
         },
     }
-    u.SetGroupVersionKind(schema.GroupVersionKind{Group: "multinic.io", Version: "v1alpha1", Kind: "MultiNicNodeConfig"})
-    return u
 }
 
 func TestReconcile_CreatesJobWithOSAwareMounts_RHEL(t *testing.T) {
-    scheme := runtime.NewScheme()
-    dyn := dynamicfake.NewSimpleDynamicClient(scheme, makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "6d4a3c2a-f1c4-414b-bedd-4938b4924f53"))
+    mnc := multinicfake.NewSimpleClientset(makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "6d4a3c2a-f1c4-414b-bedd-4938b4924f53"))
     kclient := k8sfake.NewSimpleClientset(
         &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Red Hat Enterprise Linux 9.4 (Plow)", SystemUUID: "6d4a3c2a-f1c4-414b-bedd-4938b4924f53"}}},
     )
 
-    c := &Controller{Dyn: dyn, Client: kclient, AgentImage: "multinic-agent:dev", ImagePullPolicy: corev1.PullIfNotPresent, ServiceAccount: "sa", NodeCRNamespace: "multinic-system"}
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", ImagePullPolicy: corev1.PullIfNotPresent, ServiceAccount: "sa", NodeCRNamespace: "multinic-system"}
 
     err := c.Reconcile(context.Background(), "multinic-system", "worker-node-01")
     if err != nil { t.Fatalf("reconcile error: %v", err) }
@@ -57,22 +47,65 @@ func TestReconcile_CreatesJobWithOSAwareMounts_RHEL(t *testing.T) {
 }
 
 func TestProcessJobs_UpdatesCRStatus_OnSuccess(t *testing.T) {
-    scheme := runtime.NewScheme()
     cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "6d4a3c2a-f1c4-414b-bedd-4938b4924f53")
-    dyn := dynamicfake.NewSimpleDynamicClient(scheme, cr)
+    mnc := multinicfake.NewSimpleClientset(cr)
     kclient := k8sfake.NewSimpleClientset(
         &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01"}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Red Hat Enterprise Linux 9.4 (Plow)", SystemUUID: "6d4a3c2a-f1c4-414b-bedd-4938b4924f53"}}},
         &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "multinic-agent-worker-node-01", Namespace: "multinic-system", Labels: map[string]string{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": "worker-node-01"}}, Status: batchv1.JobStatus{Succeeded: 1}},
     )
 
-    c := &Controller{Dyn: dyn, Client: kclient, AgentImage: "multinic-agent:dev", ImagePullPolicy: corev1.PullIfNotPresent, ServiceAccount: "sa", NodeCRNamespace: "multinic-system"}
+    c := &Controller{MultiNic: mnc, Client: kclient, AgentImage: "multinic-agent:dev", ImagePullPolicy: corev1.PullIfNotPresent, ServiceAccount: "sa", NodeCRNamespace: "multinic-system"}
 
     if err := c.ProcessJobs(context.Background(), "multinic-system"); err != nil { t.Fatalf("process jobs error: %v", err) }
 
-    got, err := dyn.Resource(nodeCRGVR).Namespace("multinic-system").Get(context.Background(), "worker-node-01", metav1.GetOptions{})
+    got, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-node-01", metav1.GetOptions{})
     if err != nil { t.Fatalf("get cr error: %v", err) }
-    state, _, _ := unstructured.NestedString(got.Object, "status", "state")
-    if state != "Configured" { t.Fatalf("expected status.state=Configured, got %q", state) }
+    if got.Status.State != multinicv1alpha1.StateConfigured { t.Fatalf("expected status.state=Configured, got %q", got.Status.State) }
+}
+
+// the succeeded Job of the previous generation, kept by the delete delay, must not report on the new spec
+func TestProcessJob_IgnoresJobOtherThanLastJob(t *testing.T) {
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.Generation = 2
+    cr.Status = multinicv1alpha1.MultiNicNodeConfigStatus{State: multinicv1alpha1.StateInProgress, ObservedGeneration: 2, LastJobName: "multinic-agent-worker-node-01-g2"}
+    mnc := multinicfake.NewSimpleClientset(cr)
+    old := &batchv1.Job{
+        ObjectMeta: metav1.ObjectMeta{Name: "multinic-agent-worker-node-01-g1", Namespace: "multinic-system",
+            Labels:      map[string]string{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": "worker-node-01"},
+            Annotations: map[string]string{AppliedInterfacesAnnotation: `[{"id":1,"macAddress":"02:00:00:00:01:01","name":"multinic0"}]`}},
+        Status: batchv1.JobStatus{Succeeded: 1},
+    }
+    c := &Controller{MultiNic: mnc, Client: k8sfake.NewSimpleClientset(old)}
+
+    if err := c.ProcessJob(context.Background(), "multinic-system", old); err != nil { t.Fatalf("process job error: %v", err) }
+
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-node-01", metav1.GetOptions{})
+    if got.Status.State != multinicv1alpha1.StateInProgress { t.Fatalf("expected the CR to wait for its own job, got %q", got.Status.State) }
+    if len(got.Status.AppliedInterfaces) != 0 { t.Fatalf("old job's applied interfaces were copied: %#v", got.Status.AppliedInterfaces) }
+}
+
+func TestApplyTerminationSummary_MapsFailuresToInterfaceStatuses(t *testing.T) {
+    cr := makeNodeCR("multinic-system", "worker-node-01", "worker-node-01", "")
+    cr.Spec.Interfaces = append(cr.Spec.Interfaces, multinicv1alpha1.InterfaceSpec{ID: 2, MacAddress: "02:00:00:00:01:02"})
+    mnc := multinicfake.NewSimpleClientset(cr)
+    c := &Controller{MultiNic: mnc, Client: k8sfake.NewSimpleClientset()}
+
+    msg := `{"failures":[{"id":0,"mac":"02:00:00:00:01:02","reason":"link down"}]}`
+    if err := c.ApplyTerminationSummary(context.Background(), "multinic-system", "worker-node-01", "job-1", msg); err != nil {
+        t.Fatalf("apply summary error: %v", err)
+    }
+
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-node-01", metav1.GetOptions{})
+    if got.Status.State != multinicv1alpha1.StateFailed || got.Status.LastJobName != "job-1" {
+        t.Fatalf("unexpected status: state=%q lastJobName=%q", got.Status.State, got.Status.LastJobName)
+    }
+    if len(got.Status.Conditions) != 1 || got.Status.Conditions[0].Reason != "JobFailedPartial" {
+        t.Fatalf("expected JobFailedPartial condition, got %#v", got.Status.Conditions)
+    }
+    st := got.Status.InterfaceStatuses
+    if len(st) != 2 || st[0].Status != "Configured" || st[1].Status != "Failed" || st[1].Message != "link down" || st[1].Name != "multinic1" {
+        t.Fatalf("unexpected interface statuses: %#v", st)
+    }
 }
 
 func assertRHELJob(t *testing.T, job *batchv1.Job) {
@@ -0,0 +1,175 @@
+package controller
+
+import (
+    "context"
+    "fmt"
+    "log"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionRolloutWaiting holds a CR in Pending until the rollout admits its agent Job
+const ConditionRolloutWaiting = "RolloutWaiting"
+
+// RolloutWaiting condition reasons
+const (
+    RolloutReasonWaitingForSlot    = "WaitingForSlot"
+    RolloutReasonWaitingForWorkers = "WaitingForWorkers"
+    RolloutReasonPaused            = "RolloutPaused"
+)
+
+// controlPlaneLabels mark the nodes that are rolled out after the workers
+var controlPlaneLabels = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+
+// rolloutScope is one limit checked before a Job starts: the controller-wide one (policy "")
+// or the one of the policy that selects the node (counts only CRs applied with that policy)
+type rolloutScope struct {
+    policy string
+    limits multinicv1alpha1.RolloutPolicy
+}
+
+// rolloutWait explains why a CR is not admitted yet
+type rolloutWait struct {
+    reason  string
+    message string
+}
+
+// rolloutScopes returns the limits that apply to a node with the effective config eff
+func (c *Controller) rolloutScopes(eff effectiveConfig) []rolloutScope {
+    var scopes []rolloutScope
+    if c.Rollout != nil && (c.Rollout.MaxConcurrentNodes > 0 || c.Rollout.FailureThreshold > 0) {
+        scopes = append(scopes, rolloutScope{limits: *c.Rollout})
+    }
+    if r := eff.Rollout; r != nil && (r.MaxConcurrentNodes > 0 || r.FailureThreshold > 0) {
+        scopes = append(scopes, rolloutScope{policy: eff.RolloutPolicy, limits: *r})
+    }
+    return scopes
+}
+
+func (s rolloutScope) String() string {
+    if s.policy == "" { return "controller rollout" }
+    return "rollout of policy " + s.policy
+}
+
+// includes reports whether cr counts against the scope
+func (s rolloutScope) includes(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    if s.policy == "" { return true }
+    for _, p := range cr.Status.AppliedPolicies {
+        if p == s.policy { return true }
+    }
+    return false
+}
+
+// rolloutGate는 cr의 Job을 지금 시작해도 되는지 판단한다. 대기해야 하면 이유를 반환한다.
+// 실행 중(InProgress)인 노드 수가 maxConcurrentNodes에 도달했거나, Failed CR 수가 failureThreshold에
+// 도달했거나, 컨트롤 플레인 노드인데 아직 적용 대기 중인 워커가 남아 있으면 대기한다.
+// 카운트가 캐시 지연에 흔들리지 않도록 API에서 직접 목록을 읽는다 (호출자는 rolloutMu를 잡고 있어야 함).
+func (c *Controller) rolloutGate(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, node *corev1.Node, scopes []rolloutScope) (*rolloutWait, error) {
+    list, err := c.nodeConfigs(cr.Namespace).List(ctx, metav1.ListOptions{})
+    if err != nil { return nil, fmt.Errorf("failed to list node configs for rollout: %w", err) }
+
+    for _, s := range scopes {
+        inFlight, failed := 0, 0
+        for i := range list.Items {
+            other := &list.Items[i]
+            if other.Name == cr.Name || !s.includes(other) { continue }
+            switch other.Status.State {
+            case multinicv1alpha1.StateInProgress:
+                inFlight++
+            case multinicv1alpha1.StateFailed:
+                failed++
+            case multinicv1alpha1.StatePending:
+                // a CR draining its node before a disruptive change holds a slot too
+                if hasCondition(other, ConditionDisruptionExpected) { inFlight++ }
+            }
+        }
+        // a Failed CR may always be retried: its new spec is the fix for the pause
+        if th := int(s.limits.FailureThreshold); th > 0 && failed >= th && cr.Status.State != multinicv1alpha1.StateFailed {
+            return &rolloutWait{RolloutReasonPaused, fmt.Sprintf("%s paused: failure threshold of %d Failed node configs reached", s, th)}, nil
+        }
+        if max := int(s.limits.MaxConcurrentNodes); max > 0 && inFlight >= max {
+            return &rolloutWait{RolloutReasonWaitingForSlot, fmt.Sprintf("%s: all %d slots in progress", s, max)}, nil
+        }
+    }
+
+    if !isControlPlane(node) { return nil, nil }
+    nodes, err := c.nodesByName(ctx)
+    if err != nil { return nil, err }
+    for i := range list.Items {
+        other := &list.Items[i]
+        if other.Name == cr.Name || other.DeletionTimestamp != nil { continue }
+        // a worker whose Node is gone or claims another machine's instance-id never gets a Job
+        workerNode, ok := nodes[nodeNameOf(other)]
+        if !ok || isControlPlane(workerNode) || !instanceIDMatches(other, workerNode) { continue }
+        // a paused or conflicting worker may wait for days; it does not hold back the control plane
+        if hasCondition(other, ConditionPaused) || hasCondition(other, ConditionConflict) { continue }
+        if rolloutPending(other) {
+            return &rolloutWait{RolloutReasonWaitingForWorkers, fmt.Sprintf("control-plane nodes are rolled out after the worker nodes; waiting for %s", other.Name)}, nil
+        }
+    }
+    return nil, nil
+}
+
+// rolloutPending reports whether cr still waits for (or has not yet been given) its agent Job
+func rolloutPending(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    if cr.Status.State == multinicv1alpha1.StateInProgress { return true }
+    if cr.Generation != cr.Status.ObservedGeneration { return true }
+    return hasRolloutWaiting(cr)
+}
+
+func hasRolloutWaiting(cr *multinicv1alpha1.MultiNicNodeConfig) bool {
+    return hasCondition(cr, ConditionRolloutWaiting)
+}
+
+// hasCondition reports whether condType is set to True on cr
+func hasCondition(cr *multinicv1alpha1.MultiNicNodeConfig, condType string) bool {
+    for _, cond := range cr.Status.Conditions {
+        if cond.Type == condType && cond.Status == "True" { return true }
+    }
+    return false
+}
+
+func isControlPlane(node *corev1.Node) bool {
+    for _, l := range controlPlaneLabels {
+        if _, ok := node.Labels[l]; ok { return true }
+    }
+    return false
+}
+
+// nodesByName returns every Node of the cluster by name
+func (c *Controller) nodesByName(ctx context.Context) (map[string]*corev1.Node, error) {
+    nodes, err := c.Client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+    if err != nil { return nil, fmt.Errorf("failed to list nodes: %w", err) }
+    out := make(map[string]*corev1.Node, len(nodes.Items))
+    for i := range nodes.Items { out[nodes.Items[i].Name] = &nodes.Items[i] }
+    return out, nil
+}
+
+func nodeNameOf(cr *multinicv1alpha1.MultiNicNodeConfig) string {
+    if cr.Spec.NodeName != "" { return cr.Spec.NodeName }
+    return cr.Name
+}
+
+// markRolloutWaiting holds cr in Pending with a RolloutWaiting condition; like markConflict it
+// leaves observedGeneration alone and skips the write when nothing changed (messages carry no
+// live counts, so waiting CRs are not rewritten whenever a slot frees up)
+func (c *Controller) markRolloutWaiting(ctx context.Context, cr *multinicv1alpha1.MultiNicNodeConfig, nodeName string, wait *rolloutWait) {
+    for _, cond := range cr.Status.Conditions {
+        if cond.Type == ConditionRolloutWaiting && cond.Status == "True" && cond.Reason == wait.reason && cond.Message == wait.message {
+            return
+        }
+    }
+    log.Printf("[%s/%s] job deferred: %s", cr.Namespace, cr.Name, wait.message)
+    eventType := corev1.EventTypeNormal
+    if wait.reason == RolloutReasonPaused { eventType = corev1.EventTypeWarning }
+    c.recordEvent(cr, nodeName, eventType, wait.reason, "job deferred: %s", wait.message)
+    _ = c.updateCRStatus(ctx, cr, func(st *multinicv1alpha1.MultiNicNodeConfigStatus) {
+        now := metav1.Now()
+        st.State = multinicv1alpha1.StatePending
+        st.Conditions = append([]multinicv1alpha1.Condition{{Type: ConditionRolloutWaiting, Status: "True", Reason: wait.reason, Message: wait.message, LastTransitionTime: &now}}, disruptionConditions(cr.Status.Conditions)...)
+        st.LastUpdated = &now
+    })
+}
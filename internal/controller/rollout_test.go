@@ -0,0 +1,182 @@
+package controller
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    k8sfake "k8s.io/client-go/kubernetes/fake"
+    k8stesting "k8s.io/client-go/testing"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
+)
+
+func rolloutNode(name string, labels map[string]string) *corev1.Node {
+    return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}, Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OSImage: "Ubuntu 22.04.4 LTS"}}}
+}
+
+func rolloutCR(name string, state multinicv1alpha1.NodeConfigState) *multinicv1alpha1.MultiNicNodeConfig {
+    cr := makeNodeCR("multinic-system", name, name, "")
+    cr.Generation = 1
+    if state != "" {
+        cr.Status.State = state
+        cr.Status.ObservedGeneration = 1
+    }
+    return cr
+}
+
+func jobExists(t *testing.T, kclient *k8sfake.Clientset, name string) bool {
+    t.Helper()
+    jobs, err := kclient.BatchV1().Jobs("multinic-system").List(context.Background(), metav1.ListOptions{LabelSelector: "multinic.io/node-name=" + name})
+    if err != nil { t.Fatalf("list jobs: %v", err) }
+    return len(jobs.Items) > 0
+}
+
+func rolloutCondition(t *testing.T, mnc *multinicfake.Clientset, name string) (multinicv1alpha1.NodeConfigState, string) {
+    t.Helper()
+    got, err := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), name, metav1.GetOptions{})
+    if err != nil { t.Fatalf("get CR: %v", err) }
+    for _, cond := range got.Status.Conditions {
+        if cond.Type == ConditionRolloutWaiting { return got.Status.State, cond.Reason }
+    }
+    return got.Status.State, ""
+}
+
+func TestReconcile_RolloutWaitsForFreeSlot(t *testing.T) {
+    busy := rolloutCR("worker-1", multinicv1alpha1.StateInProgress)
+    mnc := multinicfake.NewSimpleClientset(busy, rolloutCR("worker-2", ""))
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil), rolloutNode("worker-2", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Rollout: &multinicv1alpha1.RolloutPolicy{MaxConcurrentNodes: 1}}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-2"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if jobExists(t, kclient, "worker-2") { t.Fatalf("job must wait while the only slot is in progress") }
+    if state, reason := rolloutCondition(t, mnc, "worker-2"); state != multinicv1alpha1.StatePending || reason != RolloutReasonWaitingForSlot {
+        t.Fatalf("expected Pending/%s, got %s/%s", RolloutReasonWaitingForSlot, state, reason)
+    }
+
+    // the slot frees up once worker-1 reports Configured
+    busy.Status.State = multinicv1alpha1.StateConfigured
+    _, _ = mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").UpdateStatus(context.Background(), busy, metav1.UpdateOptions{})
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-2"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "worker-2") { t.Fatalf("expected a job once the slot is free") }
+    if state, reason := rolloutCondition(t, mnc, "worker-2"); state != multinicv1alpha1.StateInProgress || reason != "" {
+        t.Fatalf("expected InProgress without RolloutWaiting, got %s/%s", state, reason)
+    }
+}
+
+// the rollout lock covers the slot check and the InProgress write, not the Job API calls
+func TestReconcile_RolloutLockReleasedBeforeJobCreate(t *testing.T) {
+    mnc := multinicfake.NewSimpleClientset(rolloutCR("worker-1", ""))
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Rollout: &multinicv1alpha1.RolloutPolicy{MaxConcurrentNodes: 1}}
+    locked := false
+    kclient.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+        if c.rolloutMu.TryLock() { c.rolloutMu.Unlock() } else { locked = true }
+        return false, nil, nil
+    })
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "worker-1") { t.Fatalf("expected a job") }
+    if locked { t.Fatalf("the rollout lock must be released before the job is created") }
+}
+
+func TestReconcile_RolloutControlPlaneAfterWorkers(t *testing.T) {
+    mnc := multinicfake.NewSimpleClientset(rolloutCR("master-1", ""), rolloutCR("worker-1", ""))
+    kclient := k8sfake.NewSimpleClientset(
+        rolloutNode("master-1", map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+        rolloutNode("worker-1", nil),
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Rollout: &multinicv1alpha1.RolloutPolicy{MaxConcurrentNodes: 5}}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "master-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if _, reason := rolloutCondition(t, mnc, "master-1"); reason != RolloutReasonWaitingForWorkers || jobExists(t, kclient, "master-1") {
+        t.Fatalf("control-plane node must wait for the workers, reason=%q", reason)
+    }
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "worker-1") { t.Fatalf("expected the worker job to start") }
+}
+
+// workers that never get a Job (conflict, missing Node, instance-id mismatch) do not hold back the control plane
+func TestReconcile_RolloutControlPlaneSkipsStuckWorkers(t *testing.T) {
+    ctx := context.Background()
+    conflicted := rolloutCR("worker-1", "")
+    conflicted.Status.State = multinicv1alpha1.StatePending
+    conflicted.Status.Conditions = []multinicv1alpha1.Condition{{Type: ConditionConflict, Status: "True", Reason: "AddressConflict"}}
+    mismatched := rolloutCR("worker-2", "")
+    mismatched.Labels["multinic.io/instance-id"] = "6d4a3c2a-f1c4-414b-bedd-4938b4924f53"
+    mnc := multinicfake.NewSimpleClientset(rolloutCR("master-1", ""), conflicted, mismatched, rolloutCR("worker-gone", ""), rolloutCR("worker-3", ""))
+    kclient := k8sfake.NewSimpleClientset(
+        rolloutNode("master-1", map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+        rolloutNode("worker-1", nil), rolloutNode("worker-2", nil), rolloutNode("worker-3", nil),
+    )
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Rollout: &multinicv1alpha1.RolloutPolicy{MaxConcurrentNodes: 5}}
+
+    // worker-3 is the only worker still to be rolled out; the message names it
+    if err := c.Reconcile(ctx, "multinic-system", "master-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    got, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "master-1", metav1.GetOptions{})
+    if len(got.Status.Conditions) == 0 || got.Status.Conditions[0].Reason != RolloutReasonWaitingForWorkers || !strings.Contains(got.Status.Conditions[0].Message, "worker-3") {
+        t.Fatalf("expected the control plane to wait for worker-3, got %#v", got.Status.Conditions)
+    }
+
+    if err := c.Reconcile(ctx, "multinic-system", "worker-3"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    w3, _ := mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(ctx, "worker-3", metav1.GetOptions{})
+    w3.Status.State = multinicv1alpha1.StateConfigured
+    _, _ = mnc.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").UpdateStatus(ctx, w3, metav1.UpdateOptions{})
+    if err := c.Reconcile(ctx, "multinic-system", "master-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "master-1") { t.Fatalf("stuck workers must not hold back the control plane") }
+}
+
+func TestReconcile_RolloutPausesOnFailures(t *testing.T) {
+    failed := rolloutCR("worker-1", multinicv1alpha1.StateFailed)
+    failed.Generation = 2 // fixed spec: a Failed CR is retried despite the pause
+    mnc := multinicfake.NewSimpleClientset(failed, rolloutCR("worker-2", ""))
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil), rolloutNode("worker-2", nil))
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Rollout: &multinicv1alpha1.RolloutPolicy{FailureThreshold: 1}}
+
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-2"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if _, reason := rolloutCondition(t, mnc, "worker-2"); reason != RolloutReasonPaused || jobExists(t, kclient, "worker-2") {
+        t.Fatalf("expected the rollout to pause, reason=%q", reason)
+    }
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-1"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "worker-1") { t.Fatalf("expected the failed CR to be re-applied") }
+}
+
+func TestReconcile_PolicyRolloutCountsOnlyItsNodes(t *testing.T) {
+    other := rolloutCR("worker-1", multinicv1alpha1.StateInProgress)
+    mnc := multinicfake.NewSimpleClientset(other, rolloutCR("worker-2", ""))
+    kclient := k8sfake.NewSimpleClientset(rolloutNode("worker-1", nil), rolloutNode("worker-2", map[string]string{"pool": "gpu"}))
+    policy := multinicv1alpha1.MultiNicClusterPolicy{
+        ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+        Spec: multinicv1alpha1.MultiNicClusterPolicySpec{
+            NodeSelector: metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}},
+            Defaults:     multinicv1alpha1.PolicyDefaults{MTU: 9000},
+            Rollout:      &multinicv1alpha1.RolloutPolicy{MaxConcurrentNodes: 1},
+        },
+    }
+    c := &Controller{MultiNic: mnc, Client: kclient, NodeCRNamespace: "multinic-system", Policies: NewPolicyListerFromList([]multinicv1alpha1.MultiNicClusterPolicy{policy})}
+
+    // worker-1 is in progress but was not applied with the gpu policy
+    if err := c.Reconcile(context.Background(), "multinic-system", "worker-2"); err != nil { t.Fatalf("reconcile error: %v", err) }
+    if !jobExists(t, kclient, "worker-2") { t.Fatalf("expected a job: the policy slot is free") }
+}
+
+func TestWatcher_RolloutReleasedRequeuesWaiting(t *testing.T) {
+    w := NewWatcher(&Controller{}, "multinic-system")
+    waiting := rolloutCR("worker-2", multinicv1alpha1.StatePending)
+    waiting.Status.Conditions = []multinicv1alpha1.Condition{{Type: ConditionRolloutWaiting, Status: "True", Reason: RolloutReasonWaitingForSlot}}
+    store := w.CRInformerFactory.Multinic().V1alpha1().MultiNicNodeConfigs().Informer().GetStore()
+    for _, obj := range []runtime.Object{rolloutCR("worker-1", multinicv1alpha1.StateConfigured), waiting} {
+        _ = store.Add(obj)
+    }
+
+    old, cur := rolloutCR("worker-1", multinicv1alpha1.StateInProgress), rolloutCR("worker-1", multinicv1alpha1.StateConfigured)
+    if !rolloutReleased(old, cur) { t.Fatalf("leaving InProgress must release a slot") }
+    if rolloutReleased(cur, cur) { t.Fatalf("an unchanged state must not release a slot") }
+    w.requeueRolloutWaiting(store)
+    if w.Queue.Len() != 1 { t.Fatalf("expected only the waiting CR to be queued, got %d", w.Queue.Len()) }
+    if key, _ := w.Queue.Get(); key != "multinic-system/worker-2" { t.Fatalf("unexpected key %q", key) }
+}
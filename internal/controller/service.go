@@ -3,6 +3,9 @@ package controller
 import (
     "context"
     "time"
+
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Service provides a simple polling runner around the Controller
@@ -10,12 +13,35 @@ type Service struct {
     Controller *Controller
     Namespace  string
     Interval   time.Duration
+    // lastResync is when the last drift resync ran (zero = not yet; the first one waits an interval)
+    lastResync time.Time
 }
 
 // RunOnce runs a single reconcile + jobs processing cycle
 func (s *Service) RunOnce(ctx context.Context) error {
+    // polling has no informer cache: rebuild the MAC/IP conflict index from a fresh list
+    list, err := s.Controller.nodeConfigs(s.Namespace).List(ctx, metav1.ListOptions{})
+    if err != nil { return err }
+    s.Controller.Conflicts = NewConflictIndexFromList(list.Items)
+    // cluster policies are listed per cycle as well; a missing CRD leaves them disabled
+    s.Controller.Policies = nil
+    policies, err := s.Controller.MultiNic.MultinicV1alpha1().MultiNicClusterPolicies().List(ctx, metav1.ListOptions{})
+    if err == nil {
+        s.Controller.Policies = NewPolicyListerFromList(policies.Items)
+    } else if !apierrors.IsNotFound(err) {
+        return err
+    }
     if err := s.Controller.ProcessAll(ctx, s.Namespace); err != nil { return err }
     if err := s.Controller.ProcessJobs(ctx, s.Namespace); err != nil { return err }
+    if every := s.Controller.ResyncInterval; every > 0 {
+        now := time.Now()
+        if s.lastResync.IsZero() {
+            s.lastResync = now
+        } else if now.Sub(s.lastResync) >= every {
+            s.lastResync = now
+            if err := s.Controller.Resync(ctx, s.Namespace); err != nil { return err }
+        }
+    }
     return nil
 }
 
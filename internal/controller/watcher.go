@@ -2,30 +2,62 @@ package controller
 
 import (
     "context"
+    "errors"
+    "time"
 
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicinformers "multinic-agent/pkg/generated/informers/externalversions"
+    multinicv1alpha1informers "multinic-agent/pkg/generated/informers/externalversions/multinic/v1alpha1"
+
+    batchv1 "k8s.io/api/batch/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    "k8s.io/apimachinery/pkg/labels"
+    "k8s.io/apimachinery/pkg/util/wait"
     batchinformers "k8s.io/client-go/informers/batch/v1"
     coreinformers "k8s.io/client-go/informers/core/v1"
     informers "k8s.io/client-go/informers"
-    "k8s.io/client-go/dynamic/dynamicinformer"
     "k8s.io/client-go/tools/cache"
+    "k8s.io/client-go/util/workqueue"
     "log"
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
     corev1 "k8s.io/api/core/v1"
 )
 
+const (
+    // DefaultWorkers is the number of parallel reconciles when CONTROLLER_WORKERS is unset
+    DefaultWorkers = 2
+    // DefaultRetryBaseDelay/DefaultRetryMaxDelay bound the per-CR exponential retry backoff
+    DefaultRetryBaseDelay = time.Second
+    DefaultRetryMaxDelay  = 5 * time.Minute
+)
+
+// NewWorkQueue returns the CR key queue: a failed key is retried after base, doubling up to max
+func NewWorkQueue(base, max time.Duration) workqueue.TypedRateLimitingInterface[string] {
+    return workqueue.NewTypedRateLimitingQueueWithConfig(
+        workqueue.NewTypedItemExponentialFailureRateLimiter[string](base, max),
+        workqueue.TypedRateLimitingQueueConfig[string]{Name: "multinicnodeconfigs"},
+    )
+}
+
 // Watcher wires informers to the Controller reconcile functions
 type Watcher struct {
     Ctrl              *Controller
     Namespace         string
-    CRInformerFactory dynamicinformer.DynamicSharedInformerFactory
+    CRInformerFactory multinicinformers.SharedInformerFactory
     JobInformer       batchinformers.JobInformer
     PodInformer       coreinformers.PodInformer
+    // PolicyInformer is nil when the MultiNicClusterPolicy CRD is not installed
+    PolicyInformer    multinicv1alpha1informers.MultiNicClusterPolicyInformer
     Reconcile         func(ctx context.Context, namespace, name string) error
+    // Queue holds CR keys (namespace/name); Job events are mapped to the key of their node's CR
+    Queue             workqueue.TypedRateLimitingInterface[string]
+    // Workers is the number of keys reconciled in parallel (a key is never processed twice at once)
+    Workers           int
 }
 
 // NewWatcher는 CR/Job/Pod 인포머를 묶어 Watcher를 구성한다.
 func NewWatcher(ctrl *Controller, namespace string) *Watcher {
-    crInfFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(ctrl.Dyn, 0, namespace, nil)
+    crInfFactory := multinicinformers.NewSharedInformerFactoryWithOptions(ctrl.MultiNic, 0, multinicinformers.WithNamespace(namespace))
     jobsInfFactory := informers.NewSharedInformerFactoryWithOptions(ctrl.Client, 0, informers.WithNamespace(namespace))
     w := &Watcher{
         Ctrl:              ctrl,
@@ -33,14 +65,24 @@ func NewWatcher(ctrl *Controller, namespace string) *Watcher {
         CRInformerFactory: crInfFactory,
         JobInformer:       jobsInfFactory.Batch().V1().Jobs(),
         PodInformer:       jobsInfFactory.Core().V1().Pods(),
+        Queue:             NewWorkQueue(DefaultRetryBaseDelay, DefaultRetryMaxDelay),
+        Workers:           DefaultWorkers,
     }
     w.Reconcile = ctrl.Reconcile
+
+    // MAC/IP -> CR index over the informer cache for cluster-wide conflict checks
+    crInformer := crInfFactory.Multinic().V1alpha1().MultiNicNodeConfigs().Informer()
+    if err := crInformer.AddIndexers(ConflictIndexers()); err != nil {
+        log.Printf("failed to add conflict indexers: %v", err)
+    } else {
+        ctrl.Conflicts = NewConflictIndex(crInformer.GetIndexer(), crInformer.HasSynced)
+    }
     return w
 }
 
 // Start는 인포머를 시작하고 종료될 때까지 블록한다.
 func (w *Watcher) Start(ctx context.Context) error {
-    crInformer := w.CRInformerFactory.ForResource(nodeCRGVR).Informer()
+    crInformer := w.CRInformerFactory.Multinic().V1alpha1().MultiNicNodeConfigs().Informer()
     log.Printf("watcher starting for CRs and Jobs in ns=%s", w.Namespace)
 
     crInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -48,21 +90,43 @@ func (w *Watcher) Start(ctx context.Context) error {
         UpdateFunc: func(oldObj, newObj interface{}) { 
             // CR update events can be frequent - reduced logging for cleaner output
             w.handleCR(newObj) 
+            if specChanged(oldObj, newObj) { w.requeueConflicts(crInformer.GetStore(), newObj) }
+            if rolloutReleased(oldObj, newObj) { w.requeueRolloutWaiting(crInformer.GetStore()) }
         },
         DeleteFunc: func(obj interface{}) { 
-            log.Printf("CR delete event - about to call handleCRDelete with obj type=%T", obj)
-            w.handleCRDelete(obj) 
-            log.Printf("CR delete event - handleCRDelete call completed")
+            // node cleanup already ran before the finalizer was released (see Controller.finalize)
+            if u := unwrap(obj); u != nil { log.Printf("CR deleted: %s/%s", u.GetNamespace(), u.GetName()) }
+            // a deleted CR releases its MACs/IPs (and its rollout slot)
+            w.requeueConflicts(crInformer.GetStore(), obj)
+            w.requeueRolloutWaiting(crInformer.GetStore())
         },
     })
 
+    // a Job status change only concerns the CR of its node
     w.JobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-        UpdateFunc: func(oldObj, newObj interface{}) {
-            // On job status change, update CR statuses (reduced logging)
-            _ = w.Ctrl.ProcessJobs(context.Background(), w.Namespace)
-        },
+        AddFunc:    func(obj interface{}) { w.handleJob(obj) },
+        UpdateFunc: func(oldObj, newObj interface{}) { w.handleJob(newObj) },
     })
 
+    synced := []cache.InformerSynced{crInformer.HasSynced, w.JobInformer.Informer().HasSynced}
+    // cluster-scoped policies share the CR factory (the namespace option does not apply to them)
+    if policiesServed(ctx, w.Ctrl.MultiNic) {
+        w.PolicyInformer = w.CRInformerFactory.Multinic().V1alpha1().MultiNicClusterPolicies()
+        w.Ctrl.Policies = w.PolicyInformer.Lister()
+    }
+    if w.PolicyInformer != nil {
+        pi := w.PolicyInformer.Informer()
+        // a policy change can alter the effective spec of every node it selects (or selected)
+        pi.AddEventHandler(cache.ResourceEventHandlerFuncs{
+            AddFunc: func(obj interface{}) { w.reconcileAll(crInformer.GetStore()) },
+            UpdateFunc: func(oldObj, newObj interface{}) {
+                if specChanged(oldObj, newObj) { w.reconcileAll(crInformer.GetStore()) }
+            },
+            DeleteFunc: func(obj interface{}) { w.reconcileAll(crInformer.GetStore()) },
+        })
+        synced = append(synced, pi.HasSynced)
+    }
+
     w.PodInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
         AddFunc: func(obj interface{}) { w.handlePod(obj) },
         UpdateFunc: func(oldObj, newObj interface{}) { w.handlePod(newObj) },
@@ -70,96 +134,197 @@ func (w *Watcher) Start(ctx context.Context) error {
 
     stop := make(chan struct{})
     defer close(stop)
+    defer w.Queue.ShutDown()
     go w.CRInformerFactory.Start(stop)
     go w.JobInformer.Informer().Run(stop)
     go w.PodInformer.Informer().Run(stop)
 
-    // initial reconcile/cleanup pass for existing CRs/Jobs
-    _ = w.Ctrl.ProcessAll(context.Background(), w.Namespace)
-    _ = w.Ctrl.ProcessJobs(context.Background(), w.Namespace)
-
-    // Wait for cache sync
-    if !cache.WaitForCacheSync(stop, crInformer.HasSynced, w.JobInformer.Informer().HasSynced) {
+    // Wait for cache sync (the conflict index needs every CR before Jobs are scheduled)
+    if !cache.WaitForCacheSync(stop, synced...) {
         return context.Canceled
     }
 
+    // existing CRs and Jobs were queued by the initial add events; refresh the interface
+    // states once as the former full pass did
+    for _, obj := range crInformer.GetStore().List() {
+        if cr, ok := obj.(*multinicv1alpha1.MultiNicNodeConfig); ok {
+            _ = w.Ctrl.updateInterfaceStates(ctx, cr.Namespace, cr.Name)
+        }
+    }
+
+    workers := w.Workers
+    if workers <= 0 { workers = 1 }
+    log.Printf("watcher: starting %d workers", workers)
+    for i := 0; i < workers; i++ {
+        go wait.UntilWithContext(ctx, w.runWorker, time.Second)
+    }
+    if w.Ctrl.ResyncInterval > 0 { go w.runResync(ctx) }
+
     <-ctx.Done()
     return nil
 }
 
-// handleCR은 CR add/update 이벤트를 Reconcile로 전달한다.
+// runResync는 ResyncInterval마다 Configured CR의 드리프트 확인을 시작한다. verify Job 결과는
+// Job 이벤트로 큐를 거쳐 반영된다.
+func (w *Watcher) runResync(ctx context.Context) {
+    log.Printf("watcher: drift resync every %s (source=%s)", w.Ctrl.ResyncInterval, w.Ctrl.DriftSource)
+    ticker := time.NewTicker(w.Ctrl.ResyncInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := w.Ctrl.Resync(ctx, w.Namespace); err != nil { log.Printf("drift resync failed: %v", err) }
+        }
+    }
+}
+
+// runWorker는 큐가 종료될 때까지 키를 하나씩 꺼내 처리한다.
+func (w *Watcher) runWorker(ctx context.Context) {
+    for w.processNextItem(ctx) {
+    }
+}
+
+// processNextItem은 키 하나를 처리한다. 실패한 키는 지수 백오프로 다시 큐에 넣고, 성공하면 백오프를 초기화한다.
+func (w *Watcher) processNextItem(ctx context.Context) bool {
+    key, shutdown := w.Queue.Get()
+    if shutdown { return false }
+    defer w.Queue.Done(key)
+
+    if err := w.sync(ctx, key); err != nil {
+        var requeue *RequeueAfter
+        if errors.As(err, &requeue) {
+            // a scheduled wake-up (maintenance window), not a failure: no backoff
+            w.Queue.Forget(key)
+            w.Queue.AddAfter(key, requeue.Delay)
+            return true
+        }
+        log.Printf("reconcile %s failed (retry %d): %v", key, w.Queue.NumRequeues(key)+1, err)
+        w.Queue.AddRateLimited(key)
+        return true
+    }
+    w.Queue.Forget(key)
+    return true
+}
+
+// sync는 노드 Job 결과를 CR 상태에 반영한 뒤 CR을 Reconcile한다.
+func (w *Watcher) sync(ctx context.Context, key string) error {
+    namespace, name, err := cache.SplitMetaNamespaceKey(key)
+    if err != nil {
+        log.Printf("dropping malformed key %q: %v", key, err)
+        return nil
+    }
+    // Job results first, so that Reconcile sees the state left by the last Job
+    sel := labels.SelectorFromSet(labels.Set{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": name})
+    jobs, err := w.JobInformer.Lister().Jobs(namespace).List(sel)
+    if err != nil { return err }
+    for _, job := range jobs {
+        if err := w.Ctrl.ProcessJob(ctx, namespace, job); err != nil { return err }
+    }
+    if err := w.Reconcile(ctx, namespace, name); err != nil {
+        // the CR is gone once its finalizer was released
+        if apierrors.IsNotFound(err) { return nil }
+        return err
+    }
+    return nil
+}
+
+// enqueue는 CR 키를 큐에 넣는다. 이미 대기 중인 키는 한 번만 처리된다.
+func (w *Watcher) enqueue(namespace, name string) {
+    w.Queue.Add(namespace + "/" + name)
+}
+
+// handleCR은 CR add/update 이벤트를 큐에 넣는다.
 func (w *Watcher) handleCR(obj interface{}) {
-    u := unwrap(obj)
-    if u != nil { _ = w.Reconcile(context.Background(), u.GetNamespace(), u.GetName()) }
-    // attempt meta access via accessor if needed (omitted for brevity)
+    if u := unwrap(obj); u != nil { w.enqueue(u.GetNamespace(), u.GetName()) }
+}
+
+// handleJob은 agent Job 이벤트를 해당 노드 CR 키로 매핑한다 (CR 이름 = 노드 이름).
+// cleanup Job 완료는 삭제 중인 CR의 finalizer 제거를 진행시킨다.
+func (w *Watcher) handleJob(obj interface{}) {
+    job, ok := obj.(*batchv1.Job)
+    if !ok || job.Labels["app.kubernetes.io/name"] != "multinic-agent" { return }
+    if nodeName := job.Labels["multinic.io/node-name"]; nodeName != "" {
+        w.enqueue(job.Namespace, nodeName)
+    }
+}
+
+// requeueConflicts는 CR 변경/삭제로 MAC/IP 선점이 바뀌었을 때 충돌 상태인 다른 CR을 다시 큐에 넣는다.
+func (w *Watcher) requeueConflicts(store cache.Store, changed interface{}) {
+    src := unwrap(changed)
+    for _, obj := range store.List() {
+        cr, ok := obj.(*multinicv1alpha1.MultiNicNodeConfig)
+        if !ok || !hasConflict(cr) { continue }
+        if src != nil && cr.Namespace == src.GetNamespace() && cr.Name == src.GetName() { continue }
+        w.enqueue(cr.Namespace, cr.Name)
+    }
+}
+
+// requeueRolloutWaiting은 롤아웃 슬롯이 비었을 때 RolloutWaiting 상태인 CR을 다시 큐에 넣는다.
+func (w *Watcher) requeueRolloutWaiting(store cache.Store) {
+    for _, obj := range store.List() {
+        if cr, ok := obj.(*multinicv1alpha1.MultiNicNodeConfig); ok && hasRolloutWaiting(cr) {
+            w.enqueue(cr.Namespace, cr.Name)
+        }
+    }
+}
+
+// rolloutReleased reports whether a CR left InProgress (frees a slot and may end the worker
+// wave) or Failed (lowers the failure count)
+func rolloutReleased(oldObj, newObj interface{}) bool {
+    o, ok1 := oldObj.(*multinicv1alpha1.MultiNicNodeConfig)
+    n, ok2 := newObj.(*multinicv1alpha1.MultiNicNodeConfig)
+    if !ok1 || !ok2 || o.Status.State == n.Status.State { return false }
+    return o.Status.State == multinicv1alpha1.StateInProgress || o.Status.State == multinicv1alpha1.StateFailed
+}
+
+// reconcileAll은 클러스터 정책 변경 시 캐시의 모든 CR을 다시 큐에 넣는다.
+func (w *Watcher) reconcileAll(store cache.Store) {
+    for _, obj := range store.List() {
+        if cr, ok := obj.(*multinicv1alpha1.MultiNicNodeConfig); ok {
+            w.enqueue(cr.Namespace, cr.Name)
+        }
+    }
 }
 
-// handlePod는 Pod 종료 메시지를 수집해 실패 요약을 CR에 반영한다.
+// specChanged는 update 이벤트에서 spec(generation)이 바뀌었는지 확인한다. 상태만 바뀐 이벤트는 제외한다.
+func specChanged(oldObj, newObj interface{}) bool {
+    o, n := unwrap(oldObj), unwrap(newObj)
+    return o == nil || n == nil || o.GetGeneration() != n.GetGeneration()
+}
+
+// handlePod는 agent Pod가 종료 메시지를 남기면 해당 노드 CR 키를 큐에 넣는다.
+// 요약은 worker의 ProcessJob이 읽으므로 여기서는 CR을 직접 갱신하지 않는다.
 func (w *Watcher) handlePod(obj interface{}) {
     pod, ok := obj.(*corev1.Pod)
     if !ok || pod == nil { return }
     // Only Multinic agent pods
     if pod.Labels["app.kubernetes.io/name"] != "multinic-agent" { return }
-    // Look for terminated states
-    var msg string
-    var exitCode int32 = -1
+    terminated := false
     for _, cs := range pod.Status.ContainerStatuses {
-        if cs.State.Terminated != nil {
-            if cs.State.Terminated.Message != "" {
-                msg = cs.State.Terminated.Message
-            }
-            exitCode = cs.State.Terminated.ExitCode
-        }
+        if cs.State.Terminated != nil && cs.State.Terminated.Message != "" { terminated = true }
     }
-    if msg == "" { return }
-    // Derive node/CR name from job name label or job reference
     jobName := pod.Labels["job-name"]
-    nodeName := pod.Labels["multinic.io/node-name"]
-    if nodeName == "" && jobName != "" {
-        const prefix = "multinic-agent-"
-        if len(jobName) > len(prefix) && jobName[:len(prefix)] == prefix { nodeName = jobName[len(prefix):] }
-    }
-    if nodeName == "" { return }
-    // Only act on failure (non-zero exit) to avoid racing the success path
-    if exitCode != 0 {
-        _ = w.Ctrl.ApplyTerminationSummary(context.Background(), w.Namespace, nodeName, jobName, msg)
-    }
-}
-
-// handleCRDelete는 CR 삭제 시 cleanup Job을 실행한다.
-func (w *Watcher) handleCRDelete(obj interface{}) {
-    log.Printf("handleCRDelete: FUNCTION CALLED with obj type=%T", obj)
-    u := unwrap(obj)
-    if u == nil { 
-        log.Printf("handleCRDelete: ERROR - failed to unwrap deleted object, obj=%+v", obj)
-        return 
-    }
-    nodeName := u.GetName()
-    log.Printf("handleCRDelete: SUCCESS - unwrapped object, nodeName=%s, namespace=%s", nodeName, u.GetNamespace())
-    log.Printf("handleCRDelete: attempting to launch cleanup job for node=%s", nodeName)
-    // launch cleanup job to remove interfaces on CR delete
-    if err := w.Ctrl.LaunchCleanupJob(context.Background(), w.Namespace, nodeName); err != nil {
-        log.Printf("handleCRDelete: failed to launch cleanup job for node=%s: %v", nodeName, err)
-    } else {
-        log.Printf("handleCRDelete: cleanup job launch initiated for node=%s", nodeName)
-    }
+    if !terminated || jobName == "" { return }
+    // the pod template carries no node label; the owning Job does
+    job, err := w.JobInformer.Lister().Jobs(pod.Namespace).Get(jobName)
+    if err != nil { return }
+    w.handleJob(job)
 }
 
-// unwrap은 DeletedFinalStateUnknown을 포함해 *unstructured.Unstructured로 변환한다.
-func unwrap(obj interface{}) *unstructured.Unstructured {
-    switch t := obj.(type) {
-    case *unstructured.Unstructured:
-        return t
-    case cache.DeletedFinalStateUnknown:
-        if u, ok := t.Obj.(*unstructured.Unstructured); ok { 
-            return u 
-        }
+// unwrap은 DeletedFinalStateUnknown을 포함해 이벤트 객체의 name/namespace 접근자를 꺼낸다.
+func unwrap(obj interface{}) metav1.Object {
+    if t, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+        obj = t.Obj
+    }
+    if m, ok := obj.(metav1.Object); ok {
+        return m
     }
     if u, ok := obj.(interface{ GetName() string; GetNamespace() string }); ok {
-        // not strictly *unstructured.Unstructured but has name/ns accessors
-        uu := &unstructured.Unstructured{}
-        uu.SetName(u.GetName()); uu.SetNamespace(u.GetNamespace())
-        return uu
+        // not a full API object but has name/ns accessors
+        m := &metav1.ObjectMeta{Name: u.GetName(), Namespace: u.GetNamespace()}
+        return m
     }
     return nil
 }
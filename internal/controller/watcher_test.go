@@ -2,10 +2,18 @@ package controller
 
 import (
     "context"
+    "errors"
     "testing"
+    "time"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// Minimal test to ensure handler invokes Reconcile with name/namespace
+// CR events are queued by key and a worker hands the key to Reconcile
 func TestWatcher_HandleCR_InvokesReconcile(t *testing.T) {
     calls := 0
     ctrl := &Controller{}
@@ -19,9 +27,75 @@ func TestWatcher_HandleCR_InvokesReconcile(t *testing.T) {
     }
     obj := &fakeMeta{name: "worker-node-01", namespace: "multinic-system"}
     w.handleCR(obj)
+    w.handleCR(obj)
+    if calls != 0 { t.Fatalf("handler must not reconcile inline, got %d calls", calls) }
+    if w.Queue.Len() != 1 { t.Fatalf("expected duplicate events to collapse into one key, got %d", w.Queue.Len()) }
+    w.processNextItem(context.Background())
     if calls != 1 { t.Fatalf("expected 1 call, got %d", calls) }
 }
 
+func TestWatcher_ProcessNextItem_RetriesWithBackoff(t *testing.T) {
+    w := NewWatcher(&Controller{}, "multinic-system")
+    w.Queue = NewWorkQueue(time.Millisecond, 10*time.Millisecond)
+    var err error = errors.New("api unavailable")
+    w.Reconcile = func(ctx context.Context, ns, name string) error { return err }
+
+    w.enqueue("multinic-system", "worker-node-01")
+    w.processNextItem(context.Background())
+    if got := w.Queue.NumRequeues("multinic-system/worker-node-01"); got != 1 {
+        t.Fatalf("expected the failed key to be requeued once, got %d", got)
+    }
+    // the key comes back after the backoff; a successful reconcile resets it
+    err = nil
+    w.processNextItem(context.Background())
+    if got := w.Queue.NumRequeues("multinic-system/worker-node-01"); got != 0 {
+        t.Fatalf("expected backoff reset after success, got %d", got)
+    }
+
+    // a CR deleted meanwhile is not retried
+    err = apierrors.NewNotFound(schema.GroupResource{Group: "multinic.io", Resource: "multinicnodeconfigs"}, "worker-node-02")
+    w.enqueue("multinic-system", "worker-node-02")
+    w.processNextItem(context.Background())
+    if w.Queue.NumRequeues("multinic-system/worker-node-02") != 0 { t.Fatalf("NotFound must not be retried") }
+}
+
+func TestWatcher_HandleJob_MapsToNodeKey(t *testing.T) {
+    w := NewWatcher(&Controller{}, "multinic-system")
+    job := func(name string, labels map[string]string) *batchv1.Job {
+        return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "multinic-system", Labels: labels}}
+    }
+    w.handleJob(job("multinic-agent-worker-node-01-g2", map[string]string{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": "worker-node-01"}))
+    w.handleJob(job("multinic-agent-cleanup-worker-node-02", map[string]string{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": "worker-node-02", "multinic.io/action": "cleanup"}))
+    w.handleJob(job("other", map[string]string{"multinic.io/node-name": "worker-node-03"}))
+    if w.Queue.Len() != 2 { t.Fatalf("expected the apply and cleanup jobs to be queued, got %d keys", w.Queue.Len()) }
+    key, _ := w.Queue.Get()
+    if key != "multinic-system/worker-node-01" { t.Fatalf("expected the node CR key, got %q", key) }
+    // a cleanup Job drives the finalizer of the deleted CR
+    key, _ = w.Queue.Get()
+    if key != "multinic-system/worker-node-02" { t.Fatalf("expected the cleanup job's CR key, got %q", key) }
+}
+
+// a terminated agent pod only queues its node's key; the summary is read by the worker
+func TestWatcher_HandlePod_QueuesJobNodeKey(t *testing.T) {
+    w := NewWatcher(&Controller{}, "multinic-system")
+    job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "multinic-agent-worker-node-01-g2", Namespace: "multinic-system",
+        Labels: map[string]string{"app.kubernetes.io/name": "multinic-agent", "multinic.io/node-name": "worker-node-01"}}}
+    if err := w.JobInformer.Informer().GetIndexer().Add(job); err != nil { t.Fatalf("add job: %v", err) }
+    pod := func(jobName, msg string) *corev1.Pod {
+        return &corev1.Pod{
+            ObjectMeta: metav1.ObjectMeta{Name: jobName + "-abcde", Namespace: "multinic-system",
+                Labels: map[string]string{"app.kubernetes.io/name": "multinic-agent", "job-name": jobName}},
+            Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{
+                Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: msg}}}}},
+        }
+    }
+    w.handlePod(pod("multinic-agent-worker-node-01-g2", ""))
+    w.handlePod(pod("multinic-agent-worker-node-02-g1", `{"failures":[]}`))
+    if w.Queue.Len() != 0 { t.Fatalf("expected no key without a message or a known job, got %d", w.Queue.Len()) }
+    w.handlePod(pod("multinic-agent-worker-node-01-g2", `{"failures":[]}`))
+    if key, _ := w.Queue.Get(); key != "multinic-system/worker-node-01" { t.Fatalf("expected the node CR key, got %q", key) }
+}
+
 type fakeMeta struct{ name, namespace string }
 func (f *fakeMeta) GetName() string      { return f.name }
 func (f *fakeMeta) GetNamespace() string { return f.namespace }
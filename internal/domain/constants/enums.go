@@ -29,6 +29,8 @@ type AgentAction string
 const (
 	AgentActionCleanup   AgentAction = "cleanup"
 	AgentActionConfigure AgentAction = "configure"
+	// AgentActionVerify는 노드를 바꾸지 않고 실제 상태만 보고합니다
+	AgentActionVerify AgentAction = "verify"
 )
 
 // String은 AgentAction의 문자열 표현을 반환합니다
@@ -39,7 +41,7 @@ func (a AgentAction) String() string {
 // IsValid는 AgentAction이 유효한지 확인합니다
 func (a AgentAction) IsValid() bool {
 	switch a {
-	case AgentActionCleanup, AgentActionConfigure:
+	case AgentActionCleanup, AgentActionConfigure, AgentActionVerify:
 		return true
 	default:
 		return false
@@ -0,0 +1,106 @@
+package constants
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 인터페이스 이름 규칙 기본값
+const (
+	DefaultInterfacePrefix = "multinic"
+	DefaultMaxInterfaces   = 10
+
+	// 설정 파일 접두 숫자 (9X-<name>.yaml 등). 배포판 기본 파일(00-~50-)보다 뒤에 읽히도록 한다.
+	configFileLead = "9"
+)
+
+// 이름 접두사와 최대 개수는 설정(INTERFACE_PREFIX, MAX_INTERFACES)으로 바뀔 수 있으므로
+// 프로세스 시작 시 SetInterfaceNaming으로 한 번 지정하고 이후에는 읽기만 한다.
+var (
+	interfacePrefix = DefaultInterfacePrefix
+	maxInterfaces   = DefaultMaxInterfaces
+)
+
+// SetInterfaceNaming은 관리 인터페이스 이름 접두사와 최대 개수를 지정합니다.
+// 빈 접두사나 0 이하의 개수는 기존 값을 유지합니다.
+func SetInterfaceNaming(prefix string, max int) {
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		interfacePrefix = prefix
+	}
+	if max > 0 {
+		maxInterfaces = max
+	}
+}
+
+// InterfacePrefix는 관리 인터페이스 이름 접두사를 반환합니다 (기본: multinic)
+func InterfacePrefix() string {
+	return interfacePrefix
+}
+
+// MaxInterfaces는 노드당 관리 가능한 인터페이스 수를 반환합니다
+func MaxInterfaces() int {
+	return maxInterfaces
+}
+
+// InterfaceName은 index번째 관리 인터페이스 이름을 반환합니다 (예: multinic0)
+func InterfaceName(index int) string {
+	return fmt.Sprintf("%s%d", interfacePrefix, index)
+}
+
+// ParseInterfaceIndex는 관리 인터페이스 이름에서 인덱스를 추출합니다.
+// 접두사 뒤가 숫자로만 이루어진 경우에만 ok=true (multinic0.100, multinic-br 등은 제외)
+func ParseInterfaceIndex(name string) (int, bool) {
+	rest, ok := strings.CutPrefix(name, interfacePrefix)
+	if !ok || rest == "" {
+		return 0, false
+	}
+	for _, c := range rest {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	idx, err := strconv.Atoi(rest)
+	if err != nil {
+		return 0, false
+	}
+	return idx, true
+}
+
+// IsManagedInterfaceName은 name이 관리 인터페이스 이름 규칙을 따르는지 확인합니다
+func IsManagedInterfaceName(name string) bool {
+	_, ok := ParseInterfaceIndex(name)
+	return ok
+}
+
+// ConfigFilePrefix는 index번째 인터페이스의 설정 파일 접두사를 반환합니다.
+// 인덱스는 최대 개수 자릿수만큼 0으로 채워 사전순과 인덱스 순서를 일치시킨다
+// (최대 10개: 90-~99-, 최대 100개: 900-~999-).
+func ConfigFilePrefix(index int) string {
+	width := len(strconv.Itoa(maxInterfaces - 1))
+	return fmt.Sprintf("%s%0*d-", configFileLead, width, index)
+}
+
+// ConfigFileName은 인터페이스(또는 그에 딸린 VLAN/bridge 등) 설정 파일 이름을 반환합니다
+func ConfigFileName(index int, name, ext string) string {
+	return ConfigFilePrefix(index) + name + ext
+}
+
+// ParseConfigFileName은 ConfigFileName 형식의 파일 이름에서 name을 추출합니다.
+// 자릿수와 무관하게 동작하므로 최대 개수 변경 전 파일도 인식된다.
+func ParseConfigFileName(file, ext string) (string, bool) {
+	base, ok := strings.CutSuffix(file, ext)
+	if !ok || !strings.HasPrefix(base, configFileLead) {
+		return "", false
+	}
+	digits, name, ok := strings.Cut(base[len(configFileLead):], "-")
+	if !ok || digits == "" || name == "" {
+		return "", false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	return name, true
+}
@@ -0,0 +1,53 @@
+package constants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFileNaming(t *testing.T) {
+	defer SetInterfaceNaming(DefaultInterfacePrefix, DefaultMaxInterfaces)
+
+	// 기본 10개는 기존 90-~99- 파일 이름을 유지한다
+	assert.Equal(t, "93-multinic3.yaml", ConfigFileName(3, "multinic3", ".yaml"))
+
+	SetInterfaceNaming("stor", 24)
+	assert.Equal(t, "stor12", InterfaceName(12))
+	// 두 자리 인덱스는 0으로 채워 사전순 정렬이 인덱스 순서와 같아야 한다
+	a, b := ConfigFileName(2, "stor2", ".yaml"), ConfigFileName(10, "stor10", ".yaml")
+	assert.Equal(t, "902-stor2.yaml", a)
+	assert.Equal(t, "910-stor10.yaml", b)
+	assert.Less(t, a, b)
+}
+
+func TestParseInterfaceAndFileNames(t *testing.T) {
+	defer SetInterfaceNaming(DefaultInterfacePrefix, DefaultMaxInterfaces)
+	SetInterfaceNaming("stor", 24)
+
+	tests := []struct {
+		name  string
+		index int
+		ok    bool
+	}{
+		{"stor0", 0, true},
+		{"stor17", 17, true},
+		{"stor", 0, false},
+		{"stor1.100", 0, false},
+		{"multinic1", 0, false},
+	}
+	for _, tt := range tests {
+		idx, ok := ParseInterfaceIndex(tt.name)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.index, idx, tt.name)
+	}
+
+	// 자릿수가 달라진 이전 파일도 같은 이름으로 인식한다
+	for _, f := range []string{"91-stor1.yaml", "901-stor1.yaml"} {
+		name, ok := ParseConfigFileName(f, ".yaml")
+		assert.True(t, ok, f)
+		assert.Equal(t, "stor1", name, f)
+	}
+	_, ok := ParseConfigFileName("50-cloud-init.yaml", ".yaml")
+	assert.False(t, ok)
+}
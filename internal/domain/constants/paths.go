@@ -27,10 +27,6 @@ const (
 
 // 네트워크 설정 관련 상수들
 const (
-	// 인터페이스 이름 패턴
-	InterfacePrefix = "multinic"
-	MaxInterfaces   = 10
-
 	// 파일 권한
 	ConfigFilePermission = 0644
 
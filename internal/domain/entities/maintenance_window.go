@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"multinic-agent/internal/domain/errors"
+)
+
+// maxWindowDuration는 유지보수 창 길이의 상한입니다 (창이 계속 열려 있는 일정을 막음)
+const maxWindowDuration = 7 * 24 * time.Hour
+
+// cronSearchLimit는 다음 창 시작 시각을 찾는 최대 탐색 범위입니다 (2월 29일 같은 일정도 포함)
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// MaintenanceWindow는 네트워크 변경을 허용하는 반복 시간 창을 나타내는 값 객체입니다.
+// 창은 cron 일정(분 시 일 월 요일)이 일치하는 분에 열리고 duration 동안 유지됩니다.
+type MaintenanceWindow struct {
+	schedule cronSchedule
+	duration time.Duration
+	location *time.Location
+	spec     string
+}
+
+// NewMaintenanceWindow는 cron 일정(VAL042), 기간과 시간대(VAL043)를 검증해 유지보수 창을 생성합니다.
+// timeZone이 비어 있으면 UTC를 사용합니다.
+func NewMaintenanceWindow(schedule, duration, timeZone string) (*MaintenanceWindow, error) {
+	sched, err := parseCron(schedule)
+	if err != nil {
+		return nil, errors.NewValidationErrorWithCode("VAL042", fmt.Sprintf("invalid maintenance window schedule %q: %v", schedule, err), nil)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(duration))
+	if err != nil || d < time.Minute || d > maxWindowDuration {
+		return nil, errors.NewValidationErrorWithCode("VAL043", fmt.Sprintf("invalid maintenance window duration %q (1m to %s)", duration, maxWindowDuration), nil)
+	}
+	loc := time.UTC
+	if tz := strings.TrimSpace(timeZone); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, errors.NewValidationErrorWithCode("VAL043", fmt.Sprintf("invalid maintenance window time zone %q", timeZone), nil)
+		}
+	}
+	return &MaintenanceWindow{schedule: sched, duration: d, location: loc, spec: strings.Join(strings.Fields(schedule), " ")}, nil
+}
+
+// Duration은 창이 열려 있는 시간을 반환합니다
+func (w *MaintenanceWindow) Duration() time.Duration {
+	return w.duration
+}
+
+// String은 "일정/기간 (시간대)" 형태의 표현을 반환합니다
+func (w *MaintenanceWindow) String() string {
+	return fmt.Sprintf("%q for %s (%s)", w.spec, w.duration, w.location)
+}
+
+// IsOpen은 t가 열린 창 안에 있는지 반환합니다: (t-duration, t] 사이에 시작한 창이 있으면 열려 있습니다
+func (w *MaintenanceWindow) IsOpen(t time.Time) bool {
+	from := t.Add(-w.duration).In(w.location).Truncate(time.Minute).Add(time.Minute)
+	start, ok := w.schedule.next(from, t)
+	return ok && !start.After(t)
+}
+
+// NextOpen은 t 이후 처음으로 창이 열리는 시각을 반환합니다 (일정이 다시 오지 않으면 false)
+func (w *MaintenanceWindow) NextOpen(t time.Time) (time.Time, bool) {
+	from := t.In(w.location).Truncate(time.Minute).Add(time.Minute)
+	return w.schedule.next(from, from.Add(cronSearchLimit))
+}
+
+// cronSchedule은 5필드 cron 식의 각 필드에서 허용되는 값 집합입니다
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar/dowStar: 일/요일 필드가 "*"이면 다른 필드만으로 날짜를 판정 (표준 cron 규칙)
+	domStar, dowStar bool
+}
+
+// parseCron은 "분 시 일 월 요일" 식을 해석합니다. 각 필드는 *, 값, 범위(a-b), 목록(a,b), 간격(*/n, a-b/n)을 지원하고
+// 요일의 7은 일요일(0)과 같습니다.
+func parseCron(expr string) (cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	var s cronSchedule
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return s, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return s, fmt.Errorf("hour: %w", err)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return s, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return s, fmt.Errorf("month: %w", err)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return s, fmt.Errorf("day of week: %w", err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+	return s, nil
+}
+
+// parseCronField는 필드 하나를 min..max 범위의 비트 집합으로 변환합니다
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				// "a/n" means a, a+n, ... up to max
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", rangePart, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	// both restricted: either one matches (standard cron)
+	return dom || dow
+}
+
+// next는 from(분 단위로 정렬된 시각) 이후 일정과 일치하는 첫 분을 limit까지 찾습니다.
+// 일치하지 않는 월/일/시는 통째로 건너뜁니다.
+func (s cronSchedule) next(from, limit time.Time) (time.Time, bool) {
+	t := from
+	for !t.After(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			// Truncate works on absolute time, which is off by the zone offset in half-hour zones
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
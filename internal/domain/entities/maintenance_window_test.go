@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaintenanceWindow_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		duration string
+		timeZone string
+		code     string
+	}{
+		{name: "valid", schedule: "0 22 * * 1-5", duration: "2h"},
+		{name: "lists and steps", schedule: "*/15 0,12 1-7 */2 0", duration: "30m", timeZone: "Asia/Seoul"},
+		{name: "four fields", schedule: "0 22 * *", duration: "2h", code: "VAL042"},
+		{name: "hour out of range", schedule: "0 24 * * *", duration: "2h", code: "VAL042"},
+		{name: "bad step", schedule: "*/0 * * * *", duration: "2h", code: "VAL042"},
+		{name: "bad duration", schedule: "0 22 * * *", duration: "two hours", code: "VAL043"},
+		{name: "duration too long", schedule: "0 22 * * *", duration: "200h", code: "VAL043"},
+		{name: "unknown time zone", schedule: "0 22 * * *", duration: "2h", timeZone: "Mars/Olympus", code: "VAL043"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewMaintenanceWindow(tt.schedule, tt.duration, tt.timeZone)
+			if tt.code == "" {
+				require.NoError(t, err)
+				assert.NotNil(t, w)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.code)
+		})
+	}
+}
+
+func TestMaintenanceWindow_IsOpenAndNextOpen(t *testing.T) {
+	// weekdays 22:00-24:00 KST
+	w, err := NewMaintenanceWindow("0 22 * * 1-5", "2h", "Asia/Seoul")
+	require.NoError(t, err)
+	kst, _ := time.LoadLocation("Asia/Seoul")
+
+	friday := func(h, m int) time.Time { return time.Date(2026, 10, 16, h, m, 0, 0, kst) }
+	assert.False(t, w.IsOpen(friday(21, 59)))
+	assert.True(t, w.IsOpen(friday(22, 0)))
+	assert.True(t, w.IsOpen(friday(23, 59)))
+	// Saturday 00:00 is the end of Friday's window
+	assert.False(t, w.IsOpen(friday(24, 0)))
+
+	next, ok := w.NextOpen(friday(12, 0))
+	require.True(t, ok)
+	assert.True(t, next.Equal(friday(22, 0)), "got %s", next)
+
+	// after Friday's window the next one opens on Monday
+	next, ok = w.NextOpen(friday(23, 0))
+	require.True(t, ok)
+	assert.True(t, next.Equal(time.Date(2026, 10, 19, 22, 0, 0, 0, kst)), "got %s", next)
+}
+
+func TestMaintenanceWindow_DayOfMonthOrWeekday(t *testing.T) {
+	// both day fields restricted: the 1st of the month or any Sunday
+	w, err := NewMaintenanceWindow("0 3 1 * 0", "1h", "")
+	require.NoError(t, err)
+	assert.True(t, w.IsOpen(time.Date(2026, 10, 1, 3, 30, 0, 0, time.UTC)))  // Thursday the 1st
+	assert.True(t, w.IsOpen(time.Date(2026, 10, 18, 3, 30, 0, 0, time.UTC))) // Sunday
+	assert.False(t, w.IsOpen(time.Date(2026, 10, 17, 3, 30, 0, 0, time.UTC)))
+}
+
+func TestMaintenanceWindow_HalfHourZone(t *testing.T) {
+	w, err := NewMaintenanceWindow("0 2 * * *", "1h", "Asia/Kolkata")
+	require.NoError(t, err)
+	ist, _ := time.LoadLocation("Asia/Kolkata")
+	next, ok := w.NextOpen(time.Date(2026, 10, 16, 0, 10, 0, 0, ist))
+	require.True(t, ok)
+	assert.True(t, next.Equal(time.Date(2026, 10, 16, 2, 0, 0, 0, ist)), "got %s", next.In(ist))
+}
@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"strconv"
+	"strings"
 
 	"multinic-agent/internal/domain/constants"
 	domainErrors "multinic-agent/internal/domain/errors"
@@ -21,6 +21,24 @@ type NetworkInterface struct {
 	mtu           *MTU
 	interfaceName *InterfaceName
 	explicitName  bool
+	// extraAddresses holds secondary addresses (VIPs, dual-stack pair) beyond the primary one
+	extraAddresses []InterfaceAddress
+	routes         []Route
+	gateway        *IPAddress
+	vlans          []VLAN
+	// bond가 설정되면 macAddress는 bond master에 고정할 MAC(멤버 중 하나)이다
+	bond *Bond
+	// bridge가 설정되면 이 인터페이스는 bridge의 포트가 된다
+	bridge *Bridge
+	// vrf가 설정되면 주소를 가진 디바이스(AddressDevice)가 VRF에 소속된다
+	vrf *VRF
+	// 패밀리별 주소 할당 방식 (빈 값은 static)
+	ipv4Mode AddressMode
+	ipv6Mode AddressMode
+	// 이 인터페이스(AddressDevice)에 연결되는 네임서버/검색 도메인
+	dns *DNS
+	// CR(spec.options, interfaces[].options)에서 온 에이전트 네트워크 옵션 오버라이드
+	options *NetworkOptions
 }
 
 // NewNetworkInterface creates a new NetworkInterface with validatio
@@ -80,6 +98,50 @@ func NewNetworkInterface(id int, macAddr, nodeNameStr, ipAddr, cidrStr string, m
 	}, nil
 }
 
+// NewNetworkInterfaceWithoutAddress creates a NetworkInterface that has no static primary address,
+// for interfaces whose addressing comes from DHCP or is disabled (see SetAddressModes).
+// name이 비어 있으면 id로 이름을 만든다.
+func NewNetworkInterfaceWithoutAddress(id int, name, macAddr, nodeNameStr string, mtuValue int) (*NetworkInterface, error) {
+	interfaceIndex, err := NewInterfaceIndex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	macAddress, err := NewMACAddress(macAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeName, err := NewNodeName(nodeNameStr)
+	if err != nil {
+		return nil, err
+	}
+
+	mtu, err := NewMTU(mtuValue)
+	if err != nil {
+		return nil, err
+	}
+
+	explicit := name != ""
+	if !explicit {
+		name = interfaceIndex.ToInterfaceName()
+	}
+	interfaceName, err := NewInterfaceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NetworkInterface{
+		id:            interfaceIndex,
+		macAddress:    macAddress,
+		nodeName:      nodeName,
+		status:        StatusPending,
+		mtu:           mtu,
+		interfaceName: interfaceName,
+		explicitName:  explicit,
+	}, nil
+}
+
 // NewNetworkInterfaceWithName creates a new NetworkInterface with an explicit interface name.
 // name이 제공되면 이름을 우선 적용하고, id는 순서 식별자로만 사용한다.
 func NewNetworkInterfaceWithName(id int, name, macAddr, nodeNameStr, ipAddr, cidrStr string, mtuValue int) (*NetworkInterface, error) {
@@ -156,13 +218,33 @@ func (ni *NetworkInterface) Status() InterfaceStatus {
 }
 
 func (ni *NetworkInterface) Address() string {
+	if ni.ipAddress == nil {
+		return ""
+	}
 	return ni.ipAddress.String()
 }
 
 func (ni *NetworkInterface) CIDR() string {
+	if ni.cidr == nil {
+		return ""
+	}
 	return ni.cidr.String()
 }
 
+// Addresses returns every address of the interface, primary first
+func (ni *NetworkInterface) Addresses() []InterfaceAddress {
+	out := make([]InterfaceAddress, 0, 1+len(ni.extraAddresses))
+	if ni.ipAddress != nil && ni.cidr != nil {
+		out = append(out, InterfaceAddress{ip: ni.ipAddress, cidr: ni.cidr})
+	}
+	return append(out, ni.extraAddresses...)
+}
+
+// IsIPv6 returns true if the interface address is an IPv6 address
+func (ni *NetworkInterface) IsIPv6() bool {
+	return ni.ipAddress != nil && ni.ipAddress.IsIPv6()
+}
+
 func (ni *NetworkInterface) MTU() int {
 	return ni.mtu.Value()
 }
@@ -216,6 +298,268 @@ func (ni *NetworkInterface) UpdateMTU(newMTU int) error {
 	return nil
 }
 
+// AddAddress appends a secondary address with validation.
+// 동일한 주소가 이미 있으면 VAL020을 반환한다.
+func (ni *NetworkInterface) AddAddress(ipAddr, cidrStr string) error {
+	addr, err := NewInterfaceAddress(ipAddr, cidrStr)
+	if err != nil {
+		return err
+	}
+	for _, existing := range ni.Addresses() {
+		if existing.ip.Equals(addr.ip) {
+			return domainErrors.NewValidationErrorWithCode("VAL020",
+				fmt.Sprintf("duplicate address %s on interface", ipAddr), nil)
+		}
+	}
+	ni.extraAddresses = append(ni.extraAddresses, *addr)
+	return nil
+}
+
+// Routes returns the static routes declared for the interface
+func (ni *NetworkInterface) Routes() []Route {
+	return append([]Route(nil), ni.routes...)
+}
+
+// AddRoute appends a static route with validation
+func (ni *NetworkInterface) AddRoute(to, via string, metric, table int, onLink bool) error {
+	route, err := NewRoute(to, via, metric, table, onLink)
+	if err != nil {
+		return err
+	}
+	ni.routes = append(ni.routes, *route)
+	return nil
+}
+
+// Gateway returns the interface gateway (empty if not set)
+func (ni *NetworkInterface) Gateway() string {
+	if ni.gateway == nil {
+		return ""
+	}
+	return ni.gateway.String()
+}
+
+// SetGateway sets the default gateway reachable through this interface
+func (ni *NetworkInterface) SetGateway(gw string) error {
+	ip, err := NewIPAddress(gw)
+	if err != nil {
+		return err
+	}
+	ni.gateway = ip
+	return nil
+}
+
+// EffectiveRoutes returns declared routes plus the gateway rendered as a default route
+func (ni *NetworkInterface) EffectiveRoutes() []Route {
+	out := ni.Routes()
+	if ni.gateway != nil {
+		if def, err := NewRoute("default", ni.gateway.String(), 0, 0, false); err == nil {
+			out = append(out, *def)
+		}
+	}
+	return out
+}
+
+// VLANs returns the 802.1Q sub-interfaces declared on top of this interface
+func (ni *NetworkInterface) VLANs() []VLAN {
+	return append([]VLAN(nil), ni.vlans...)
+}
+
+// AddVLAN appends a VLAN sub-interface with validation.
+// 같은 VLAN ID 또는 같은 명시적 이름이 이미 있으면 VAL027을 반환한다.
+func (ni *NetworkInterface) AddVLAN(id int, name, ipAddr, cidrStr string, mtuValue int) error {
+	vlan, err := NewVLAN(id, name, ipAddr, cidrStr, mtuValue)
+	if err != nil {
+		return err
+	}
+	for _, existing := range ni.vlans {
+		if existing.id == vlan.id || (vlan.name != "" && existing.name == vlan.name) {
+			return domainErrors.NewValidationErrorWithCode("VAL027",
+				fmt.Sprintf("duplicate VLAN %d (%s) on interface", id, name), nil)
+		}
+	}
+	ni.vlans = append(ni.vlans, *vlan)
+	return nil
+}
+
+// IsBond returns true if the interface is a bond master aggregating member ports
+func (ni *NetworkInterface) IsBond() bool {
+	return ni.bond != nil
+}
+
+// Bond returns the bond settings (nil for a plain ethernet interface)
+func (ni *NetworkInterface) Bond() *Bond {
+	if ni.bond == nil {
+		return nil
+	}
+	b := *ni.bond
+	return &b
+}
+
+// SetBond turns the interface into a bond master.
+// 인터페이스 MAC은 bond MAC으로 사용되므로 멤버 중 하나여야 한다 (VAL031).
+func (ni *NetworkInterface) SetBond(members []string, mode string, miimon int, xmitHashPolicy string) error {
+	bond, err := NewBond(members, mode, miimon, xmitHashPolicy)
+	if err != nil {
+		return err
+	}
+	if !bond.HasMember(ni.macAddress.Canonical()) {
+		return domainErrors.NewValidationErrorWithCode("VAL031",
+			fmt.Sprintf("bond MAC %s must be one of the members", ni.MacAddress()), nil)
+	}
+	ni.bond = bond
+	return nil
+}
+
+// Bridge returns the Linux bridge this interface is a port of (nil when not bridged)
+func (ni *NetworkInterface) Bridge() *Bridge {
+	if ni.bridge == nil {
+		return nil
+	}
+	b := *ni.bridge
+	return &b
+}
+
+// SetBridge attaches the interface to a Linux bridge.
+// bridge 이름이 명시적 VLAN 이름과 겹치면 VAL032를 반환한다.
+func (ni *NetworkInterface) SetBridge(name string, stp, moveIP bool) error {
+	bridge, err := NewBridge(name, stp, moveIP)
+	if err != nil {
+		return err
+	}
+	for _, v := range ni.vlans {
+		if v.name == name {
+			return domainErrors.NewValidationErrorWithCode("VAL032",
+				fmt.Sprintf("bridge name %s collides with a VLAN on the interface", name), nil)
+		}
+	}
+	ni.bridge = bridge
+	return nil
+}
+
+// VRF returns the VRF the interface addresses are isolated in (nil when not set)
+func (ni *NetworkInterface) VRF() *VRF {
+	if ni.vrf == nil {
+		return nil
+	}
+	v := *ni.vrf
+	return &v
+}
+
+// SetVRF places the interface (or its bridge when the IP moved there) in a VRF.
+// VRF 이름이 bridge 또는 명시적 VLAN 이름과 겹치면 VAL033을 반환한다.
+func (ni *NetworkInterface) SetVRF(name string, table int) error {
+	vrf, err := NewVRF(name, table)
+	if err != nil {
+		return err
+	}
+	collides := ni.bridge != nil && ni.bridge.name == name
+	for _, v := range ni.vlans {
+		collides = collides || v.name == name
+	}
+	if collides {
+		return domainErrors.NewValidationErrorWithCode("VAL033",
+			fmt.Sprintf("VRF name %s collides with another device of the interface", name), nil)
+	}
+	ni.vrf = vrf
+	return nil
+}
+
+// IPv4Mode returns how IPv4 addresses are assigned (static unless set)
+func (ni *NetworkInterface) IPv4Mode() AddressMode {
+	if ni.ipv4Mode == "" {
+		return AddressModeStatic
+	}
+	return ni.ipv4Mode
+}
+
+// IPv6Mode returns how IPv6 addresses are assigned (static unless set)
+func (ni *NetworkInterface) IPv6Mode() AddressMode {
+	if ni.ipv6Mode == "" {
+		return AddressModeStatic
+	}
+	return ni.ipv6Mode
+}
+
+// UsesDHCP reports whether any address family is assigned by DHCP
+func (ni *NetworkInterface) UsesDHCP() bool {
+	return ni.IPv4Mode() == AddressModeDHCP || ni.IPv6Mode() == AddressModeDHCP
+}
+
+// SetAddressModes sets the IPv4/IPv6 assignment modes.
+// dhcp/disabled 패밀리에 정적 주소가 선언되어 있으면 VAL034를 반환한다.
+func (ni *NetworkInterface) SetAddressModes(ipv4Mode, ipv6Mode string) error {
+	v4, err := NewAddressMode(ipv4Mode)
+	if err != nil {
+		return err
+	}
+	v6, err := NewAddressMode(ipv6Mode)
+	if err != nil {
+		return err
+	}
+	for _, a := range ni.Addresses() {
+		mode := v4
+		if a.IsIPv6() {
+			mode = v6
+		}
+		if mode != AddressModeStatic {
+			return domainErrors.NewValidationErrorWithCode("VAL034",
+				fmt.Sprintf("static address %s conflicts with %s mode", a.WithPrefix(), mode), nil)
+		}
+	}
+	ni.ipv4Mode, ni.ipv6Mode = v4, v6
+	return nil
+}
+
+// DNS returns the per-interface resolver settings (nil when not set)
+func (ni *NetworkInterface) DNS() *DNS {
+	if ni.dns == nil {
+		return nil
+	}
+	d := *ni.dns
+	return &d
+}
+
+// SetDNS sets the nameservers and search domains contributed by the interface
+func (ni *NetworkInterface) SetDNS(servers, search []string) error {
+	dns, err := NewDNS(servers, search)
+	if err != nil {
+		return err
+	}
+	ni.dns = dns
+	return nil
+}
+
+// Options returns the network option overrides of the interface (nil when none are set)
+func (ni *NetworkInterface) Options() *NetworkOptions {
+	if ni.options == nil {
+		return nil
+	}
+	o := *ni.options
+	return &o
+}
+
+// SetOptions sets the network option overrides; an empty set clears them
+func (ni *NetworkInterface) SetOptions(opts NetworkOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.IsEmpty() {
+		ni.options = nil
+		return nil
+	}
+	ni.options = &opts
+	return nil
+}
+
+// AddressDevice returns the device that carries the interface addresses and routes:
+// the bridge when the IP moves to it, otherwise the interface itself (name).
+func (ni *NetworkInterface) AddressDevice(name string) string {
+	if ni.bridge != nil && ni.bridge.moveIP {
+		return ni.bridge.name
+	}
+	return name
+}
+
 // IsJumboFrame checks if this interface uses jumbo frames
 func (ni *NetworkInterface) IsJumboFrame() bool {
 	return ni.mtu.IsJumboFrame()
@@ -236,30 +580,27 @@ type InterfaceName struct {
 	index int
 }
 
-// interfaceNamePattern은 유효한 인터페이스 이름 패턴입니다
-var interfaceNamePattern = regexp.MustCompile(`^` + constants.InterfacePrefix + `([0-9]+)$`)
-
 // NewInterfaceName creates a new interface name
 func NewInterfaceName(name string) (*InterfaceName, error) {
 	if name == "" {
 		return nil, domainErrors.NewValidationErrorWithCode("VAL016", "interface name cannot be empty", nil)
 	}
 
-	matches := interfaceNamePattern.FindStringSubmatch(name)
-	if len(matches) != 2 {
+	// 접두사는 설정으로 바뀔 수 있으므로 정규식 대신 매번 현재 규칙으로 해석한다
+	if !strings.HasPrefix(name, constants.InterfacePrefix()) {
 		return nil, domainErrors.NewValidationErrorWithCode("VAL017",
-			fmt.Sprintf("invalid interface name format: %s (expected: %s<number>)", name, constants.InterfacePrefix), nil)
+			fmt.Sprintf("invalid interface name format: %s (expected: %s<number>)", name, constants.InterfacePrefix()), nil)
 	}
 
-	index, err := strconv.Atoi(matches[1])
-	if err != nil {
+	index, ok := constants.ParseInterfaceIndex(name)
+	if !ok {
 		return nil, domainErrors.NewValidationErrorWithCode("VAL018",
-			fmt.Sprintf("invalid interface index in name: %s", name), err)
+			fmt.Sprintf("invalid interface index in name: %s", name), nil)
 	}
 
-	if index < 0 || index >= constants.MaxInterfaces {
+	if index < 0 || index >= constants.MaxInterfaces() {
 		return nil, domainErrors.NewValidationErrorWithCode("VAL019",
-			fmt.Sprintf("interface index out of range: %d (0-%d)", index, constants.MaxInterfaces-1), nil)
+			fmt.Sprintf("interface index out of range: %d (0-%d)", index, constants.MaxInterfaces()-1), nil)
 	}
 
 	return &InterfaceName{
@@ -312,6 +653,6 @@ func isValidMacAddress(mac string) bool {
 
 // isValidInterfaceName validates interface name format
 func isValidInterfaceName(name string) bool {
-	matched, _ := regexp.MatchString(`^multinic[0-9]$`, name)
-	return matched
+	idx, ok := constants.ParseInterfaceIndex(name)
+	return ok && idx < constants.MaxInterfaces()
 }
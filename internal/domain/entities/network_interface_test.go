@@ -42,6 +42,279 @@ func TestNetworkInterface_ConstructAndValidate(t *testing.T) {
         assert.Equal(t, "multinic0", ni.InterfaceName())
         assert.True(t, ni.HasExplicitName())
     })
+
+    t.Run("IPv6 주소", func(t *testing.T) {
+        ni, err := NewNetworkInterface(1, "00:11:22:33:44:55", "test-node", "2001:db8:10::10", "2001:db8:10::/64", 1500)
+        require.NoError(t, err)
+        assert.True(t, ni.IsIPv6())
+    })
+
+    t.Run("IPv6 주소가 IPv4 CIDR에 속하지 않음", func(t *testing.T) {
+        _, err := NewNetworkInterface(1, "00:11:22:33:44:55", "test-node", "2001:db8:10::10", "10.0.0.0/24", 1500)
+        assert.Error(t, err)
+    })
+}
+
+func TestNetworkInterface_AddAddress(t *testing.T) {
+    ni, err := NewNetworkInterface(1, "00:11:22:33:44:55", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+
+    t.Run("보조 IPv4와 IPv6 추가", func(t *testing.T) {
+        require.NoError(t, ni.AddAddress("10.0.0.100", "10.0.0.0/24"))
+        require.NoError(t, ni.AddAddress("2001:db8::10", "2001:db8::/64"))
+
+        addrs := ni.Addresses()
+        require.Len(t, addrs, 3)
+        assert.Equal(t, "10.0.0.10/24", addrs[0].WithPrefix())
+        assert.Equal(t, "10.0.0.100/24", addrs[1].WithPrefix())
+        assert.Equal(t, "2001:db8::10/64", addrs[2].WithPrefix())
+        assert.True(t, addrs[2].IsIPv6())
+    })
+
+    t.Run("중복 주소 거부", func(t *testing.T) {
+        err := ni.AddAddress("10.0.0.10", "10.0.0.0/24")
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), "VAL020")
+    })
+
+    t.Run("CIDR 범위 밖 주소 거부", func(t *testing.T) {
+        err := ni.AddAddress("10.1.0.10", "10.0.0.0/24")
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), "VAL015")
+    })
+}
+
+func TestNetworkInterface_RoutesAndGateway(t *testing.T) {
+    ni, err := NewNetworkInterface(2, "00:11:22:33:44:55", "node", "192.168.192.10", "192.168.192.0/24", 1500)
+    require.NoError(t, err)
+
+    require.NoError(t, ni.AddRoute("10.20.0.0/16", "192.168.192.1", 0, 0, false))
+    require.NoError(t, ni.AddRoute("172.16.5.0/24", "", 50, 200, false))
+    require.NoError(t, ni.SetGateway("192.168.192.1"))
+
+    routes := ni.EffectiveRoutes()
+    require.Len(t, routes, 3)
+    assert.Equal(t, "10.20.0.0/16", routes[0].To())
+    assert.Equal(t, "192.168.192.1", routes[0].Via())
+    assert.Equal(t, 200, routes[1].Table())
+    assert.Equal(t, "default", routes[2].To())
+    assert.True(t, routes[2].IsDefault())
+
+    t.Run("기본 경로는 게이트웨이 필요", func(t *testing.T) {
+        err := ni.AddRoute("default", "", 0, 0, false)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), "VAL021")
+    })
+
+    t.Run("주소 체계 불일치 거부", func(t *testing.T) {
+        err := ni.AddRoute("2001:db8::/64", "192.168.192.1", 0, 0, false)
+        assert.Error(t, err)
+        assert.Contains(t, err.Error(), "VAL022")
+    })
+
+    t.Run("IPv6 기본 경로", func(t *testing.T) {
+        r, err := NewRoute("default", "2001:db8::1", 0, 0, true)
+        require.NoError(t, err)
+        assert.True(t, r.IsIPv6())
+        assert.True(t, r.OnLink())
+    })
+}
+
+func TestNetworkInterface_VLANs(t *testing.T) {
+    ni, err := NewNetworkInterface(0, "00:11:22:33:44:55", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+
+    require.NoError(t, ni.AddVLAN(100, "", "192.168.100.10", "192.168.100.0/24", 1400))
+    require.NoError(t, ni.AddVLAN(200, "tenant-b", "", "", 0))
+
+    vlans := ni.VLANs()
+    require.Len(t, vlans, 2)
+    assert.Equal(t, "multinic0.100", vlans[0].Name("multinic0"))
+    assert.Equal(t, 1400, vlans[0].MTU())
+    require.Len(t, vlans[0].Addresses(), 1)
+    assert.Equal(t, "192.168.100.10/24", vlans[0].Addresses()[0].WithPrefix())
+    assert.Equal(t, "tenant-b", vlans[1].Name("multinic0"))
+    assert.Empty(t, vlans[1].Addresses())
+    assert.Equal(t, 0, vlans[1].MTU())
+
+    tests := []struct {
+        name     string
+        id       int
+        vlanName string
+        ip, cidr string
+        code     string
+    }{
+        {"ID 범위 초과", 4095, "", "", "", "VAL024"},
+        {"ID 0", 0, "", "", "", "VAL024"},
+        {"이름 길이 초과", 300, "averyverylongname", "", "", "VAL025"},
+        {"주소만 지정", 300, "", "192.168.3.10", "", "VAL026"},
+        {"중복 ID", 100, "", "", "", "VAL027"},
+        {"중복 이름", 300, "tenant-b", "", "", "VAL027"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := ni.AddVLAN(tt.id, tt.vlanName, tt.ip, tt.cidr, 0)
+            assert.Error(t, err)
+            assert.Contains(t, err.Error(), tt.code)
+        })
+    }
+}
+
+func TestNetworkInterface_Bond(t *testing.T) {
+    ni, err := NewNetworkInterface(0, "02:00:00:00:00:01", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    assert.False(t, ni.IsBond())
+
+    require.NoError(t, ni.SetBond([]string{"02:00:00:00:00:01", "02:00:00:00:00:02"}, "", 0, ""))
+    require.True(t, ni.IsBond())
+    b := ni.Bond()
+    assert.Equal(t, []string{"02:00:00:00:00:01", "02:00:00:00:00:02"}, b.Members())
+    assert.Equal(t, DefaultBondMode, b.Mode())
+    assert.Equal(t, DefaultBondMIIMon, b.MIIMon())
+    assert.True(t, b.HasMember("02:00:00:00:00:02"))
+
+    tests := []struct {
+        name    string
+        members []string
+        mode    string
+        miimon  int
+        xmit    string
+        code    string
+    }{
+        {"멤버 없음", nil, "", 0, "", "VAL028"},
+        {"중복 멤버", []string{"02:00:00:00:00:01", "02:00:00:00:00:01"}, "", 0, "", "VAL028"},
+        {"잘못된 모드", []string{"02:00:00:00:00:01"}, "lacp", 0, "", "VAL029"},
+        {"음수 miimon", []string{"02:00:00:00:00:01"}, "802.3ad", -1, "", "VAL030"},
+        {"잘못된 해시 정책", []string{"02:00:00:00:00:01"}, "802.3ad", 100, "layer4", "VAL030"},
+        {"bond MAC이 멤버가 아님", []string{"02:00:00:00:00:03", "02:00:00:00:00:04"}, "802.3ad", 100, "layer3+4", "VAL031"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := ni.SetBond(tt.members, tt.mode, tt.miimon, tt.xmit)
+            assert.Error(t, err)
+            assert.Contains(t, err.Error(), tt.code)
+        })
+    }
+}
+
+func TestNetworkInterface_Bridge(t *testing.T) {
+    ni, err := NewNetworkInterface(1, "02:00:00:00:00:01", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    require.NoError(t, ni.AddVLAN(100, "storage", "", "", 0))
+    assert.Nil(t, ni.Bridge())
+    assert.Equal(t, "multinic1", ni.AddressDevice("multinic1"))
+
+    for _, name := range []string{"", "multinic9", "br with space", "storage", "bridge-name-too-long"} {
+        err := ni.SetBridge(name, false, true)
+        assert.Error(t, err, name)
+        assert.Contains(t, err.Error(), "VAL032")
+    }
+
+    require.NoError(t, ni.SetBridge("br-vm", true, true))
+    require.NotNil(t, ni.Bridge())
+    assert.True(t, ni.Bridge().STP())
+    assert.Equal(t, "br-vm", ni.AddressDevice("multinic1"))
+
+    // IP를 옮기지 않으면 포트가 주소를 유지
+    require.NoError(t, ni.SetBridge("br-vm", false, false))
+    assert.Equal(t, "multinic1", ni.AddressDevice("multinic1"))
+}
+
+func TestNetworkInterface_VRF(t *testing.T) {
+    ni, err := NewNetworkInterface(1, "02:00:00:00:00:01", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    require.NoError(t, ni.SetBridge("br-vm", false, true))
+    assert.Nil(t, ni.VRF())
+
+    tests := []struct {
+        name  string
+        vrf   string
+        table int
+    }{
+        {"이름 없음", "", 0},
+        {"multinic 예약 이름", "multinic3", 0},
+        {"bridge와 같은 이름", "br-vm", 0},
+        {"음수 테이블", "vrf-a", -1},
+        {"main 테이블", "vrf-a", 254},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := ni.SetVRF(tt.vrf, tt.table)
+            assert.Error(t, err)
+            assert.Contains(t, err.Error(), "VAL033")
+        })
+    }
+
+    require.NoError(t, ni.SetVRF("vrf-tenant", 1001))
+    require.NotNil(t, ni.VRF())
+    assert.Equal(t, "vrf-tenant", ni.VRF().Name())
+    assert.Equal(t, 1001, ni.VRF().Table())
+}
+
+func TestNetworkInterface_AddressModes(t *testing.T) {
+    ni, err := NewNetworkInterface(1, "02:00:00:00:00:01", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    assert.Equal(t, AddressModeStatic, ni.IPv4Mode())
+    assert.Equal(t, AddressModeStatic, ni.IPv6Mode())
+    assert.False(t, ni.UsesDHCP())
+
+    tests := []struct {
+        name string
+        v4   string
+        v6   string
+    }{
+        {"알 수 없는 모드", "slaac", ""},
+        {"static IPv4 주소가 있는데 dhcp", "dhcp", ""},
+        {"static IPv4 주소가 있는데 disabled", "disabled", "dhcp"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := ni.SetAddressModes(tt.v4, tt.v6)
+            assert.Error(t, err)
+            assert.Contains(t, err.Error(), "VAL034")
+        })
+    }
+
+    require.NoError(t, ni.SetAddressModes("", "disabled"))
+    assert.Equal(t, AddressModeDisabled, ni.IPv6Mode())
+
+    dhcp, err := NewNetworkInterfaceWithoutAddress(2, "", "02:00:00:00:00:02", "node", 1500)
+    require.NoError(t, err)
+    assert.Empty(t, dhcp.Address())
+    assert.Empty(t, dhcp.CIDR())
+    require.NoError(t, dhcp.SetAddressModes("dhcp", "dhcp"))
+    assert.True(t, dhcp.UsesDHCP())
+}
+
+func TestNetworkInterface_DNS(t *testing.T) {
+    ni, err := NewNetworkInterface(1, "02:00:00:00:00:01", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    assert.Nil(t, ni.DNS())
+
+    tests := []struct {
+        name    string
+        servers []string
+        search  []string
+    }{
+        {"서버와 검색 도메인 모두 없음", nil, nil},
+        {"잘못된 서버 주소", []string{"10.0.0.300"}, nil},
+        {"잘못된 검색 도메인", nil, []string{"bad_domain..example"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := ni.SetDNS(tt.servers, tt.search)
+            assert.Error(t, err)
+            assert.Contains(t, err.Error(), "VAL035")
+        })
+    }
+
+    require.NoError(t, ni.SetDNS([]string{"10.0.0.53", "2001:db8::53"}, []string{"storage.example.com"}))
+    require.NotNil(t, ni.DNS())
+    assert.Equal(t, []string{"10.0.0.53", "2001:db8::53"}, ni.DNS().Servers())
+    v4, v6 := ni.DNS().ServersByFamily()
+    assert.Equal(t, []string{"10.0.0.53"}, v4)
+    assert.Equal(t, []string{"2001:db8::53"}, v6)
+    assert.Equal(t, []string{"storage.example.com"}, ni.DNS().Search())
 }
 
 func TestNetworkInterface_StatusMethods(t *testing.T) {
@@ -157,3 +430,27 @@ func TestInterfaceNameValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkInterface_Options(t *testing.T) {
+    ni, err := NewNetworkInterface(1, "02:00:00:00:00:01", "node", "10.0.0.10", "10.0.0.0/24", 1500)
+    require.NoError(t, err)
+    assert.Nil(t, ni.Options())
+
+    zero, negative := 0, -1
+    err = ni.SetOptions(NetworkOptions{RoutingTableBase: &zero})
+    require.Error(t, err)
+    assert.Contains(t, err.Error(), "VAL040")
+    err = ni.SetOptions(NetworkOptions{RouteMetric: &negative})
+    require.Error(t, err)
+    assert.Contains(t, err.Error(), "VAL041")
+
+    base, off := 2000, false
+    require.NoError(t, ni.SetOptions(NetworkOptions{RoutingTableBase: &base, PolicyRouting: &off}))
+    require.NotNil(t, ni.Options())
+    assert.Equal(t, 2000, *ni.Options().RoutingTableBase)
+    assert.False(t, *ni.Options().PolicyRouting)
+    assert.Nil(t, ni.Options().RouteMetric)
+
+    require.NoError(t, ni.SetOptions(NetworkOptions{}))
+    assert.Nil(t, ni.Options())
+}
@@ -134,13 +134,511 @@ func (c *CIDR) Contains(ip *IPAddress) bool {
 	return c.network.Contains(ip.ip)
 }
 
+// PrefixLength는 CIDR의 프리픽스 길이를 반환합니다 (예: "10.0.0.0/24" -> 24)
+func (c *CIDR) PrefixLength() int {
+	ones, _ := c.network.Mask.Size()
+	return ones
+}
+
 // NetworkAddress는 네트워크 주소를 반환합니다
 func (c *CIDR) NetworkAddress() *IPAddress {
 	addr, _ := NewIPAddress(c.network.IP.String())
 	return addr
 }
 
-// MTU는 MTU 값을 나타내는 값 객체입니다
+// InterfaceAddress는 CIDR에 속한 하나의 인터페이스 주소를 나타내는 값 객체입니다
+type InterfaceAddress struct {
+	ip   *IPAddress
+	cidr *CIDR
+}
+
+// NewInterfaceAddress는 주소와 CIDR을 검증하여 새로운 InterfaceAddress를 생성합니다
+func NewInterfaceAddress(ipAddr, cidrStr string) (*InterfaceAddress, error) {
+	ip, err := NewIPAddress(ipAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	cidr, err := NewCIDR(cidrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cidr.Contains(ip) {
+		return nil, errors.NewValidationErrorWithCode("VAL015",
+			fmt.Sprintf("IP address %s is not within CIDR %s", ipAddr, cidrStr), nil)
+	}
+
+	return &InterfaceAddress{ip: ip, cidr: cidr}, nil
+}
+
+// Address는 IP 주소 문자열을 반환합니다
+func (a InterfaceAddress) Address() string {
+	return a.ip.String()
+}
+
+// CIDR은 CIDR 문자열을 반환합니다
+func (a InterfaceAddress) CIDR() string {
+	return a.cidr.String()
+}
+
+// Network는 호스트 비트를 제거한 네트워크 CIDR을 반환합니다 (예: 10.0.0.0/24)
+func (a InterfaceAddress) Network() string {
+	return a.cidr.network.String()
+}
+
+// PrefixLength는 CIDR의 프리픽스 길이를 반환합니다
+func (a InterfaceAddress) PrefixLength() int {
+	return a.cidr.PrefixLength()
+}
+
+// WithPrefix는 "주소/프리픽스" 형식을 반환합니다 (예: 10.0.0.10/24)
+func (a InterfaceAddress) WithPrefix() string {
+	return fmt.Sprintf("%s/%d", a.ip.String(), a.cidr.PrefixLength())
+}
+
+// IsIPv6는 IPv6 주소인지 확인합니다
+func (a InterfaceAddress) IsIPv6() bool {
+	return a.ip.IsIPv6()
+}
+
+// Equals는 두 주소가 같은지 비교합니다 (프리픽스 길이 포함)
+func (a InterfaceAddress) Equals(other InterfaceAddress) bool {
+	return a.ip.Equals(other.ip) && a.PrefixLength() == other.PrefixLength()
+}
+
+// Route는 인터페이스에 선언된 정적 경로를 나타내는 값 객체입니다
+type Route struct {
+	to     *CIDR
+	via    *IPAddress // nil이면 직접 연결 경로
+	metric int        // 0이면 어댑터 기본값 사용
+	table  int        // 0이면 어댑터 기본 테이블 사용
+	onLink bool
+}
+
+// NewRoute는 새로운 Route를 생성합니다.
+// to에 "default"를 지정하면 via의 주소 체계에 맞는 기본 경로(0.0.0.0/0, ::/0)가 됩니다.
+func NewRoute(to, via string, metric, table int, onLink bool) (*Route, error) {
+	var gw *IPAddress
+	if via != "" {
+		ip, err := NewIPAddress(via)
+		if err != nil {
+			return nil, err
+		}
+		gw = ip
+	}
+
+	if to == "default" {
+		if gw == nil {
+			return nil, errors.NewValidationErrorWithCode("VAL021", "default route requires a gateway (via)", nil)
+		}
+		to = "0.0.0.0/0"
+		if gw.IsIPv6() {
+			to = "::/0"
+		}
+	}
+	dest, err := NewCIDR(to)
+	if err != nil {
+		return nil, errors.NewValidationErrorWithCode("VAL021", fmt.Sprintf("invalid route destination: %s", to), err)
+	}
+
+	if gw != nil && dest.NetworkAddress().IsIPv6() != gw.IsIPv6() {
+		return nil, errors.NewValidationErrorWithCode("VAL022",
+			fmt.Sprintf("route %s and gateway %s must use the same address family", to, via), nil)
+	}
+	if metric < 0 || table < 0 {
+		return nil, errors.NewValidationErrorWithCode("VAL023",
+			fmt.Sprintf("route metric/table cannot be negative: metric=%d table=%d", metric, table), nil)
+	}
+
+	return &Route{to: dest, via: gw, metric: metric, table: table, onLink: onLink}, nil
+}
+
+// To는 목적지 네트워크를 반환합니다 (호스트 비트 제거, 기본 경로는 "default")
+func (r Route) To() string {
+	if r.IsDefault() {
+		return "default"
+	}
+	return r.to.network.String()
+}
+
+// Via는 게이트웨이 주소를 반환합니다 (없으면 빈 문자열)
+func (r Route) Via() string {
+	if r.via == nil {
+		return ""
+	}
+	return r.via.String()
+}
+
+// Metric은 경로 메트릭을 반환합니다 (0이면 미지정)
+func (r Route) Metric() int {
+	return r.metric
+}
+
+// Table은 라우팅 테이블 번호를 반환합니다 (0이면 미지정)
+func (r Route) Table() int {
+	return r.table
+}
+
+// OnLink는 게이트웨이를 링크 직접 연결로 간주하는지 여부를 반환합니다
+func (r Route) OnLink() bool {
+	return r.onLink
+}
+
+// IsDefault는 기본 경로인지 확인합니다
+func (r Route) IsDefault() bool {
+	return r.to.PrefixLength() == 0
+}
+
+// IsIPv6는 IPv6 경로인지 확인합니다
+func (r Route) IsIPv6() bool {
+	return r.to.NetworkAddress().IsIPv6()
+}
+
+// vlanNamePattern은 커널 인터페이스 이름 규칙(최대 15자, 공백/슬래시/콜론 불가)을 따릅니다
+var vlanNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,15}$`)
+
+// VLAN은 관리 인터페이스 위에 선언된 802.1Q 하위 인터페이스를 나타내는 값 객체입니다
+type VLAN struct {
+	id      int
+	name    string            // 비어 있으면 "<parent>.<id>"
+	address *InterfaceAddress // nil이면 주소 없이 링크만 생성
+	mtu     *MTU              // nil이면 부모 MTU 상속
+}
+
+// NewVLAN은 새로운 VLAN을 생성합니다.
+// address와 cidr은 함께 지정하거나 함께 비워야 하며, mtu가 0이면 부모 값을 따릅니다.
+func NewVLAN(id int, name, ipAddr, cidrStr string, mtuValue int) (*VLAN, error) {
+	if id < 1 || id > 4094 {
+		return nil, errors.NewValidationErrorWithCode("VAL024", fmt.Sprintf("VLAN id out of range: %d (1-4094)", id), nil)
+	}
+	if name != "" && !vlanNamePattern.MatchString(name) {
+		return nil, errors.NewValidationErrorWithCode("VAL025", fmt.Sprintf("invalid VLAN interface name: %s", name), nil)
+	}
+	if (ipAddr == "") != (cidrStr == "") {
+		return nil, errors.NewValidationErrorWithCode("VAL026",
+			fmt.Sprintf("VLAN %d address and cidr must be set together", id), nil)
+	}
+
+	v := &VLAN{id: id, name: name}
+	if ipAddr != "" {
+		addr, err := NewInterfaceAddress(ipAddr, cidrStr)
+		if err != nil {
+			return nil, err
+		}
+		v.address = addr
+	}
+	if mtuValue > 0 {
+		mtu, err := NewMTU(mtuValue)
+		if err != nil {
+			return nil, err
+		}
+		v.mtu = mtu
+	}
+	return v, nil
+}
+
+// ID는 802.1Q VLAN ID를 반환합니다
+func (v VLAN) ID() int {
+	return v.id
+}
+
+// Name은 parent 위에서 사용할 인터페이스 이름을 반환합니다 (미지정 시 "<parent>.<id>")
+func (v VLAN) Name(parent string) string {
+	if v.name != "" {
+		return v.name
+	}
+	return fmt.Sprintf("%s.%d", parent, v.id)
+}
+
+// HasExplicitName은 spec에서 이름을 지정했는지 여부를 반환합니다
+func (v VLAN) HasExplicitName() bool {
+	return v.name != ""
+}
+
+// Addresses는 VLAN 주소 목록을 반환합니다 (주소가 없으면 빈 목록)
+func (v VLAN) Addresses() []InterfaceAddress {
+	if v.address == nil {
+		return nil
+	}
+	return []InterfaceAddress{*v.address}
+}
+
+// MTU는 VLAN MTU를 반환합니다 (0이면 미지정)
+func (v VLAN) MTU() int {
+	if v.mtu == nil {
+		return 0
+	}
+	return v.mtu.Value()
+}
+
+// 본딩 기본값: 모드 미지정 시 스위치 설정이 필요 없는 active-backup, 링크 감시는 100ms
+const (
+	DefaultBondMode   = "active-backup"
+	DefaultBondMIIMon = 100
+)
+
+// bondModes는 커널 bonding 드라이버가 지원하는 모드 목록입니다
+var bondModes = map[string]bool{
+	"balance-rr": true, "active-backup": true, "balance-xor": true, "broadcast": true,
+	"802.3ad": true, "balance-tlb": true, "balance-alb": true,
+}
+
+// bondXmitHashPolicies는 xmit_hash_policy로 허용되는 값입니다
+var bondXmitHashPolicies = map[string]bool{
+	"layer2": true, "layer2+3": true, "layer3+4": true, "encap2+3": true, "encap3+4": true, "vlan+srcmac": true,
+}
+
+// Bond는 여러 포트(MAC)를 하나로 묶는 본딩 설정을 나타내는 값 객체입니다
+type Bond struct {
+	members        []*MACAddress
+	mode           string
+	miimon         int
+	xmitHashPolicy string // 비어 있으면 커널 기본값(layer2)
+}
+
+// NewBond는 새로운 Bond를 생성합니다. mode/miimon이 비어 있으면 기본값을 사용합니다.
+func NewBond(members []string, mode string, miimon int, xmitHashPolicy string) (*Bond, error) {
+	if len(members) == 0 {
+		return nil, errors.NewValidationErrorWithCode("VAL028", "bond requires at least one member MAC address", nil)
+	}
+	b := &Bond{mode: mode, miimon: miimon, xmitHashPolicy: xmitHashPolicy}
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		mac, err := NewMACAddress(m)
+		if err != nil {
+			return nil, err
+		}
+		if seen[mac.Canonical()] {
+			return nil, errors.NewValidationErrorWithCode("VAL028", fmt.Sprintf("duplicate bond member %s", m), nil)
+		}
+		seen[mac.Canonical()] = true
+		b.members = append(b.members, mac)
+	}
+
+	if b.mode == "" {
+		b.mode = DefaultBondMode
+	}
+	if !bondModes[b.mode] {
+		return nil, errors.NewValidationErrorWithCode("VAL029", fmt.Sprintf("unsupported bond mode: %s", mode), nil)
+	}
+	if b.miimon < 0 {
+		return nil, errors.NewValidationErrorWithCode("VAL030", fmt.Sprintf("bond miimon cannot be negative: %d", miimon), nil)
+	}
+	if b.miimon == 0 {
+		b.miimon = DefaultBondMIIMon
+	}
+	if b.xmitHashPolicy != "" && !bondXmitHashPolicies[b.xmitHashPolicy] {
+		return nil, errors.NewValidationErrorWithCode("VAL030", fmt.Sprintf("unsupported bond xmit hash policy: %s", xmitHashPolicy), nil)
+	}
+	return b, nil
+}
+
+// Members는 멤버 MAC 주소 목록을 반환합니다 (소문자, spec 순서)
+func (b Bond) Members() []string {
+	out := make([]string, 0, len(b.members))
+	for _, m := range b.members {
+		out = append(out, m.Canonical())
+	}
+	return out
+}
+
+// HasMember는 주어진 MAC이 멤버인지 확인합니다
+func (b Bond) HasMember(mac string) bool {
+	for _, m := range b.members {
+		if strings.EqualFold(m.Canonical(), mac) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mode는 본딩 모드를 반환합니다
+func (b Bond) Mode() string {
+	return b.mode
+}
+
+// MIIMon은 링크 감시 주기(ms)를 반환합니다
+func (b Bond) MIIMon() int {
+	return b.miimon
+}
+
+// XmitHashPolicy는 전송 해시 정책을 반환합니다 (비어 있으면 미지정)
+func (b Bond) XmitHashPolicy() string {
+	return b.xmitHashPolicy
+}
+
+// Bridge는 인터페이스를 포트로 묶는 Linux bridge 설정을 나타내는 값 객체입니다
+type Bridge struct {
+	name   string
+	stp    bool
+	moveIP bool // true면 인터페이스 주소/라우트를 bridge로 옮김
+}
+
+// NewBridge는 새로운 Bridge를 생성합니다.
+// multinic 이름은 포트용으로 예약되어 있으므로 bridge 이름으로 사용할 수 없습니다.
+func NewBridge(name string, stp, moveIP bool) (*Bridge, error) {
+	if !vlanNamePattern.MatchString(name) || strings.HasPrefix(name, constants.InterfacePrefix()) {
+		return nil, errors.NewValidationErrorWithCode("VAL032", fmt.Sprintf("invalid bridge name: %s", name), nil)
+	}
+	return &Bridge{name: name, stp: stp, moveIP: moveIP}, nil
+}
+
+// Name은 bridge 인터페이스 이름을 반환합니다
+func (b Bridge) Name() string {
+	return b.name
+}
+
+// STP는 spanning tree 사용 여부를 반환합니다
+func (b Bridge) STP() bool {
+	return b.stp
+}
+
+// MoveIP는 주소가 포트 대신 bridge에 설정되는지 반환합니다
+func (b Bridge) MoveIP() bool {
+	return b.moveIP
+}
+
+// VRF는 인터페이스를 독립된 라우팅 도메인에 넣는 VRF 설정을 나타내는 값 객체입니다
+type VRF struct {
+	name  string
+	table int // 0이면 어댑터가 인터페이스 인덱스로 계산한 테이블을 사용
+}
+
+// 커널 예약 테이블(default/main/local)은 VRF 테이블로 사용할 수 없습니다
+var reservedRouteTables = map[int]bool{253: true, 254: true, 255: true}
+
+// NewVRF는 새로운 VRF를 생성합니다
+func NewVRF(name string, table int) (*VRF, error) {
+	if !vlanNamePattern.MatchString(name) || strings.HasPrefix(name, constants.InterfacePrefix()) {
+		return nil, errors.NewValidationErrorWithCode("VAL033", fmt.Sprintf("invalid VRF name: %s", name), nil)
+	}
+	if table < 0 || reservedRouteTables[table] {
+		return nil, errors.NewValidationErrorWithCode("VAL033", fmt.Sprintf("invalid VRF table: %d", table), nil)
+	}
+	return &VRF{name: name, table: table}, nil
+}
+
+// Name은 VRF 디바이스 이름을 반환합니다
+func (v VRF) Name() string {
+	return v.name
+}
+
+// Table은 명시된 라우팅 테이블을 반환합니다 (0이면 미지정)
+func (v VRF) Table() int {
+	return v.table
+}
+
+// AddressMode는 주소 패밀리(IPv4/IPv6)별 주소 할당 방식을 나타냅니다
+type AddressMode string
+
+const (
+	AddressModeStatic   AddressMode = "static"   // spec에 선언된 주소만 사용 (기본값)
+	AddressModeDHCP     AddressMode = "dhcp"     // DHCP 클라이언트가 주소를 받아온다
+	AddressModeDisabled AddressMode = "disabled" // 해당 패밀리를 사용하지 않음
+)
+
+// NewAddressMode는 문자열을 AddressMode로 변환합니다. 빈 값은 static으로 취급합니다.
+func NewAddressMode(value string) (AddressMode, error) {
+	switch mode := AddressMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return AddressModeStatic, nil
+	case AddressModeStatic, AddressModeDHCP, AddressModeDisabled:
+		return mode, nil
+	default:
+		return "", errors.NewValidationErrorWithCode("VAL034", fmt.Sprintf("invalid address mode: %s (static|dhcp|disabled)", value), nil)
+	}
+}
+
+// String은 AddressMode의 문자열 표현을 반환합니다
+func (m AddressMode) String() string {
+	return string(m)
+}
+
+// 검색 도메인 형식 (레이블 63자, 마지막 점 허용)
+var searchDomainPattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)*[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.?$`)
+
+// DNS는 인터페이스가 제공하는 네임서버와 검색 도메인을 나타내는 값 객체입니다
+type DNS struct {
+	servers []IPAddress
+	search  []string
+}
+
+// NewDNS는 새로운 DNS 설정을 생성합니다. 서버와 검색 도메인이 모두 비어 있으면 VAL035를 반환합니다.
+func NewDNS(servers, search []string) (*DNS, error) {
+	if len(servers) == 0 && len(search) == 0 {
+		return nil, errors.NewValidationErrorWithCode("VAL035", "dns requires at least one server or search domain", nil)
+	}
+	dns := &DNS{}
+	for _, s := range servers {
+		ip, err := NewIPAddress(s)
+		if err != nil {
+			return nil, errors.NewValidationErrorWithCode("VAL035", fmt.Sprintf("invalid DNS server: %s", s), err)
+		}
+		dns.servers = append(dns.servers, *ip)
+	}
+	for _, d := range search {
+		if len(d) > 253 || !searchDomainPattern.MatchString(d) {
+			return nil, errors.NewValidationErrorWithCode("VAL035", fmt.Sprintf("invalid DNS search domain: %s", d), nil)
+		}
+		dns.search = append(dns.search, d)
+	}
+	return dns, nil
+}
+
+// Servers는 네임서버 주소 목록을 반환합니다
+func (d DNS) Servers() []string {
+	out := make([]string, 0, len(d.servers))
+	for _, s := range d.servers {
+		out = append(out, s.String())
+	}
+	return out
+}
+
+// ServersByFamily는 네임서버를 IPv4/IPv6로 나누어 반환합니다 (NetworkManager는 패밀리별로 저장)
+func (d DNS) ServersByFamily() (v4, v6 []string) {
+	for _, s := range d.servers {
+		if s.IsIPv6() {
+			v6 = append(v6, s.String())
+		} else {
+			v4 = append(v4, s.String())
+		}
+	}
+	return v4, v6
+}
+
+// Search는 검색 도메인 목록을 반환합니다
+func (d DNS) Search() []string {
+	return append([]string(nil), d.search...)
+}
+
+// NetworkOptions는 CR에서 지정한 에이전트 네트워크 옵션 오버라이드를 나타내는 값 객체입니다.
+// nil 필드는 에이전트 전역 설정(NETWORK_* 환경변수)을 그대로 사용합니다.
+type NetworkOptions struct {
+	PolicyRouting    *bool
+	RoutingTableBase *int
+	RouteMetric      *int
+	NoPrefixRoute    *bool
+	ArpSysctls       *bool
+	LooseRPFilter    *bool
+}
+
+// Validate는 라우팅 테이블 기준값(VAL040)과 라우트 메트릭(VAL041)의 범위를 검사합니다
+func (o NetworkOptions) Validate() error {
+	if o.RoutingTableBase != nil && *o.RoutingTableBase < 1 {
+		return errors.NewValidationErrorWithCode("VAL040", fmt.Sprintf("routing table base must be positive: %d", *o.RoutingTableBase), nil)
+	}
+	if o.RouteMetric != nil && *o.RouteMetric < 0 {
+		return errors.NewValidationErrorWithCode("VAL041", fmt.Sprintf("route metric must not be negative: %d", *o.RouteMetric), nil)
+	}
+	return nil
+}
+
+// IsEmpty는 오버라이드가 하나도 없는지 반환합니다
+func (o NetworkOptions) IsEmpty() bool {
+	return o == NetworkOptions{}
+}
+
 type MTU struct {
 	value int
 }
@@ -182,8 +680,8 @@ func NewInterfaceIndex(value int) (*InterfaceIndex, error) {
 	if value < 0 {
 		return nil, errors.NewValidationErrorWithCode("VAL010", fmt.Sprintf("interface index cannot be negative: %d", value), nil)
 	}
-	if value >= constants.MaxInterfaces {
-		return nil, errors.NewValidationErrorWithCode("VAL011", fmt.Sprintf("interface index too large: %d (maximum: %d)", value, constants.MaxInterfaces-1), nil)
+	if value >= constants.MaxInterfaces() {
+		return nil, errors.NewValidationErrorWithCode("VAL011", fmt.Sprintf("interface index too large: %d (maximum: %d)", value, constants.MaxInterfaces()-1), nil)
 	}
 
 	return &InterfaceIndex{value: value}, nil
@@ -201,7 +699,7 @@ func (idx *InterfaceIndex) String() string {
 
 // ToInterfaceName은 인터페이스 이름으로 변환합니다
 func (idx *InterfaceIndex) ToInterfaceName() string {
-	return fmt.Sprintf("%s%d", constants.InterfacePrefix, idx.value)
+	return constants.InterfaceName(idx.value)
 }
 
 // NodeName은 노드 이름을 나타내는 값 객체입니다
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"multinic-agent/internal/domain/entities"
+	"strings"
 )
 
 // NetworkConfigurer는 네트워크 설정을 적용하는 인터페이스입니다
@@ -22,3 +23,80 @@ type NetworkRollbacker interface {
 	// Rollback은 인터페이스 설정을 이전 상태로 되돌립니다
 	Rollback(ctx context.Context, name string) error
 }
+
+// VLANManager는 관리 인터페이스 위에 생성된 VLAN 하위 인터페이스를 정리하는 인터페이스입니다
+type VLANManager interface {
+	// ListVLANs는 parent 위에 존재하는 VLAN 인터페이스 이름 목록을 반환합니다
+	ListVLANs(ctx context.Context, parent string) ([]string, error)
+
+	// RemoveVLAN은 VLAN 링크와 해당 영속 설정을 삭제합니다
+	RemoveVLAN(ctx context.Context, name string) error
+}
+
+// InterfaceState는 노드에서 관찰한 인터페이스의 런타임 상태입니다
+type InterfaceState struct {
+	Up        bool // 관리 상태 UP 플래그
+	LowerUp   bool // 캐리어 (LOWER_UP)
+	MTU       int
+	Addresses []string // global scope 주소 ("주소/프리픽스")
+	// PolicyTable은 인터페이스 경로가 있어야 할 테이블입니다 (0이면 main 테이블만 사용)
+	PolicyTable int
+	Rules       []string // PolicyTable을 가리키는 ip rule
+	Routes      []string // PolicyTable의 경로
+}
+
+// NetworkInspector는 설정을 바꾸지 않고 인터페이스의 런타임 상태를 읽는 인터페이스입니다
+type NetworkInspector interface {
+	// Inspect는 name으로 설정된 iface의 링크/주소/정책 라우팅 상태를 반환합니다
+	Inspect(ctx context.Context, iface entities.NetworkInterface, name string) (*InterfaceState, error)
+}
+
+// DriftReporter는 verify 결과를 컨트롤러가 읽을 수 있는 곳에 게시하는 인터페이스입니다
+type DriftReporter interface {
+	// ReportDrift는 nodeName의 verify 결과(JSON)를 기록합니다
+	ReportDrift(ctx context.Context, nodeName string, report []byte) error
+}
+
+// Link은 "ip -o link show" 한 줄에서 읽은 링크 정보입니다
+type Link struct {
+	Name    string   // 이름 (VLAN 등 stacked 링크는 "name@parent")
+	Flags   []string // <...> 안의 플래그 (UP, LOWER_UP, MASTER ...)
+	MAC     string   // link/ether 주소 (소문자)
+	PermMAC string   // permaddr: bond 멤버는 bond MAC을 물려받으므로 포트 고유 MAC (소문자)
+	Master  string   // 소속 master (bond/bridge/VRF)
+}
+
+// HasFlag는 링크에 flag가 설정되어 있는지 반환합니다
+func (l Link) HasFlag(flag string) bool {
+	for _, f := range l.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity는 물리 포트를 식별하는 MAC을 반환합니다 (permaddr가 있으면 permaddr)
+func (l Link) Identity() string {
+	if l.PermMAC != "" {
+		return l.PermMAC
+	}
+	return l.MAC
+}
+
+// Stacked는 다른 링크 위에 쌓인 링크("multinic0.100@multinic0")인지 반환합니다. 부모 MAC을 공유합니다
+func (l Link) Stacked() bool {
+	return strings.Contains(l.Name, "@")
+}
+
+// IsPortFor는 l이 mac으로 식별되는 물리 포트인지 반환합니다.
+// bond master와 stacked 링크는 포트의 MAC을 공유하지만 포트 자체는 아닙니다
+func (l Link) IsPortFor(mac string) bool {
+	return !l.HasFlag("MASTER") && !l.Stacked() && l.Identity() == strings.ToLower(strings.TrimSpace(mac))
+}
+
+// LinkLister는 호스트의 링크 목록을 읽는 인터페이스입니다
+type LinkLister interface {
+	// ListLinks는 "ip -o link show" 출력을 파싱한 링크 목록을 반환합니다
+	ListLinks(ctx context.Context) ([]Link, error)
+}
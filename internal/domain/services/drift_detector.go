@@ -3,11 +3,14 @@ package services
 import (
     "bufio"
     "context"
+    "multinic-agent/internal/domain/constants"
     "multinic-agent/internal/domain/entities"
     "multinic-agent/internal/domain/interfaces"
     "multinic-agent/internal/infrastructure/metrics"
     "net"
     "path/filepath"
+    "slices"
+    "sort"
     "strconv"
     "strings"
 
@@ -30,53 +33,108 @@ type NetplanYAML struct {
     Network struct {
         Ethernets map[string]struct {
             DHCP4     bool     `yaml:"dhcp4"`
+            DHCP6     bool     `yaml:"dhcp6"`
             MTU       int      `yaml:"mtu,omitempty"`
             Addresses []string `yaml:"addresses,omitempty"`
+            Nameservers netplanNameservers `yaml:"nameservers,omitempty"`
             Match     struct {
                 MACAddress string `yaml:"macaddress"`
             } `yaml:"match"`
             SetName string `yaml:"set-name"`
         } `yaml:"ethernets"`
+        Bonds map[string]struct {
+            DHCP4      bool     `yaml:"dhcp4"`
+            DHCP6      bool     `yaml:"dhcp6"`
+            MTU        int      `yaml:"mtu,omitempty"`
+            Addresses  []string `yaml:"addresses,omitempty"`
+            MACAddress string   `yaml:"macaddress,omitempty"`
+            Nameservers netplanNameservers `yaml:"nameservers,omitempty"`
+        } `yaml:"bonds,omitempty"`
+        Bridges map[string]struct {
+            DHCP4     bool     `yaml:"dhcp4"`
+            DHCP6     bool     `yaml:"dhcp6"`
+            Addresses []string `yaml:"addresses,omitempty"`
+            Nameservers netplanNameservers `yaml:"nameservers,omitempty"`
+        } `yaml:"bridges,omitempty"`
         Version int `yaml:"version"`
     } `yaml:"network"`
 }
 
+// netplanNameservers is the "nameservers:" block of an ethernet/bond/bridge entry
+type netplanNameservers struct {
+    Addresses []string `yaml:"addresses,omitempty"`
+    Search    []string `yaml:"search,omitempty"`
+}
+
 type netplanFileConfig struct {
     macAddress   string
-    address      string
-    cidr         string
+    addresses    []string // "addr/prefix" entries as written in the file
     mtu          int
     hasAddresses bool
+    dhcp4        bool
+    dhcp6        bool
+    nameservers  netplanNameservers
 }
 
 type ifcfgFileConfig struct {
     macAddress string
-    ipAddress  string
-    prefix     string
+    addresses  []string // IPADDR[n]/PREFIX[n] and IPV6ADDR(_SECONDARIES) folded into "addr/prefix"
     mtu        int
 }
 
 // Public API
 func (d *DriftDetector) IsNetplanDrift(ctx context.Context, dbIface entities.NetworkInterface, configPath string) bool {
+    fileConfig, ok := d.readNetplanConfig(dbIface, configPath)
+    if !ok {
+        return true
+    }
+
+    // Validate against system state
+    interfaceName := d.extractInterfaceNameFromPath(configPath)
+    if interfaceName != "" {
+        if d.checkSystemInterfaceDrift(ctx, dbIface, interfaceName) {
+            return true
+        }
+        if d.checkDHCPLeaseDrift(dbIface, interfaceName) {
+            return true
+        }
+    }
+
+    return d.checkConfigDrift(dbIface, fileConfig)
+}
+
+// IsNetplanFileDrift compares only the persisted file with the spec. The verify action uses it
+// because IsNetplanDrift also flags an UP interface, which is unsafe to modify but not drifted.
+func (d *DriftDetector) IsNetplanFileDrift(dbIface entities.NetworkInterface, configPath string) bool {
+    fileConfig, ok := d.readNetplanConfig(dbIface, configPath)
+    if !ok {
+        return true
+    }
+    return d.checkConfigDrift(dbIface, fileConfig)
+}
+
+// readNetplanConfig loads the netplan file of dbIface; ok is false when it is missing, unreadable
+// or written for another MAC
+func (d *DriftDetector) readNetplanConfig(dbIface entities.NetworkInterface, configPath string) (netplanFileConfig, bool) {
     if !d.fs.Exists(configPath) {
         d.logger.WithFields(logrus.Fields{
             "interface_id": dbIface.ID(),
             "mac_address":  dbIface.MacAddress(),
             "config_path":  configPath,
         }).Debug("Configuration file not found, detected as configuration change")
-        return true
+        return netplanFileConfig{}, false
     }
 
     content, err := d.fs.ReadFile(configPath)
     if err != nil {
         d.logger.WithError(err).WithField("file", configPath).Warn("Failed to read Netplan file, treating as configuration mismatch")
-        return true
+        return netplanFileConfig{}, false
     }
 
     netplanData, err := d.parseNetplanFile(content)
     if err != nil {
         d.logger.WithError(err).WithField("file", configPath).Warn("Failed to parse Netplan YAML, treating as configuration mismatch")
-        return true
+        return netplanFileConfig{}, false
     }
     fileConfig := d.extractNetplanConfig(netplanData)
 
@@ -85,25 +143,41 @@ func (d *DriftDetector) IsNetplanDrift(ctx context.Context, dbIface entities.Net
             "db_mac":   dbIface.MacAddress(),
             "file_mac": fileConfig.macAddress,
         }).Warn("MAC address mismatch, treating as configuration change")
-        return true
+        return netplanFileConfig{}, false
     }
+    return fileConfig, true
+}
 
-    // Validate against system state
+func (d *DriftDetector) IsIfcfgDrift(ctx context.Context, dbIface entities.NetworkInterface, configPath string) bool {
+    fileConfig, ok := d.readIfcfgConfig(dbIface, configPath)
+    if !ok {
+        return true
+    }
     interfaceName := d.extractInterfaceNameFromPath(configPath)
     if interfaceName != "" {
         if d.checkSystemInterfaceDrift(ctx, dbIface, interfaceName) {
             return true
         }
     }
+    return d.checkIfcfgDrift(dbIface, fileConfig)
+}
 
-    return d.checkConfigDrift(dbIface, fileConfig)
+// IsIfcfgFileDrift compares only the persisted ifcfg file with the spec (see IsNetplanFileDrift)
+func (d *DriftDetector) IsIfcfgFileDrift(dbIface entities.NetworkInterface, configPath string) bool {
+    fileConfig, ok := d.readIfcfgConfig(dbIface, configPath)
+    if !ok {
+        return true
+    }
+    return d.checkIfcfgDrift(dbIface, fileConfig)
 }
 
-func (d *DriftDetector) IsIfcfgDrift(ctx context.Context, dbIface entities.NetworkInterface, configPath string) bool {
+// readIfcfgConfig loads the ifcfg file of dbIface; ok is false when it is unreadable or written
+// for another MAC
+func (d *DriftDetector) readIfcfgConfig(dbIface entities.NetworkInterface, configPath string) (ifcfgFileConfig, bool) {
     content, err := d.fs.ReadFile(configPath)
     if err != nil {
         d.logger.WithError(err).WithField("file", configPath).Warn("Failed to read ifcfg file, treating as configuration mismatch")
-        return true
+        return ifcfgFileConfig{}, false
     }
     fileConfig := d.parseIfcfgFile(content)
     if fileConfig.macAddress != strings.ToLower(dbIface.MacAddress()) {
@@ -111,15 +185,9 @@ func (d *DriftDetector) IsIfcfgDrift(ctx context.Context, dbIface entities.Netwo
             "db_mac":   dbIface.MacAddress(),
             "file_mac": fileConfig.macAddress,
         }).Warn("MAC address mismatch in ifcfg file")
-        return true
-    }
-    interfaceName := d.extractInterfaceNameFromPath(configPath)
-    if interfaceName != "" {
-        if d.checkSystemInterfaceDrift(ctx, dbIface, interfaceName) {
-            return true
-        }
+        return ifcfgFileConfig{}, false
     }
-    return d.checkIfcfgDrift(dbIface, fileConfig)
+    return fileConfig, true
 }
 
 func (d *DriftDetector) FindNetplanFileForInterface(configDir, interfaceName string) string {
@@ -129,7 +197,12 @@ func (d *DriftDetector) FindNetplanFileForInterface(configDir, interfaceName str
         return ""
     }
     for _, file := range files {
-        if strings.Contains(file, interfaceName) && strings.HasSuffix(file, ".yaml") {
+        // 부분 일치는 multinic1이 911-multinic11.yaml을 잡게 되므로 이름을 정확히 비교한다
+        name, ok := constants.ParseConfigFileName(file, ".yaml")
+        if !ok {
+            name = strings.TrimSuffix(file, ".yaml")
+        }
+        if name == interfaceName && strings.HasSuffix(file, ".yaml") {
             return filepath.Join(configDir, file)
         }
     }
@@ -156,19 +229,40 @@ func (d *DriftDetector) parseNetplanFile(content []byte) (*NetplanYAML, error) {
 
 func (d *DriftDetector) extractNetplanConfig(netplanData *NetplanYAML) netplanFileConfig {
     config := netplanFileConfig{}
-    for _, eth := range netplanData.Network.Ethernets {
-        config.macAddress = eth.Match.MACAddress
-        config.hasAddresses = len(eth.Addresses) > 0
-        config.mtu = eth.MTU
-        if config.hasAddresses {
-            ip, ipNet, err := net.ParseCIDR(eth.Addresses[0])
-            if err == nil {
-                config.address = ip.String()
-                config.cidr = ipNet.String()
-            } else {
-                config.address = eth.Addresses[0]
-                config.cidr = ""
-            }
+    // bond 파일의 ethernets는 멤버 포트이므로 주소/MTU는 bond 항목에서 읽습니다
+    found := false
+    for _, bond := range netplanData.Network.Bonds {
+        config.macAddress = bond.MACAddress
+        config.hasAddresses = len(bond.Addresses) > 0
+        config.mtu = bond.MTU
+        config.addresses = append([]string(nil), bond.Addresses...)
+        config.dhcp4, config.dhcp6 = bond.DHCP4, bond.DHCP6
+        config.nameservers = bond.Nameservers
+        found = true
+        break
+    }
+    if !found {
+        for _, eth := range netplanData.Network.Ethernets {
+            config.macAddress = eth.Match.MACAddress
+            config.hasAddresses = len(eth.Addresses) > 0
+            config.mtu = eth.MTU
+            config.addresses = append([]string(nil), eth.Addresses...)
+            config.dhcp4, config.dhcp6 = eth.DHCP4, eth.DHCP6
+            config.nameservers = eth.Nameservers
+            break
+        }
+    }
+    // IP를 bridge로 옮긴 경우 포트에는 주소가 없고 bridge 항목이 주소를 가집니다
+    for _, br := range netplanData.Network.Bridges {
+        if len(br.Addresses) > 0 {
+            config.hasAddresses = true
+            config.addresses = append([]string(nil), br.Addresses...)
+        }
+        if br.DHCP4 || br.DHCP6 {
+            config.dhcp4, config.dhcp6 = br.DHCP4, br.DHCP6
+        }
+        if len(br.Nameservers.Addresses) > 0 || len(br.Nameservers.Search) > 0 {
+            config.nameservers = br.Nameservers
         }
         break
     }
@@ -176,26 +270,41 @@ func (d *DriftDetector) extractNetplanConfig(netplanData *NetplanYAML) netplanFi
 }
 
 func (d *DriftDetector) checkConfigDrift(dbIface entities.NetworkInterface, fileConfig netplanFileConfig) bool {
-    isDrifted := (!fileConfig.hasAddresses && dbIface.Address() != "") ||
-        (dbIface.Address() != fileConfig.address) ||
-        (dbIface.CIDR() != fileConfig.cidr) ||
+    // 주소는 순서와 표기(IPv6 축약 등)에 무관하게 집합으로 비교
+    dbAddrs := interfaceAddressSet(dbIface)
+    fileAddrs := addressSet(fileConfig.addresses)
+    addrDrift, prefixDrift := compareAddressSets(dbAddrs, fileAddrs)
+    dhcpDrift := (dbIface.IPv4Mode() == entities.AddressModeDHCP) != fileConfig.dhcp4 ||
+        (dbIface.IPv6Mode() == entities.AddressModeDHCP) != fileConfig.dhcp6
+    // 네임서버는 순서가 조회 우선순위이므로 순서까지 비교
+    var dbServers, dbSearch []string
+    if dns := dbIface.DNS(); dns != nil {
+        dbServers, dbSearch = dns.Servers(), dns.Search()
+    }
+    dnsDrift := !slices.Equal(dbServers, fileConfig.nameservers.Addresses) ||
+        !slices.Equal(dbSearch, fileConfig.nameservers.Search)
+    isDrifted := (!fileConfig.hasAddresses && len(dbAddrs) > 0) ||
+        addrDrift ||
+        prefixDrift ||
+        dhcpDrift ||
+        dnsDrift ||
         (dbIface.MTU() != fileConfig.mtu)
 
     if isDrifted {
         d.logger.WithFields(logrus.Fields{
-            "interface_id": dbIface.ID(),
-            "mac_address":  dbIface.MacAddress(),
-            "db_address":   dbIface.Address(),
-            "db_cidr":      dbIface.CIDR(),
-            "db_mtu":       dbIface.MTU(),
-            "file_address": fileConfig.address,
-            "file_cidr":    fileConfig.cidr,
-            "file_mtu":     fileConfig.mtu,
+            "interface_id":   dbIface.ID(),
+            "mac_address":    dbIface.MacAddress(),
+            "db_addresses":   sortedKeys(dbAddrs),
+            "db_mtu":         dbIface.MTU(),
+            "file_addresses": sortedKeys(fileAddrs),
+            "file_mtu":       fileConfig.mtu,
         }).Debug("netplan configuration drift detected")
 
-        if !fileConfig.hasAddresses && dbIface.Address() != "" { metrics.RecordDrift("missing_address") }
-        if dbIface.Address() != fileConfig.address { metrics.RecordDrift("ip_address") }
-        if dbIface.CIDR() != fileConfig.cidr { metrics.RecordDrift("cidr") }
+        if !fileConfig.hasAddresses && len(dbAddrs) > 0 { metrics.RecordDrift("missing_address") }
+        if addrDrift { metrics.RecordDrift("ip_address") }
+        if prefixDrift { metrics.RecordDrift("cidr") }
+        if dhcpDrift { metrics.RecordDrift("dhcp_mode") }
+        if dnsDrift { metrics.RecordDrift("dns") }
         if dbIface.MTU() != fileConfig.mtu { metrics.RecordDrift("mtu") }
     }
     return isDrifted
@@ -215,6 +324,9 @@ func (d *DriftDetector) extractInterfaceNameFromPath(configPath string) string {
 
 func (d *DriftDetector) parseIfcfgFile(content []byte) ifcfgFileConfig {
     config := ifcfgFileConfig{}
+    ipv4 := map[string]string{}   // suffix ("" or "0".."N") -> address
+    prefix := map[string]string{} // suffix -> prefix
+    var ipv4Order []string
     scanner := bufio.NewScanner(strings.NewReader(string(content)))
     for scanner.Scan() {
         line := strings.TrimSpace(scanner.Text())
@@ -222,40 +334,107 @@ func (d *DriftDetector) parseIfcfgFile(content []byte) ifcfgFileConfig {
         parts := strings.SplitN(line, "=", 2)
         if len(parts) != 2 { continue }
         key := strings.TrimSpace(parts[0])
-        value := strings.TrimSpace(parts[1])
-        switch key {
-        case "HWADDR": config.macAddress = strings.ToLower(value)
-        case "IPADDR": config.ipAddress = value
-        case "PREFIX": config.prefix = value
-        case "MTU": if mtu, err := strconv.Atoi(value); err == nil { config.mtu = mtu }
+        value := strings.Trim(strings.TrimSpace(parts[1]), `"`)
+        switch {
+        case key == "HWADDR": config.macAddress = strings.ToLower(value)
+        case key == "MTU": if mtu, err := strconv.Atoi(value); err == nil { config.mtu = mtu }
+        case key == "IPV6ADDR" || key == "IPV6ADDR_SECONDARIES":
+            // IPv6 entries carry the prefix inline (addr/prefix), secondaries are space separated
+            config.addresses = append(config.addresses, strings.Fields(value)...)
+        case strings.HasPrefix(key, "IPADDR"):
+            suffix := strings.TrimPrefix(key, "IPADDR")
+            ipv4[suffix] = value
+            ipv4Order = append(ipv4Order, suffix)
+        case strings.HasPrefix(key, "PREFIX"):
+            prefix[strings.TrimPrefix(key, "PREFIX")] = value
         }
     }
+    for _, suffix := range ipv4Order {
+        addr := ipv4[suffix]
+        if p := prefix[suffix]; p != "" {
+            addr = addr + "/" + p
+        }
+        config.addresses = append(config.addresses, addr)
+    }
     return config
 }
 
 func (d *DriftDetector) checkIfcfgDrift(dbIface entities.NetworkInterface, fileConfig ifcfgFileConfig) bool {
-    var dbPrefix string
-    if dbIface.CIDR() != "" {
-        if parts := strings.Split(dbIface.CIDR(), "/"); len(parts) == 2 { dbPrefix = parts[1] }
-    }
-    isDrifted := (dbIface.Address() != fileConfig.ipAddress) ||
-        (dbPrefix != "" && fileConfig.prefix != "" && dbPrefix != fileConfig.prefix) ||
-        (dbIface.MTU() != fileConfig.mtu)
+    dbAddrs := interfaceAddressSet(dbIface)
+    fileAddrs := addressSet(fileConfig.addresses)
+    addrDrift, prefixDrift := compareAddressSets(dbAddrs, fileAddrs)
+    isDrifted := addrDrift || prefixDrift || (dbIface.MTU() != fileConfig.mtu)
     if isDrifted {
         d.logger.WithFields(logrus.Fields{
-            "interface_id": dbIface.ID(),
-            "mac_address":  dbIface.MacAddress(),
-            "db_address":   dbIface.Address(),
-            "db_cidr":      dbIface.CIDR(),
-            "db_mtu":       dbIface.MTU(),
-            "file_address": fileConfig.ipAddress,
-            "file_prefix":  fileConfig.prefix,
-            "file_mtu":     fileConfig.mtu,
+            "interface_id":   dbIface.ID(),
+            "mac_address":    dbIface.MacAddress(),
+            "db_addresses":   sortedKeys(dbAddrs),
+            "db_mtu":         dbIface.MTU(),
+            "file_addresses": sortedKeys(fileAddrs),
+            "file_mtu":       fileConfig.mtu,
         }).Debug("ifcfg configuration drift detected")
     }
     return isDrifted
 }
 
+// interfaceAddressSet maps canonical IP -> prefix length for every address of the entity.
+func interfaceAddressSet(iface entities.NetworkInterface) map[string]string {
+    out := map[string]string{}
+    for _, a := range iface.Addresses() {
+        out[canonicalIP(a.Address())] = strconv.Itoa(a.PrefixLength())
+    }
+    return out
+}
+
+// addressSet maps canonical IP -> prefix length ("" when the entry has no prefix).
+func addressSet(entries []string) map[string]string {
+    out := map[string]string{}
+    for _, e := range entries {
+        addr, prefix, _ := strings.Cut(strings.TrimSpace(e), "/")
+        if addr == "" { continue }
+        out[canonicalIP(addr)] = prefix
+    }
+    return out
+}
+
+// compareAddressSets reports whether the IP sets differ, and whether a shared IP
+// changed its prefix length. A missing prefix on the file side is not treated as drift.
+func compareAddressSets(db, file map[string]string) (addrDrift, prefixDrift bool) {
+    if len(db) != len(file) {
+        addrDrift = true
+    }
+    for ip, dbPrefix := range db {
+        filePrefix, ok := file[ip]
+        if !ok {
+            addrDrift = true
+            continue
+        }
+        if filePrefix != "" && filePrefix != dbPrefix {
+            prefixDrift = true
+        }
+    }
+    return addrDrift, prefixDrift
+}
+
+func sortedKeys(m map[string]string) []string {
+    out := make([]string, 0, len(m))
+    for k, v := range m {
+        if v != "" { k = k + "/" + v }
+        out = append(out, k)
+    }
+    sort.Strings(out)
+    return out
+}
+
+// canonicalIP returns the canonical text form of an IP so that equivalent
+// IPv6 notations compare equal; unparsable input is returned unchanged.
+func canonicalIP(value string) string {
+    if ip := net.ParseIP(strings.TrimSpace(value)); ip != nil {
+        return ip.String()
+    }
+    return value
+}
+
 // System checks
 func (d *DriftDetector) checkSystemInterfaceDrift(ctx context.Context, dbIface entities.NetworkInterface, interfaceName string) bool {
     foundName, err := d.naming.FindInterfaceNameByMAC(dbIface.MacAddress())
@@ -275,6 +454,24 @@ func (d *DriftDetector) checkSystemInterfaceDrift(ctx context.Context, dbIface e
     return false
 }
 
+// checkDHCPLeaseDrift reports drift when a DHCP family holds no leased address, so that the
+// interface is re-applied and the client restarted
+func (d *DriftDetector) checkDHCPLeaseDrift(dbIface entities.NetworkInterface, interfaceName string) bool {
+    dev := dbIface.AddressDevice(interfaceName)
+    for _, ipv6 := range []bool{false, true} {
+        mode := dbIface.IPv4Mode()
+        if ipv6 { mode = dbIface.IPv6Mode() }
+        if mode != entities.AddressModeDHCP { continue }
+        leased, err := d.naming.HasDynamicAddress(dev, ipv6)
+        if err != nil || !leased {
+            d.logger.WithFields(logrus.Fields{"interface_name": dev, "ipv6": ipv6, "error": err}).Warn("DHCP lease missing, treating as configuration change")
+            metrics.RecordDrift("dhcp_lease")
+            return true
+        }
+    }
+    return false
+}
+
 func (d *DriftDetector) isInterfaceUp(interfaceName string) bool {
     isUp, err := d.naming.IsInterfaceUp(interfaceName)
     if err != nil {
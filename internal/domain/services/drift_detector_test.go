@@ -6,6 +6,7 @@ import (
     "time"
 
     "multinic-agent/internal/domain/entities"
+    "multinic-agent/internal/infrastructure/adapters"
 
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/mock"
@@ -17,7 +18,7 @@ func TestDriftDetector_IsNetplanDrift_FileNotExist(t *testing.T) {
     mockExec := new(MockCommandExecutor)
     // constructor check for container
     mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
-    naming := NewInterfaceNamingService(mockFS, mockExec)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
     detector := NewDriftDetector(mockFS, logrus.New(), naming)
 
     cfgPath := "/etc/netplan/91-multinic0.yaml"
@@ -32,7 +33,7 @@ func TestDriftDetector_IsNetplanDrift_NoDrift(t *testing.T) {
     mockFS := new(MockFileSystem)
     mockExec := new(MockCommandExecutor)
     mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
-    naming := NewInterfaceNamingService(mockFS, mockExec)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
     detector := NewDriftDetector(mockFS, logrus.New(), naming)
 
     cfgPath := "/etc/netplan/91-multinic0.yaml"
@@ -58,11 +59,40 @@ func TestDriftDetector_IsNetplanDrift_NoDrift(t *testing.T) {
     assert.False(t, drift)
 }
 
+func TestDriftDetector_IsNetplanFileDrift_IgnoresUpLink(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/netplan/91-multinic0.yaml"
+    content := []byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:ff
+      dhcp4: false
+      addresses: ["10.0.0.10/24"]
+      mtu: 1500
+`)
+    mockFS.On("Exists", cfgPath).Return(true)
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+
+    // a configured interface is UP; only the file content decides (an ip command would fail the unset mock)
+    ni, _ := entities.NewNetworkInterface(1, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.10", "10.0.0.0/24", 1500)
+    assert.False(t, detector.IsNetplanFileDrift(*ni, cfgPath))
+
+    changed, _ := entities.NewNetworkInterface(1, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.10", "10.0.0.0/24", 9000)
+    assert.True(t, detector.IsNetplanFileDrift(*changed, cfgPath))
+}
+
 func TestDriftDetector_IsIfcfgDrift_NoDrift(t *testing.T) {
     mockFS := new(MockFileSystem)
     mockExec := new(MockCommandExecutor)
     mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
-    naming := NewInterfaceNamingService(mockFS, mockExec)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
     detector := NewDriftDetector(mockFS, logrus.New(), naming)
 
     cfgPath := "/etc/sysconfig/network-scripts/ifcfg-multinic0"
@@ -77,3 +107,225 @@ func TestDriftDetector_IsIfcfgDrift_NoDrift(t *testing.T) {
     drift := detector.IsIfcfgDrift(context.Background(), *ni, cfgPath)
     assert.False(t, drift)
 }
+
+func TestDriftDetector_IsNetplanDrift_IPv6(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/netplan/90-multinic0.yaml"
+    // 파일에는 축약되지 않은 표기로 기록되어 있어도 동일 주소로 판단해야 함
+    content := []byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:ff
+      dhcp4: false
+      dhcp6: false
+      addresses: ["2001:DB8:10:0:0:0:0:10/64"]
+      mtu: 1500
+`)
+    mockFS.On("Exists", cfgPath).Return(true)
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "-o", "link", "show").Return([]byte("2: multinic0: ... link/ether aa:bb:cc:dd:ee:ff brd ..."), nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "link", "show", "multinic0").Return([]byte("state DOWN"), nil)
+
+    same, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "2001:db8:10::10", "2001:db8:10::/64", 1500)
+    assert.False(t, detector.IsNetplanDrift(context.Background(), *same, cfgPath))
+
+    changed, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "2001:db8:10::20", "2001:db8:10::/64", 1500)
+    assert.True(t, detector.IsNetplanDrift(context.Background(), *changed, cfgPath))
+}
+
+func TestDriftDetector_IsIfcfgDrift_IPv6(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/sysconfig/network-scripts/ifcfg-multinic0"
+    content := []byte("DEVICE=multinic0\nBOOTPROTO=none\nIPV6INIT=yes\nIPV6ADDR=2001:db8:10::10/64\nMTU=1500\nHWADDR=aa:bb:cc:dd:ee:ff\n")
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "-o", "link", "show").Return([]byte("2: multinic0: ... link/ether aa:bb:cc:dd:ee:ff brd ..."), nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "link", "show", "multinic0").Return([]byte("state DOWN"), nil)
+
+    same, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "2001:db8:10::10", "2001:db8:10::/64", 1500)
+    assert.False(t, detector.IsIfcfgDrift(context.Background(), *same, cfgPath))
+
+    changed, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "2001:db8:10::10", "2001:db8:10::/80", 1500)
+    assert.True(t, detector.IsIfcfgDrift(context.Background(), *changed, cfgPath))
+}
+
+func TestDriftDetector_IsNetplanDrift_AddressSet(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/netplan/90-multinic0.yaml"
+    // 순서가 달라도 같은 집합이면 drift 아님
+    content := []byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:ff
+      addresses: ["2001:db8::10/64", "10.0.0.100/24", "10.0.0.10/24"]
+      mtu: 1500
+`)
+    mockFS.On("Exists", cfgPath).Return(true)
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "-o", "link", "show").Return([]byte("2: multinic0: ... link/ether aa:bb:cc:dd:ee:ff brd ..."), nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "link", "show", "multinic0").Return([]byte("state DOWN"), nil)
+
+    ni, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.10", "10.0.0.0/24", 1500)
+    _ = ni.AddAddress("10.0.0.100", "10.0.0.0/24")
+    _ = ni.AddAddress("2001:db8::10", "2001:db8::/64")
+    assert.False(t, detector.IsNetplanDrift(context.Background(), *ni, cfgPath))
+
+    // VIP가 spec에서 빠지면 drift
+    withoutVIP, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.10", "10.0.0.0/24", 1500)
+    _ = withoutVIP.AddAddress("2001:db8::10", "2001:db8::/64")
+    assert.True(t, detector.IsNetplanDrift(context.Background(), *withoutVIP, cfgPath))
+}
+
+func TestDriftDetector_IsNetplanDrift_Bond(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/netplan/90-multinic0.yaml"
+    // 멤버 포트(ethernets)에는 주소가 없고 bond 항목이 CR 값을 가짐
+    content := []byte(`network:
+  version: 2
+  ethernets:
+    multinic0-port0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:01
+    multinic0-port1:
+      match:
+        macaddress: aa:bb:cc:dd:ee:02
+  bonds:
+    multinic0:
+      interfaces: [multinic0-port0, multinic0-port1]
+      macaddress: aa:bb:cc:dd:ee:01
+      addresses: ["10.0.0.10/24"]
+      mtu: 9000
+`)
+    mockFS.On("Exists", cfgPath).Return(true)
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+    // 첫 번째 멤버는 bond MAC을 물려받고 permaddr로 자신의 MAC을 표시
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "-o", "link", "show").Return([]byte(
+        "2: ens4: <BROADCAST,MULTICAST,SLAVE,UP> mtu 9000 master multinic0 state UP    link/ether aa:bb:cc:dd:ee:01 brd ff:ff:ff:ff:ff:ff permaddr aa:bb:cc:dd:ee:01\n"+
+            "3: ens5: <BROADCAST,MULTICAST,SLAVE,UP> mtu 9000 master multinic0 state UP    link/ether aa:bb:cc:dd:ee:01 brd ff:ff:ff:ff:ff:ff permaddr aa:bb:cc:dd:ee:02\n"+
+            "4: multinic0: <BROADCAST,MULTICAST,MASTER,UP> mtu 9000 state UP    link/ether aa:bb:cc:dd:ee:01 brd ff:ff:ff:ff:ff:ff"), nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "link", "show", "multinic0").Return([]byte("state DOWN"), nil)
+
+    ni, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:01", "node1", "10.0.0.10", "10.0.0.0/24", 9000)
+    _ = ni.SetBond([]string{"aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02"}, "", 0, "")
+    assert.False(t, detector.IsNetplanDrift(context.Background(), *ni, cfgPath))
+
+    name, err := naming.FindInterfaceNameByMAC("aa:bb:cc:dd:ee:01")
+    assert.NoError(t, err)
+    assert.Equal(t, "multinic0", name)
+    port, master, err := naming.FindPortByMAC("aa:bb:cc:dd:ee:02")
+    assert.NoError(t, err)
+    assert.Equal(t, "ens5", port)
+    assert.Equal(t, "multinic0", master)
+}
+
+func TestDriftDetector_IsNetplanDrift_BridgeOwnsAddress(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/netplan/90-multinic0.yaml"
+    // 포트에는 주소가 없고 bridge가 주소를 가짐 → drift 아님
+    content := []byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:ff
+      set-name: multinic0
+      mtu: 1500
+  bridges:
+    br-vm:
+      interfaces: [multinic0]
+      addresses: ["10.0.0.10/24"]
+`)
+    mockFS.On("Exists", cfgPath).Return(true)
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "-o", "link", "show").Return([]byte(
+        "2: multinic0: <BROADCAST,MULTICAST,UP> mtu 1500 master br-vm state UP    link/ether aa:bb:cc:dd:ee:ff brd ff:ff:ff:ff:ff:ff\n"+
+            "5: br-vm: <BROADCAST,MULTICAST,UP> mtu 1500 state UP    link/ether aa:bb:cc:dd:ee:ff brd ff:ff:ff:ff:ff:ff"), nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "link", "show", "multinic0").Return([]byte("state DOWN"), nil)
+
+    ni, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.10", "10.0.0.0/24", 1500)
+    _ = ni.SetBridge("br-vm", false, true)
+    assert.False(t, detector.IsNetplanDrift(context.Background(), *ni, cfgPath))
+
+    changed, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.11", "10.0.0.0/24", 1500)
+    assert.True(t, detector.IsNetplanDrift(context.Background(), *changed, cfgPath))
+}
+
+func TestDriftDetector_FindNetplanFileForInterface_ExactName(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    // multinic1 must not pick up multinic11's file once indexes go past 9
+    mockFS.On("ListFiles", "/etc/netplan").Return([]string{"50-cloud-init.yaml", "911-multinic11.yaml", "901-multinic1.yaml"}, nil)
+
+    assert.Equal(t, "/etc/netplan/901-multinic1.yaml", detector.FindNetplanFileForInterface("/etc/netplan", "multinic1"))
+    assert.Equal(t, "/etc/netplan/911-multinic11.yaml", detector.FindNetplanFileForInterface("/etc/netplan", "multinic11"))
+    assert.Equal(t, "", detector.FindNetplanFileForInterface("/etc/netplan", "multinic2"))
+}
+
+func TestDriftDetector_IsNetplanDrift_DHCP(t *testing.T) {
+    mockFS := new(MockFileSystem)
+    mockExec := new(MockCommandExecutor)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, time.Second, "test", "-d", "/host").Return([]byte(""), nil)
+    naming := NewInterfaceNamingService(mockFS, mockExec, adapters.NewLinkLister(mockExec))
+    detector := NewDriftDetector(mockFS, logrus.New(), naming)
+
+    cfgPath := "/etc/netplan/90-multinic0.yaml"
+    content := []byte(`network:
+  version: 2
+  ethernets:
+    multinic0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:ff
+      dhcp4: true
+      mtu: 1500
+`)
+    mockFS.On("Exists", cfgPath).Return(true)
+    mockFS.On("ReadFile", cfgPath).Return(content, nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "-o", "link", "show").Return([]byte("2: multinic0: ... link/ether aa:bb:cc:dd:ee:ff brd ..."), nil)
+    mockExec.On("ExecuteWithTimeout", mock.Anything, 10*time.Second, "ip", "link", "show", "multinic0").Return([]byte("state DOWN"), nil)
+    leaseCall := mockExec.On("ExecuteWithTimeout", mock.Anything, 5*time.Second, "ip", "-o", "-4", "addr", "show", "dev", "multinic0", "scope", "global", "dynamic").
+        Return([]byte("2: multinic0    inet 10.0.0.50/24 scope global dynamic multinic0"), nil)
+
+    ni, _ := entities.NewNetworkInterfaceWithoutAddress(0, "", "aa:bb:cc:dd:ee:ff", "node1", 1500)
+    _ = ni.SetAddressModes("dhcp", "")
+    assert.False(t, detector.IsNetplanDrift(context.Background(), *ni, cfgPath))
+
+    // 파일은 DHCP지만 spec은 static → drift
+    static, _ := entities.NewNetworkInterface(0, "aa:bb:cc:dd:ee:ff", "node1", "10.0.0.10", "10.0.0.0/24", 1500)
+    assert.True(t, detector.IsNetplanDrift(context.Background(), *static, cfgPath))
+
+    // lease가 없으면 재적용 대상
+    leaseCall.Return([]byte(""), nil)
+    assert.True(t, detector.IsNetplanDrift(context.Background(), *ni, cfgPath))
+}
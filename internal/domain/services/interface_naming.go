@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"multinic-agent/internal/domain/constants"
 	"multinic-agent/internal/domain/entities"
 	"multinic-agent/internal/domain/interfaces"
 	"regexp"
@@ -15,6 +16,7 @@ import (
 type InterfaceNamingService struct {
 	fileSystem      interfaces.FileSystem
 	commandExecutor interfaces.CommandExecutor
+	links           interfaces.LinkLister // ip -o link show 파싱 (infrastructure 공용 파서)
 	isContainer     bool       // indicates if running in container
 	namingMutex     sync.Mutex // 인터페이스 이름 생성 동시성 제어
 	// 사전 배정용 상태(프로세스 수명 동안만 유지)
@@ -23,7 +25,7 @@ type InterfaceNamingService struct {
 }
 
 // NewInterfaceNamingService는 새로운 InterfaceNamingService를 생성합니다
-func NewInterfaceNamingService(fs interfaces.FileSystem, executor interfaces.CommandExecutor) *InterfaceNamingService {
+func NewInterfaceNamingService(fs interfaces.FileSystem, executor interfaces.CommandExecutor, links interfaces.LinkLister) *InterfaceNamingService {
 	// Check if running in container by checking if /host exists
 	isContainer := false
 	if _, err := executor.ExecuteWithTimeout(context.Background(), 1*time.Second, "test", "-d", "/host"); err == nil {
@@ -33,6 +35,7 @@ func NewInterfaceNamingService(fs interfaces.FileSystem, executor interfaces.Com
 	return &InterfaceNamingService{
 		fileSystem:      fs,
 		commandExecutor: executor,
+		links:           links,
 		isContainer:     isContainer,
 		reservedByMac:   make(map[string]string),
 		reservedNames:   make(map[string]bool),
@@ -44,8 +47,8 @@ func (s *InterfaceNamingService) GenerateNextName() (*entities.InterfaceName, er
 	s.namingMutex.Lock()
 	defer s.namingMutex.Unlock()
 
-	for i := 0; i < 10; i++ {
-		name := fmt.Sprintf("multinic%d", i)
+	for i := 0; i < constants.MaxInterfaces(); i++ {
+		name := constants.InterfaceName(i)
 
 		// 실제 인터페이스로 존재하는지 확인
 		if s.isInterfaceInUse(name) {
@@ -56,7 +59,7 @@ func (s *InterfaceNamingService) GenerateNextName() (*entities.InterfaceName, er
 		return entities.NewInterfaceName(name)
 	}
 
-	return nil, fmt.Errorf("사용 가능한 인터페이스 이름이 없습니다 (multinic0-9 모두 사용 중)")
+	return nil, fmt.Errorf("사용 가능한 인터페이스 이름이 없습니다 (%s 모두 사용 중)", nameRange())
 }
 
 // GenerateNextNameForMAC은 특정 MAC 주소에 대한 인터페이스 이름을 생성합니다
@@ -73,8 +76,8 @@ func (s *InterfaceNamingService) GenerateNextNameForMAC(macAddress string) (*ent
 	}
 
 	// 먼저 해당 MAC 주소로 이미 설정된 인터페이스가 있는지 확인
-	for i := 0; i < 10; i++ {
-		name := fmt.Sprintf("multinic%d", i)
+	for i := 0; i < constants.MaxInterfaces(); i++ {
+		name := constants.InterfaceName(i)
 
 		// ip 명령어로 MAC 주소 확인
 		if s.isNameTaken(name) {
@@ -100,8 +103,8 @@ func (s *InterfaceNamingService) GenerateNextNameForMAC(macAddress string) (*ent
 
 // generateNextNameInternal은 락이 이미 걸린 상태에서 호출되는 내부 함수입니다
 func (s *InterfaceNamingService) generateNextNameInternal() (*entities.InterfaceName, error) {
-	for i := 0; i < 10; i++ {
-		name := fmt.Sprintf("multinic%d", i)
+	for i := 0; i < constants.MaxInterfaces(); i++ {
+		name := constants.InterfaceName(i)
 
 		// 실제 인터페이스로 존재하는지 확인
 		if s.isNameTaken(name) {
@@ -112,7 +115,12 @@ func (s *InterfaceNamingService) generateNextNameInternal() (*entities.Interface
 		return entities.NewInterfaceName(name)
 	}
 
-	return nil, fmt.Errorf("사용 가능한 인터페이스 이름이 없습니다 (multinic0-9 모두 사용 중)")
+	return nil, fmt.Errorf("사용 가능한 인터페이스 이름이 없습니다 (%s 모두 사용 중)", nameRange())
+}
+
+// nameRange는 오류 메시지용 이름 범위를 반환합니다 (예: multinic0-9)
+func nameRange() string {
+	return fmt.Sprintf("%s-%d", constants.InterfaceName(0), constants.MaxInterfaces()-1)
 }
 
 // isInterfaceInUse는 인터페이스가 이미 사용 중인지 확인합니다
@@ -167,8 +175,8 @@ func (s *InterfaceNamingService) ReserveNamesForInterfaces(ifaces []entities.Net
 	}
 
 	// 1) 기존 multinicX 중 MAC이 일치하는 경우 재사용
-	for i := 0; i < 10; i++ {
-		name := fmt.Sprintf("multinic%d", i)
+	for i := 0; i < constants.MaxInterfaces(); i++ {
+		name := constants.InterfaceName(i)
 		if !s.isInterfaceInUse(name) {
 			continue
 		}
@@ -202,15 +210,15 @@ func (s *InterfaceNamingService) ReserveNamesForInterfaces(ifaces []entities.Net
 		}
 		// 다음 가용 이름 찾기
 		var chosen string
-		for i := 0; i < 10; i++ {
-			candidate := fmt.Sprintf("multinic%d", i)
+		for i := 0; i < constants.MaxInterfaces(); i++ {
+			candidate := constants.InterfaceName(i)
 			if !s.isNameTaken(candidate) {
 				chosen = candidate
 				break
 			}
 		}
 		if chosen == "" {
-			return nil, fmt.Errorf("사용 가능한 인터페이스 이름이 없습니다 (multinic0-9 모두 사용/예약됨)")
+			return nil, fmt.Errorf("사용 가능한 인터페이스 이름이 없습니다 (%s 모두 사용/예약됨)", nameRange())
 		}
 		s.reservedByMac[macLower] = chosen
 		s.reservedNames[chosen] = true
@@ -227,8 +235,8 @@ func (s *InterfaceNamingService) ReserveNamesForInterfaces(ifaces []entities.Net
 func (s *InterfaceNamingService) GetCurrentMultinicInterfaces() []entities.InterfaceName {
 	var interfaces []entities.InterfaceName
 
-	for i := 0; i < 10; i++ {
-		name := fmt.Sprintf("multinic%d", i)
+	for i := 0; i < constants.MaxInterfaces(); i++ {
+		name := constants.InterfaceName(i)
 		if s.isInterfaceInUse(name) {
 			if interfaceName, err := entities.NewInterfaceName(name); err == nil {
 				interfaces = append(interfaces, *interfaceName)
@@ -297,34 +305,56 @@ func (s *InterfaceNamingService) InterfaceExists(name string) bool {
 	return s.isInterfaceInUse(name)
 }
 
-// FindInterfaceNameByMAC는 시스템 전체 인터페이스 중 주어진 MAC을 가진 인터페이스 이름을 찾습니다.
-// ip -o link show 출력에서 라인 단위로 파싱하여 인터페이스명과 MAC을 매칭합니다.
-func (s *InterfaceNamingService) FindInterfaceNameByMAC(macAddress string) (string, error) {
+// listSystemLinks는 시스템의 링크 목록을 읽습니다 (파싱은 LinkLister 구현이 담당)
+func (s *InterfaceNamingService) listSystemLinks() ([]interfaces.Link, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return s.links.ListLinks(ctx)
+}
 
-    output, err := s.commandExecutor.ExecuteWithTimeout(ctx, 10*time.Second, "ip", "-o", "link", "show")
-    if err != nil {
-        return "", fmt.Errorf("failed to list system interfaces: %w", err)
-    }
+// FindInterfaceNameByMAC는 시스템 전체 인터페이스 중 주어진 MAC을 가진 인터페이스 이름을 찾습니다.
+// bond master와 첫 번째 멤버처럼 같은 MAC이 여러 곳에 보이면 multinic 이름을 우선합니다.
+func (s *InterfaceNamingService) FindInterfaceNameByMAC(macAddress string) (string, error) {
+	links, err := s.listSystemLinks()
+	if err != nil {
+		return "", err
+	}
 
 	macLower := strings.ToLower(strings.TrimSpace(macAddress))
-	// 예: "2: ens3: <...> mtu ... qdisc ... state ... link/ether fa:16:3e:e8:ae:9d brd ..."
-	lineRe := regexp.MustCompile(`^\s*\d+:\s+([^:]+):.*link/ether\s+([0-9A-Fa-f:]{17})`) // 인터페이스명, MAC
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		m := lineRe.FindStringSubmatch(line)
-		if len(m) == 3 {
-			name := m[1]
-			mac := strings.ToLower(m[2])
-			if mac == macLower {
-				return name, nil
-			}
+	found := ""
+	for _, link := range links {
+		// VLAN 하위 인터페이스("multinic0.100@multinic0")는 부모 MAC을 공유하므로 제외
+		if link.Stacked() || link.Identity() != macLower {
+			continue
+		}
+		if constants.IsManagedInterfaceName(link.Name) {
+			return link.Name, nil
 		}
+		if found == "" {
+			found = link.Name
+		}
+	}
+	if found != "" {
+		return found, nil
 	}
     return "", fmt.Errorf("interface with MAC %s not found", macAddress)
 }
 
+// FindPortByMAC는 bond master를 제외하고 MAC을 가진 물리 포트와 그 master를 찾습니다.
+// bond 멤버 preflight에 사용되며, master가 없으면 빈 문자열을 반환합니다.
+func (s *InterfaceNamingService) FindPortByMAC(macAddress string) (name string, master string, err error) {
+	links, err := s.listSystemLinks()
+	if err != nil {
+		return "", "", err
+	}
+	for _, link := range links {
+		if link.IsPortFor(macAddress) {
+			return link.Name, link.Master, nil
+		}
+	}
+	return "", "", fmt.Errorf("port with MAC %s not found", macAddress)
+}
+
 // IsMacPresent는 시스템에 해당 MAC을 가진 인터페이스가 존재하는지 여부를 반환합니다.
 func (s *InterfaceNamingService) IsMacPresent(macAddress string) bool {
 	if name, err := s.FindInterfaceNameByMAC(macAddress); err == nil && name != "" {
@@ -350,6 +380,21 @@ func (s *InterfaceNamingService) IsInterfaceUp(interfaceName string) (bool, erro
 		(strings.Contains(outputStr, ",UP,") && strings.Contains(outputStr, "LOWER_UP")), nil
 }
 
+// HasDynamicAddress는 인터페이스에 DHCP(또는 SLAAC)로 받은 global 주소가 있는지 확인합니다
+func (s *InterfaceNamingService) HasDynamicAddress(interfaceName string, ipv6 bool) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	family := "-4"
+	if ipv6 {
+		family = "-6"
+	}
+	output, err := s.commandExecutor.ExecuteWithTimeout(ctx, 5*time.Second, "ip", "-o", family, "addr", "show", "dev", interfaceName, "scope", "global", "dynamic")
+	if err != nil {
+		return false, fmt.Errorf("인터페이스 %s 주소 조회 실패: %w", interfaceName, err)
+	}
+	return strings.TrimSpace(string(output)) != "", nil
+}
+
 // GetInterfaceMTU는 특정 인터페이스의 MTU 값을 반환합니다
 func (s *InterfaceNamingService) GetInterfaceMTU(interfaceName string) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
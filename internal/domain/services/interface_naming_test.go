@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"multinic-agent/internal/infrastructure/adapters"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -152,7 +154,7 @@ func TestInterfaceNamingService_GenerateNextName(t *testing.T) {
 			mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "nmcli", "-t", "-f", "NAME", "c", "show").Return([]byte(""), nil).Maybe()
 			// 컨테이너 환경에서 nsenter 사용하는 경우도 대비
 			mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "nmcli", "-t", "-f", "NAME", "c", "show").Return([]byte(""), nil).Maybe()
-			service := NewInterfaceNamingService(mockFS, mockExecutor)
+			service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 			result, err := service.GenerateNextName()
 
 			if tt.wantError {
@@ -201,7 +203,7 @@ func TestInterfaceNamingService_isInterfaceInUse(t *testing.T) {
 			expectedPath := fmt.Sprintf("/sys/class/net/%s", tt.interfaceName)
 			mockFS.On("Exists", expectedPath).Return(tt.exists)
 
-			service := NewInterfaceNamingService(mockFS, mockExecutor)
+			service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 			result := service.isInterfaceInUse(tt.interfaceName)
 
 			assert.Equal(t, tt.expected, result)
@@ -272,7 +274,7 @@ func TestInterfaceNamingService_GetCurrentMultinicInterfaces_SystemBased(t *test
 			mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "nmcli", "-t", "-f", "NAME", "c", "show").Return([]byte(""), nil).Maybe()
 			tt.setupMock(mockFS)
 
-			service := NewInterfaceNamingService(mockFS, mockExecutor)
+			service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 			interfaces := service.GetCurrentMultinicInterfaces()
 
 			assert.Equal(t, tt.expectedCount, len(interfaces))
@@ -354,7 +356,7 @@ func TestInterfaceNamingService_GetMacAddressForInterface_FromIPCommand(t *testi
 			mockExecutor.On("ExecuteWithTimeout", mock.Anything, mock.Anything, "nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "nmcli", "-t", "-f", "NAME", "c", "show").Return([]byte(""), nil).Maybe()
 			tt.setupMock(mockFS, mockExecutor)
 
-			service := NewInterfaceNamingService(mockFS, mockExecutor)
+			service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 			mac, err := service.GetMacAddressForInterface(tt.interfaceName)
 
 			if tt.expectError {
@@ -418,7 +420,7 @@ func TestInterfaceNamingService_GetHostname(t *testing.T) {
 					Return([]byte(tt.hostnameOutput), nil).Once()
 			}
 
-			service := NewInterfaceNamingService(mockFS, mockExecutor)
+			service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 			hostname, err := service.GetHostname()
 
 			if tt.expectError {
@@ -448,7 +450,7 @@ func TestInterfaceNamingService_ConcurrentNaming(t *testing.T) {
 		mockFS.On("Exists", fmt.Sprintf("/sys/class/net/multinic%d", i)).Return(false).Maybe()
 	}
 
-	service := NewInterfaceNamingService(mockFS, mockExecutor)
+	service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 
 	// 10개의 고루틴이 동시에 이름을 요청
 	const numGoroutines = 10
@@ -517,7 +519,7 @@ func TestInterfaceNamingService_ConcurrentNamingForMAC(t *testing.T) {
 		mockFS.On("Exists", fmt.Sprintf("/sys/class/net/multinic%d", i)).Return(false)
 	}
 
-	service := NewInterfaceNamingService(mockFS, mockExecutor)
+	service := NewInterfaceNamingService(mockFS, mockExecutor, adapters.NewLinkLister(mockExecutor))
 
 	// 동일한 MAC 주소로 동시 요청
 	const numGoroutines = 5
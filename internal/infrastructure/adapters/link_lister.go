@@ -0,0 +1,65 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"multinic-agent/internal/domain/interfaces"
+)
+
+var (
+	linkLineRe   = regexp.MustCompile(`^\s*\d+:\s+([^:\s]+):(?:\s+<([^>]*)>)?`)
+	linkMasterRe = regexp.MustCompile(`\smaster\s+(\S+)`)
+	linkEtherRe  = regexp.MustCompile(`link/ether\s+([0-9A-Fa-f:]{17})`)
+	linkPermRe   = regexp.MustCompile(`permaddr\s+([0-9A-Fa-f:]{17})`)
+)
+
+// IPLinkLister is a LinkLister reading the host link table through "ip -o link show"
+type IPLinkLister struct {
+	executor interfaces.CommandExecutor
+}
+
+// NewLinkLister creates a LinkLister running ip through executor
+func NewLinkLister(executor interfaces.CommandExecutor) interfaces.LinkLister {
+	return &IPLinkLister{executor: executor}
+}
+
+// ListLinks returns every link of the host
+func (l *IPLinkLister) ListLinks(ctx context.Context) ([]interfaces.Link, error) {
+	out, err := l.executor.ExecuteWithTimeout(ctx, 10*time.Second, "ip", "-o", "link", "show")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list system interfaces: %w", err)
+	}
+	return ParseLinks(out), nil
+}
+
+// ParseLinks parses "ip -o link show" output, one link per line.
+// This is the only parser of that output; adapters that run ip themselves (e.g. through
+// nsenter) hand their output to it.
+func ParseLinks(out []byte) []interfaces.Link {
+	var links []interfaces.Link
+	for _, line := range strings.Split(string(out), "\n") {
+		m := linkLineRe.FindStringSubmatch(line)
+		if len(m) != 3 {
+			continue
+		}
+		l := interfaces.Link{Name: m[1]}
+		if m[2] != "" {
+			l.Flags = strings.Split(m[2], ",")
+		}
+		if mm := linkMasterRe.FindStringSubmatch(line); len(mm) == 2 {
+			l.Master = mm[1]
+		}
+		if mm := linkEtherRe.FindStringSubmatch(line); len(mm) == 2 {
+			l.MAC = strings.ToLower(mm[1])
+		}
+		if mm := linkPermRe.FindStringSubmatch(line); len(mm) == 2 {
+			l.PermMAC = strings.ToLower(mm[1])
+		}
+		links = append(links, l)
+	}
+	return links
+}
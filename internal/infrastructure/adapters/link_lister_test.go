@@ -0,0 +1,39 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinks(t *testing.T) {
+	out := "2: ens4: <BROADCAST,MULTICAST,SLAVE,UP> mtu 9000 master multinic0 state UP\\    link/ether aa:bb:cc:dd:ee:01 brd ff:ff:ff:ff:ff:ff permaddr AA:BB:CC:DD:EE:02\n" +
+		"3: multinic0: <BROADCAST,MULTICAST,MASTER,UP,LOWER_UP> mtu 9000 state UP\\    link/ether aa:bb:cc:dd:ee:01 brd ff:ff:ff:ff:ff:ff\n" +
+		"4: multinic0.100@multinic0: <BROADCAST,MULTICAST,UP> mtu 9000 state UP\\    link/ether aa:bb:cc:dd:ee:01 brd ff:ff:ff:ff:ff:ff\n" +
+		"5: vrf-a: <NOARP,MASTER,UP,LOWER_UP> mtu 65575 state UP\\    link/ether 12:34:56:78:9a:bc brd ff:ff:ff:ff:ff:ff\n" +
+		"6: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 state UNKNOWN\\    link/loopback 00:00:00:00:00:00 brd 00:00:00:00:00:00\n" +
+		"7: ens9: ... link/ether aa:bb:cc:dd:ee:09 brd ff:ff:ff:ff:ff:ff\n"
+
+	links := ParseLinks([]byte(out))
+	require.Len(t, links, 6)
+
+	assert.Equal(t, "ens4", links[0].Name)
+	assert.Equal(t, "multinic0", links[0].Master)
+	assert.Equal(t, "aa:bb:cc:dd:ee:02", links[0].Identity())
+	assert.True(t, links[1].HasFlag("MASTER"))
+	assert.True(t, links[2].Stacked())
+	assert.Equal(t, "", links[4].MAC)
+	// a line without flags still yields its name and MAC
+	assert.Equal(t, "ens9", links[5].Name)
+	assert.Equal(t, "aa:bb:cc:dd:ee:09", links[5].MAC)
+
+	// the bond master and the VLAN share the port MAC; only the port owns it
+	var owners []string
+	for _, l := range links {
+		if l.IsPortFor("AA:BB:CC:DD:EE:02") || l.IsPortFor("aa:bb:cc:dd:ee:01") {
+			owners = append(owners, l.Name)
+		}
+	}
+	assert.Equal(t, []string{"ens4"}, owners)
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"multinic-agent/internal/domain/constants"
 	"multinic-agent/internal/domain/errors"
 	"os"
+	"regexp"
 	"strconv"
 	"time"
 )
@@ -40,6 +42,7 @@ type AgentConfig struct {
     DataSource         string // 데이터 소스 선택: "db" | "nodecr"
     NodeCRNamespace    string // nodecr 선택 시, 조회할 네임스페이스 (기본: multinic-system)
     RunMode            string // "service"(default) or "job"
+    NodeConfigSpec     string // nodecr Job: 컨트롤러가 정책 기본값을 병합해 넘긴 spec(JSON), 비어 있으면 CR 조회
 }
 
 // NetworkConfig controls runtime networking behaviors for multinic interfaces
@@ -50,6 +53,8 @@ type NetworkConfig struct {
 	UseNoPrefixRoute     bool
 	SetArpSysctls        bool
 	SetLooseRPFilter     bool
+	InterfacePrefix      string // 관리 인터페이스 이름 접두사 (<prefix>N)
+	MaxInterfaces        int    // 노드당 최대 인터페이스 수 (인덱스 0..N-1)
 }
 
 // BackoffConfig is a struct that holds backoff configuration
@@ -105,6 +110,7 @@ func (l *EnvironmentConfigLoader) Load() (*Config, error) {
             DataSource:      getEnvOrDefault("DATA_SOURCE", "db"),
             NodeCRNamespace: getEnvOrDefault("NODE_CR_NAMESPACE", constants.DefaultNodeCRNamespace),
             RunMode:         getEnvOrDefault("RUN_MODE", constants.RunModeService.String()),
+            NodeConfigSpec:  os.Getenv("NODE_CONFIG_SPEC"),
         },
         Health: HealthConfig{
             Port: getEnvOrDefault("HEALTH_PORT", constants.DefaultHealthPort),
@@ -116,6 +122,8 @@ func (l *EnvironmentConfigLoader) Load() (*Config, error) {
             UseNoPrefixRoute:     getEnvBoolOrDefault("NETWORK_NOPREFIXROUTE", true),
             SetArpSysctls:        getEnvBoolOrDefault("NETWORK_SET_ARP_SYSCTLS", true),
             SetLooseRPFilter:     getEnvBoolOrDefault("NETWORK_SET_RP_FILTER_LOOSE", true),
+            InterfacePrefix:      getEnvOrDefault("INTERFACE_PREFIX", constants.DefaultInterfacePrefix),
+            MaxInterfaces:        getEnvIntOrDefault("MAX_INTERFACES", constants.DefaultMaxInterfaces),
         },
     }
 
@@ -165,6 +173,9 @@ func (l *EnvironmentConfigLoader) validate(config *Config) error {
 	if config.Network.RouteMetric < 0 {
 		return errors.NewValidationError("invalid routing metric", nil)
 	}
+	if err := validateInterfaceNaming(config.Network); err != nil {
+		return err
+	}
 
 	// Validate health check configuration
 	if config.Health.Port == "" {
@@ -174,6 +185,30 @@ func (l *EnvironmentConfigLoader) validate(config *Config) error {
 	return nil
 }
 
+// interfacePrefixPattern: 숫자로 끝나면 접두사와 인덱스의 경계가 모호해지므로 허용하지 않는다
+var interfacePrefixPattern = regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_-]*[A-Za-z_-])?$`)
+
+// validateInterfaceNaming validates the interface name prefix and count, including the
+// per-index routing tables they imply
+func validateInterfaceNaming(n NetworkConfig) error {
+	if !interfacePrefixPattern.MatchString(n.InterfacePrefix) {
+		return errors.NewValidationError(fmt.Sprintf("invalid interface prefix: %q", n.InterfacePrefix), nil)
+	}
+	if n.MaxInterfaces <= 0 {
+		return errors.NewValidationError("invalid max interface count", nil)
+	}
+	// 커널 인터페이스 이름은 최대 15자 (IFNAMSIZ-1)
+	if longest := len(n.InterfacePrefix) + len(strconv.Itoa(n.MaxInterfaces-1)); longest > 15 {
+		return errors.NewValidationError(fmt.Sprintf("interface names would exceed 15 characters (%s%d)", n.InterfacePrefix, n.MaxInterfaces-1), nil)
+	}
+	// 인터페이스별 테이블(base+index)이 예약 테이블(253 default, 254 main, 255 local)과 겹치면 안 된다
+	last := n.RoutingTableBase + n.MaxInterfaces - 1
+	if n.RoutingTableBase <= 255 && last >= 253 {
+		return errors.NewValidationError(fmt.Sprintf("routing tables %d-%d overlap reserved tables 253-255", n.RoutingTableBase, last), nil)
+	}
+	return nil
+}
+
 // Environment variable helper functions
 
 func getEnvOrDefault(key, defaultValue string) string {
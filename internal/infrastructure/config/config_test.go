@@ -154,6 +154,12 @@ func TestEnvironmentConfigLoader_validate(t *testing.T) {
 				Health: HealthConfig{
 					Port: "8080",
 				},
+				Network: NetworkConfig{
+					RoutingTableBase: 100,
+					RouteMetric:      100,
+					InterfacePrefix:  "multinic",
+					MaxInterfaces:    10,
+				},
 			},
 			wantError: false,
 		},
@@ -229,6 +235,39 @@ func TestEnvironmentConfigLoader_validate(t *testing.T) {
 	}
 }
 
+func TestValidateInterfaceNaming(t *testing.T) {
+	base := NetworkConfig{RoutingTableBase: 100, RouteMetric: 100, InterfacePrefix: "multinic", MaxInterfaces: 10}
+
+	tests := []struct {
+		name      string
+		modify    func(*NetworkConfig)
+		wantError bool
+	}{
+		{name: "기본값", modify: func(n *NetworkConfig) {}},
+		{name: "24개 포트", modify: func(n *NetworkConfig) { n.MaxInterfaces = 24 }},
+		{name: "다른 접두사", modify: func(n *NetworkConfig) { n.InterfacePrefix = "stor" }},
+		{name: "숫자로 끝나는 접두사", modify: func(n *NetworkConfig) { n.InterfacePrefix = "eth1" }, wantError: true},
+		{name: "빈 접두사", modify: func(n *NetworkConfig) { n.InterfacePrefix = "" }, wantError: true},
+		{name: "0개", modify: func(n *NetworkConfig) { n.MaxInterfaces = 0 }, wantError: true},
+		{name: "15자 초과 이름", modify: func(n *NetworkConfig) { n.InterfacePrefix = "verylongprefix"; n.MaxInterfaces = 20 }, wantError: true},
+		{name: "예약 테이블과 겹침", modify: func(n *NetworkConfig) { n.MaxInterfaces = 160 }, wantError: true},
+		{name: "예약 테이블 이후 base", modify: func(n *NetworkConfig) { n.RoutingTableBase = 1000; n.MaxInterfaces = 160 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := base
+			tt.modify(&n)
+			err := validateInterfaceNaming(n)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGetEnvHelpers(t *testing.T) {
 	t.Run("getEnvOrDefault", func(t *testing.T) {
 		// 존재하지 않는 환경 변수
@@ -4,6 +4,7 @@ import (
     "database/sql"
     "fmt"
     "multinic-agent/internal/application/usecases"
+    "multinic-agent/internal/domain/constants"
     "multinic-agent/internal/domain/interfaces"
     "multinic-agent/internal/domain/services"
     "multinic-agent/internal/infrastructure/adapters"
@@ -11,6 +12,7 @@ import (
     "multinic-agent/internal/infrastructure/health"
     "multinic-agent/internal/infrastructure/network"
     "multinic-agent/internal/infrastructure/persistence"
+    "multinic-agent/pkg/generated/clientset/versioned"
     "os"
 
     _ "github.com/go-sql-driver/mysql"
@@ -40,10 +42,13 @@ type Container struct {
 
 	// 레포지토리
 	repository interfaces.NetworkInterfaceRepository
+	// DaemonSet 모드에서 verify 결과를 node config annotation으로 보고 (Job 모드에서는 nil)
+	driftReporter interfaces.DriftReporter
 
 	// 유스케이스
 	configureNetworkUseCase *usecases.ConfigureNetworkUseCase
 	deleteNetworkUseCase    *usecases.DeleteNetworkUseCase
+	verifyNetworkUseCase    *usecases.VerifyNetworkUseCase
 
 	// 데이터베이스
 	db *sql.DB
@@ -56,6 +61,9 @@ func NewContainer(cfg *config.Config, logger *logrus.Logger) (*Container, error)
 		logger: logger,
 	}
 
+	// 이름 규칙은 도메인 전역에서 쓰이므로 서비스 생성 전에 확정한다
+	constants.SetInterfaceNaming(cfg.Network.InterfacePrefix, cfg.Network.MaxInterfaces)
+
 	if err := container.initializeInfrastructure(); err != nil {
 		return nil, err
 	}
@@ -77,23 +85,25 @@ func (c *Container) initializeInfrastructure() error {
     c.fileSystem = adapters.NewRealFileSystem()
     c.commandExecutor = adapters.NewRealCommandExecutor()
     c.clock = adapters.NewRealClock()
-    // Prepare Kubernetes dynamic client (used for OS detection and NodeCR source)
+    // Prepare Kubernetes clients: dynamic for OS detection, typed multinic.io for the NodeCR source
     var dyn dynamicclient.Interface
+    var mnc versioned.Interface
     {
         // Try in-cluster, fallback to KUBECONFIG
-        if cfg, err := rest.InClusterConfig(); err == nil {
+        cfg, err := rest.InClusterConfig()
+        if err != nil {
+            if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
+                if kcfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig); err == nil {
+                    cfg = kcfg
+                }
+            }
+        }
+        if cfg != nil {
             if d, err := dynamicclient.NewForConfig(cfg); err == nil {
                 dyn = d
             }
-        }
-        if dyn == nil {
-            kubeconfig := os.Getenv("KUBECONFIG")
-            if kubeconfig != "" {
-                if cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig); err == nil {
-                    if d, err := dynamicclient.NewForConfig(cfg); err == nil {
-                        dyn = d
-                    }
-                }
+            if m, err := versioned.NewForConfig(cfg); err == nil {
+                mnc = m
             }
         }
     }
@@ -107,10 +117,22 @@ func (c *Container) initializeInfrastructure() error {
 
     // 데이터 소스가 nodecr인 경우, DB 초기화 없이 NodeCR 레포지토리 사용
     if c.config.Agent.DataSource == "nodecr" {
-        if dyn == nil {
+        if mnc == nil {
             return fmt.Errorf("kubernetes client not available for nodecr data source")
         }
-        src := persistence.NewK8sNodeConfigSource(dyn, c.config.Agent.NodeCRNamespace)
+        k8sSource := persistence.NewK8sNodeConfigSource(mnc, c.config.Agent.NodeCRNamespace)
+        var src persistence.NodeConfigSource = k8sSource
+        if spec := c.config.Agent.NodeConfigSpec; spec != "" {
+            // the controller merged cluster policy defaults into this spec
+            static, err := persistence.NewStaticNodeConfigSource(spec)
+            if err != nil {
+                return err
+            }
+            src = static
+        } else {
+            // DaemonSet agent: the controller reads drift from the node config annotation
+            c.driftReporter = k8sSource
+        }
         c.repository = persistence.NewNodeCRRepository(src, c.logger)
         return nil
     }
@@ -146,7 +168,7 @@ func (c *Container) initializeServices() error {
 	c.healthService = health.NewHealthService(c.clock, c.logger)
 
     // 인터페이스 네이밍 서비스
-    c.namingService = services.NewInterfaceNamingService(c.fileSystem, c.commandExecutor)
+    c.namingService = services.NewInterfaceNamingService(c.fileSystem, c.commandExecutor, adapters.NewLinkLister(c.commandExecutor))
 
     // 드리프트 디텍터 서비스
     c.driftDetector = services.NewDriftDetector(c.fileSystem, c.logger, c.namingService)
@@ -169,7 +191,7 @@ func (c *Container) initializeServices() error {
         c.fileSystem,
         c.logger,
         netOpts,
-    )
+    ).WithRoutingCoordinator(c.routingCoordinator)
 
 	return nil
 }
@@ -214,6 +236,22 @@ func (c *Container) initializeUseCases() error {
 		c.logger,
 	)
 
+	// 네트워크 검증 유스케이스 (읽기 전용)
+	inspector, err := c.networkFactory.CreateNetworkInspector()
+	if err != nil {
+		return err
+	}
+	c.verifyNetworkUseCase = usecases.NewVerifyNetworkUseCase(
+		c.repository,
+		inspector,
+		configurer,
+		c.namingService,
+		c.driftDetector,
+		c.fileSystem,
+		c.osDetector,
+		c.logger,
+	)
+
 	return nil
 }
 
@@ -243,6 +281,16 @@ func (c *Container) GetDeleteNetworkUseCase() *usecases.DeleteNetworkUseCase {
 	return c.deleteNetworkUseCase
 }
 
+// GetVerifyNetworkUseCase는 네트워크 검증 유스케이스를 반환합니다
+func (c *Container) GetVerifyNetworkUseCase() *usecases.VerifyNetworkUseCase {
+	return c.verifyNetworkUseCase
+}
+
+// GetDriftReporter는 verify 결과 보고자를 반환합니다 (보고 대상이 없으면 nil)
+func (c *Container) GetDriftReporter() interfaces.DriftReporter {
+	return c.driftReporter
+}
+
 // GetOSDetector는 OS 감지기를 반환합니다
 func (c *Container) GetOSDetector() interfaces.OSDetector {
 	return c.osDetector
@@ -0,0 +1,84 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+)
+
+// isIPv6Address reports whether addr (with or without prefix) is an IPv6 address.
+func isIPv6Address(addr string) bool {
+	addr = strings.TrimSpace(addr)
+	if i := strings.Index(addr, "/"); i >= 0 {
+		addr = addr[:i]
+	}
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() == nil
+}
+
+// ipArgs prefixes iproute2 arguments with the family selector for addr.
+// IPv4 keeps the flagless form so existing command lines stay unchanged; IPv6 needs "-6"
+// for rule/route commands where iproute2 does not infer the family.
+func ipArgs(addr string, args ...string) []string {
+	if isIPv6Address(addr) {
+		return append([]string{"-6"}, args...)
+	}
+	return args
+}
+
+// hostPrefix returns the single-host prefix for addr (/32 for IPv4, /128 for IPv6).
+func hostPrefix(addr string) string {
+	if isIPv6Address(addr) {
+		return fmt.Sprintf("%s/128", addr)
+	}
+	return fmt.Sprintf("%s/32", addr)
+}
+
+// ipFamilyName returns a human readable family label used in error messages.
+func ipFamilyName(addr string) string {
+	if isIPv6Address(addr) {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// addressesByFamily splits interface addresses into IPv4 and IPv6 groups, keeping spec order.
+func addressesByFamily(addrs []entities.InterfaceAddress) (v4, v6 []entities.InterfaceAddress) {
+	for _, a := range addrs {
+		if a.IsIPv6() {
+			v6 = append(v6, a)
+		} else {
+			v4 = append(v4, a)
+		}
+	}
+	return v4, v6
+}
+
+// connectedNetworks returns one address per distinct network, so that each connected
+// route is installed once even when several addresses (e.g. a VIP) share the subnet.
+func connectedNetworks(addrs []entities.InterfaceAddress) []entities.InterfaceAddress {
+	seen := map[string]bool{}
+	var out []entities.InterfaceAddress
+	for _, a := range addrs {
+		if seen[a.Network()] {
+			continue
+		}
+		seen[a.Network()] = true
+		out = append(out, a)
+	}
+	return out
+}
+
+// routesByFamily splits declared routes into IPv4 and IPv6 groups, keeping spec order.
+func routesByFamily(routes []entities.Route) (v4, v6 []entities.Route) {
+	for _, r := range routes {
+		if r.IsIPv6() {
+			v6 = append(v6, r)
+		} else {
+			v4 = append(v4, r)
+		}
+	}
+	return v4, v6
+}
@@ -0,0 +1,107 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+	"multinic-agent/internal/domain/interfaces"
+	"multinic-agent/internal/infrastructure/adapters"
+
+	"github.com/sirupsen/logrus"
+)
+
+// listLinks runs "ip -o link show" through run and parses it with the shared link parser
+func listLinks(ctx context.Context, run commandFunc) ([]interfaces.Link, error) {
+	out, err := run(ctx, "ip", "-o", "link", "show")
+	if err != nil {
+		return nil, err
+	}
+	return adapters.ParseLinks(out), nil
+}
+
+// findPortByMAC returns the physical port owning mac, skipping bond masters and stacked links
+// that share the same address.
+func findPortByMAC(links []interfaces.Link, mac string) (interfaces.Link, bool) {
+	for _, l := range links {
+		if l.IsPortFor(mac) {
+			return l, true
+		}
+	}
+	return interfaces.Link{}, false
+}
+
+// applyBond creates the bond master name (if missing), pins its MAC to the spec macAddress and
+// enslaves every member port. Members keep their kernel names; only the master gets a multinic name.
+func applyBond(ctx context.Context, run commandFunc, logger *logrus.Logger, iface entities.NetworkInterface, name string) error {
+	bond := iface.Bond()
+	links, err := listLinks(ctx, run)
+	if err != nil {
+		return errors.NewNetworkError("failed to list links for bond setup", err)
+	}
+
+	exists := false
+	for _, l := range links {
+		if l.Name == name {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		args := []string{"link", "add", "name", name, "type", "bond", "mode", bond.Mode(), "miimon", strconv.Itoa(bond.MIIMon())}
+		if bond.XmitHashPolicy() != "" {
+			args = append(args, "xmit_hash_policy", bond.XmitHashPolicy())
+		}
+		if _, err := run(ctx, "ip", args...); err != nil && !strings.Contains(err.Error(), "File exists") {
+			return errors.NewNetworkError(fmt.Sprintf("failed to create bond %s", name), err)
+		}
+	}
+
+	// A fixed bond MAC keeps MAC-based lookups (naming, validation) stable across member failover
+	if _, err := run(ctx, "ip", "link", "set", name, "address", strings.ToLower(iface.MacAddress())); err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to set bond %s MAC address", name), err)
+	}
+
+	for _, mac := range bond.Members() {
+		port, ok := findPortByMAC(links, mac)
+		if !ok {
+			return errors.NewNetworkError(fmt.Sprintf("bond member %s not found on system", mac), nil)
+		}
+		if port.Master == name {
+			continue
+		}
+		if port.Master != "" {
+			return errors.NewNetworkError(fmt.Sprintf("bond member %s (%s) is already enslaved to %s", mac, port.Name, port.Master), nil)
+		}
+		// the kernel only enslaves ports that are down
+		if _, err := run(ctx, "ip", "link", "set", port.Name, "down"); err != nil {
+			return errors.NewNetworkError(fmt.Sprintf("failed to set bond member %s down", port.Name), err)
+		}
+		if _, err := run(ctx, "ip", "link", "set", port.Name, "master", name); err != nil {
+			return errors.NewNetworkError(fmt.Sprintf("failed to enslave %s to %s", port.Name, name), err)
+		}
+		logger.WithFields(logrus.Fields{"bond": name, "member": port.Name, "mac": mac}).Info("Bond member enslaved")
+	}
+	return nil
+}
+
+// removeBondDevice deletes name when it is a bond master, which also releases its members.
+// Plain ethernet interfaces are left untouched.
+func removeBondDevice(ctx context.Context, run commandFunc, fs interfaces.FileSystem, logger *logrus.Logger, name string) {
+	if !fs.Exists(fmt.Sprintf("/sys/class/net/%s/bonding", name)) {
+		return
+	}
+	if _, err := run(ctx, "ip", "link", "delete", name); err != nil {
+		logger.WithError(err).WithField("bond", name).Warn("failed to delete bond device")
+		return
+	}
+	logger.WithField("bond", name).Info("Bond device removed")
+}
+
+// bondPortID returns the id used for the n-th member port in persisted configuration
+func bondPortID(bond string, n int) string {
+	return fmt.Sprintf("%s-port%d", bond, n)
+}
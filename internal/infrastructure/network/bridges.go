@@ -0,0 +1,71 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+	"multinic-agent/internal/domain/interfaces"
+
+	"github.com/sirupsen/logrus"
+)
+
+// applyBridge creates the Linux bridge declared on iface (if missing) and attaches port to it.
+// When the IP moves to the bridge, spec addresses left on the port by an earlier apply are
+// removed so that the same address is never configured on both devices.
+func applyBridge(ctx context.Context, run commandFunc, logger *logrus.Logger, iface entities.NetworkInterface, port string) error {
+	br := iface.Bridge()
+	name := br.Name()
+	if _, err := run(ctx, "ip", "link", "add", "name", name, "type", "bridge"); err != nil && !strings.Contains(err.Error(), "File exists") {
+		return errors.NewNetworkError(fmt.Sprintf("failed to create bridge %s", name), err)
+	}
+	stp := "0"
+	if br.STP() {
+		stp = "1"
+	}
+	if _, err := run(ctx, "ip", "link", "set", name, "type", "bridge", "stp_state", stp); err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to set STP on bridge %s", name), err)
+	}
+	if _, err := run(ctx, "ip", "link", "set", port, "master", name); err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to attach %s to bridge %s", port, name), err)
+	}
+	if br.MoveIP() {
+		for _, ad := range iface.Addresses() {
+			if _, err := run(ctx, "ip", ipArgs(ad.Address(), "addr", "del", ad.WithPrefix(), "dev", port)...); err != nil {
+				logger.WithError(err).WithFields(logrus.Fields{"interface": port, "address": ad.WithPrefix()}).Debug("address not on port (ignored)")
+			}
+		}
+	}
+	if _, err := run(ctx, "ip", "link", "set", name, "up"); err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to set bridge %s up", name), err)
+	}
+	logger.WithFields(logrus.Fields{"bridge": name, "port": port, "stp": br.STP(), "move_ip": br.MoveIP()}).Info("Bridge applied")
+	return nil
+}
+
+// removePortBridge deletes the bridge port is attached to and returns its name.
+// Only Linux bridges are removed; a bond master or an unattached port is left alone.
+func removePortBridge(ctx context.Context, run commandFunc, fs interfaces.FileSystem, logger *logrus.Logger, port string) string {
+	links, err := listLinks(ctx, run)
+	if err != nil {
+		return ""
+	}
+	for _, l := range links {
+		if l.Name != port || l.Master == "" {
+			continue
+		}
+		if !fs.Exists(fmt.Sprintf("/sys/class/net/%s/bridge", l.Master)) {
+			return ""
+		}
+		flushStaticRoutes(ctx, run, logger, l.Master)
+		if _, err := run(ctx, "ip", "link", "delete", l.Master); err != nil {
+			logger.WithError(err).WithField("bridge", l.Master).Warn("failed to delete bridge device")
+			return ""
+		}
+		logger.WithFields(logrus.Fields{"bridge": l.Master, "port": port}).Info("Bridge device removed")
+		return l.Master
+	}
+	return ""
+}
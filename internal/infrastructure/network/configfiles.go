@@ -0,0 +1,113 @@
+package network
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"multinic-agent/internal/domain/constants"
+	"multinic-agent/internal/domain/interfaces"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// removeStaleConfigFiles deletes files persisted for name under another index prefix than keep.
+// The prefix width follows the configured max interface count, so raising it (90- -> 900-)
+// would otherwise leave the old file behind and both would be loaded at boot.
+func removeStaleConfigFiles(fs interfaces.FileSystem, logger *logrus.Logger, dir, name, ext, keep string) {
+	files, err := fs.ListFiles(dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if f == keep {
+			continue
+		}
+		if n, ok := constants.ParseConfigFileName(f, ext); ok && n == name {
+			path := filepath.Join(dir, f)
+			if err := fs.Remove(path); err != nil {
+				logger.WithError(err).WithField("path", path).Debug("failed to remove stale config file (ignored)")
+				continue
+			}
+			logger.WithField("path", path).Info("Stale config file removed")
+		}
+	}
+}
+
+// persistedPolicyTables returns the policy routing tables referenced by the persisted files at
+// paths, using parse for their format; ok is false when none of the files exists.
+// Per-CR options only live as long as the Job that applied them, so a later Job (cleanup,
+// orphan removal) learns the table from what was written to disk.
+func persistedPolicyTables(fs interfaces.FileSystem, parse func([]byte) []int, paths ...string) (tables []int, ok bool) {
+	tables = []int{}
+	for _, path := range paths {
+		content, err := fs.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		ok = true
+		for _, t := range parse(content) {
+			tables = appendTable(tables, t)
+		}
+	}
+	return tables, ok
+}
+
+var nmRoutingRuleRe = regexp.MustCompile(`(?m)^routing-rule\d+=.*\btable (\d+)\s*$`)
+
+// nmPolicyTables returns the tables of the routing-ruleN keys of a NetworkManager keyfile
+func nmPolicyTables(content []byte) []int {
+	var tables []int
+	for _, m := range nmRoutingRuleRe.FindAllSubmatch(content, -1) {
+		if t, err := strconv.Atoi(string(m[1])); err == nil {
+			tables = appendTable(tables, t)
+		}
+	}
+	return tables
+}
+
+// netplanPolicyTables returns the tables of the routing-policy entries of a netplan file,
+// whichever device section (ethernet, bond, bridge) carries them
+func netplanPolicyTables(content []byte) []int {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+	var tables []int
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch n := v.(type) {
+		case map[string]interface{}:
+			for k, child := range n {
+				if k != "routing-policy" {
+					walk(child)
+					continue
+				}
+				policies, _ := child.([]interface{})
+				for _, p := range policies {
+					if m, ok := p.(map[string]interface{}); ok {
+						if t, ok := m["table"].(int); ok {
+							tables = appendTable(tables, t)
+						}
+					}
+				}
+			}
+		case []interface{}:
+			for _, child := range n {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+	return tables
+}
+
+func appendTable(tables []int, t int) []int {
+	for _, have := range tables {
+		if have == t {
+			return tables
+		}
+	}
+	return append(tables, t)
+}
@@ -0,0 +1,108 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// dhcpClient is the host DHCP client started through the adapter command path (nsenter in a
+// container). Persisted netplan/NetworkManager config takes over leases after a reboot.
+const dhcpClient = "dhclient"
+
+// dhcpFiles returns the pid and lease files of the client run for name; keyed by the multinic
+// name so that Rollback can stop it without knowing whether a bridge carried the address.
+func dhcpFiles(name string, ipv6 bool) (pid, lease string) {
+	suffix := ""
+	if ipv6 {
+		suffix = "-6"
+	}
+	return fmt.Sprintf("/run/multinic-dhclient-%s%s.pid", name, suffix), fmt.Sprintf("/run/multinic-dhclient-%s%s.lease", name, suffix)
+}
+
+// dhcpArgs builds dhclient arguments for one family; extra goes before the device
+func dhcpArgs(name, dev string, ipv6 bool, extra ...string) []string {
+	pid, lease := dhcpFiles(name, ipv6)
+	var args []string
+	if ipv6 {
+		args = append(args, "-6")
+	}
+	args = append(args, extra...)
+	return append(args, "-pf", pid, "-lf", lease, dev)
+}
+
+// applyDHCP runs the DHCP client on dev for every family in dhcp mode and checks that a lease
+// was obtained, and turns IPv6 off on dev when that family is disabled. Routes handed out by
+// DHCP are dropped from the main table: like the persisted config, only spec routes/gateway
+// are installed so a secondary network never replaces the node default route.
+func applyDHCP(ctx context.Context, run commandFunc, logger *logrus.Logger, iface entities.NetworkInterface, name, dev string) error {
+	if iface.IPv6Mode() == entities.AddressModeDisabled {
+		if _, err := run(ctx, "sysctl", "-w", fmt.Sprintf("net.ipv6.conf.%s.disable_ipv6=1", dev)); err != nil {
+			logger.WithError(err).WithField("interface", dev).Warn("failed to disable IPv6")
+		}
+	}
+	for _, ipv6 := range []bool{false, true} {
+		mode := iface.IPv4Mode()
+		if ipv6 {
+			mode = iface.IPv6Mode()
+		}
+		if mode != entities.AddressModeDHCP {
+			continue
+		}
+		anyAddr := "0.0.0.0"
+		if ipv6 {
+			anyAddr = "::"
+		}
+		family := ipFamilyName(anyAddr)
+		if ipv6 {
+			if _, err := run(ctx, "sysctl", "-w", fmt.Sprintf("net.ipv6.conf.%s.disable_ipv6=0", dev)); err != nil {
+				logger.WithError(err).WithField("interface", dev).Debug("failed to enable IPv6 (ignored)")
+			}
+		}
+		// restart the client so that a re-apply (e.g. after a MAC/bridge change) gets a fresh lease
+		if _, err := run(ctx, dhcpClient, dhcpArgs(name, dev, ipv6, "-r")...); err != nil {
+			logger.WithError(err).WithField("interface", dev).Debug("no previous DHCP client to release (ignored)")
+		}
+		if _, err := run(ctx, dhcpClient, dhcpArgs(name, dev, ipv6, "-1")...); err != nil {
+			return errors.NewNetworkError(fmt.Sprintf("%s DHCP on %s failed (is %s installed on the host?)", family, dev, dhcpClient), err)
+		}
+		if !hasDynamicAddress(ctx, run, dev, ipv6) {
+			return errors.NewNetworkError(fmt.Sprintf("no %s DHCP lease obtained on %s", family, dev), nil)
+		}
+		if _, err := run(ctx, "ip", ipArgs(anyAddr, "route", "del", "default", "dev", dev, "table", "main")...); err != nil {
+			logger.WithError(err).WithField("interface", dev).Debug("no DHCP default route to remove (ignored)")
+		}
+		logger.WithFields(logrus.Fields{"interface": dev, "family": family}).Info("DHCP lease obtained")
+	}
+	return nil
+}
+
+// hasDynamicAddress reports whether dev holds a global address of the family that was assigned
+// dynamically (DHCP lease or SLAAC)
+func hasDynamicAddress(ctx context.Context, run commandFunc, dev string, ipv6 bool) bool {
+	family := "-4"
+	if ipv6 {
+		family = "-6"
+	}
+	out, err := run(ctx, "ip", "-o", family, "addr", "show", "dev", dev, "scope", "global", "dynamic")
+	return err == nil && strings.TrimSpace(string(out)) != ""
+}
+
+// releaseDHCP stops the DHCP clients started for name and releases their leases (best effort)
+func releaseDHCP(ctx context.Context, run commandFunc, logger *logrus.Logger, name string) {
+	for _, ipv6 := range []bool{false, true} {
+		pid, lease := dhcpFiles(name, ipv6)
+		args := []string{"-r", "-pf", pid, "-lf", lease}
+		if ipv6 {
+			args = append([]string{"-6"}, args...)
+		}
+		if _, err := run(ctx, dhcpClient, args...); err != nil {
+			logger.WithError(err).WithField("interface", name).Debug("no DHCP client to stop (ignored)")
+		}
+	}
+}
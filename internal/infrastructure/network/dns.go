@@ -0,0 +1,79 @@
+package network
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"multinic-agent/internal/domain/entities"
+
+	"github.com/sirupsen/logrus"
+)
+
+// appliedDNS remembers the links this agent set resolver settings on, so that a spec without
+// dns only reverts links the agent changed itself.
+type appliedDNS struct {
+	mu    sync.Mutex
+	links map[string]bool
+}
+
+func (m *appliedDNS) set(dev string, applied bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.links == nil {
+		m.links = map[string]bool{}
+	}
+	if applied {
+		m.links[dev] = true
+	} else {
+		delete(m.links, dev)
+	}
+}
+
+func (m *appliedDNS) has(dev string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.links[dev]
+}
+
+// applyDNS registers the interface resolvers and search domains with systemd-resolved on dev.
+// Hosts without resolved (most RHEL images) only get the persisted netplan/NetworkManager
+// settings, so a failing resolvectl is logged and not treated as a configure error.
+func applyDNS(ctx context.Context, run commandFunc, logger *logrus.Logger, applied *appliedDNS, iface entities.NetworkInterface, dev string) {
+	dns := iface.DNS()
+	if dns == nil {
+		// drop link DNS left by an earlier spec of this run
+		if applied.has(dev) {
+			revertDNS(ctx, run, logger, dev)
+			applied.set(dev, false)
+		}
+		return
+	}
+	// resolvectl replaces the whole per-link list, so removed entries disappear as well
+	if _, err := run(ctx, "resolvectl", append([]string{"dns", dev}, dns.Servers()...)...); err != nil {
+		logger.WithError(err).WithField("interface", dev).Warn("systemd-resolved not available; DNS settings are persisted only")
+		return
+	}
+	applied.set(dev, true)
+	if _, err := run(ctx, "resolvectl", append([]string{"domain", dev}, dns.Search()...)...); err != nil {
+		logger.WithError(err).WithField("interface", dev).Warn("failed to set DNS search domains")
+		return
+	}
+	logger.WithFields(logrus.Fields{
+		"interface": dev,
+		"servers":   strings.Join(dns.Servers(), ","),
+		"search":    strings.Join(dns.Search(), ","),
+	}).Info("Interface DNS applied")
+}
+
+// revertDNS resets the per-link resolved settings of dev (best effort)
+func revertDNS(ctx context.Context, run commandFunc, logger *logrus.Logger, dev string) {
+	if _, err := run(ctx, "resolvectl", "revert", dev); err != nil {
+		logger.WithError(err).WithField("interface", dev).Debug("resolvectl revert skipped (ignored)")
+	}
+}
+
+// nmList renders a NetworkManager keyfile list value ("a;b;")
+func nmList(items []string) string {
+	return strings.Join(items, ";") + ";"
+}
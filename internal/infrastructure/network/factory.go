@@ -3,6 +3,7 @@ package network
 import (
 	"multinic-agent/internal/domain/errors"
 	"multinic-agent/internal/domain/interfaces"
+	"multinic-agent/internal/domain/services"
 
 	"github.com/sirupsen/logrus"
 )
@@ -14,6 +15,12 @@ type NetworkManagerFactory struct {
 	fileSystem      interfaces.FileSystem
 	logger          *logrus.Logger
 	opts            Options
+	routing         *services.RoutingCoordinator
+}
+
+// routingAware is implemented by adapters that serialize route changes through a shared lock
+type routingAware interface {
+	SetRoutingCoordinator(rc *services.RoutingCoordinator)
 }
 
 // NewNetworkManagerFactory creates a new NetworkManagerFactory
@@ -33,8 +40,25 @@ func NewNetworkManagerFactory(
 	}
 }
 
+// WithRoutingCoordinator makes every created adapter share the given routing lock
+func (f *NetworkManagerFactory) WithRoutingCoordinator(rc *services.RoutingCoordinator) *NetworkManagerFactory {
+	f.routing = rc
+	return f
+}
+
 // CreateNetworkConfigurer creates appropriate NetworkConfigurer based on OS
 func (f *NetworkManagerFactory) CreateNetworkConfigurer() (interfaces.NetworkConfigurer, error) {
+	configurer, err := f.createConfigurer()
+	if err != nil {
+		return nil, err
+	}
+	if ra, ok := configurer.(routingAware); ok && f.routing != nil {
+		ra.SetRoutingCoordinator(f.routing)
+	}
+	return configurer, nil
+}
+
+func (f *NetworkManagerFactory) createConfigurer() (interfaces.NetworkConfigurer, error) {
 	osType, err := f.osDetector.DetectOS()
 	if err != nil {
 		return nil, errors.NewSystemError("failed to detect OS", err)
@@ -79,3 +103,15 @@ func (f *NetworkManagerFactory) CreateNetworkRollbacker() (interfaces.NetworkRol
 
 	return nil, errors.NewSystemError("network manager does not support rollback functionality", nil)
 }
+
+// CreateNetworkInspector creates the read-only state reader used by the verify action
+func (f *NetworkManagerFactory) CreateNetworkInspector() (interfaces.NetworkInspector, error) {
+	configurer, err := f.createConfigurer()
+	if err != nil {
+		return nil, err
+	}
+	if inspector, ok := configurer.(interfaces.NetworkInspector); ok {
+		return inspector, nil
+	}
+	return nil, errors.NewSystemError("network manager does not support state inspection", nil)
+}
@@ -0,0 +1,88 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+	"multinic-agent/internal/domain/interfaces"
+	"multinic-agent/internal/infrastructure/adapters"
+)
+
+var (
+	linkMTURe  = regexp.MustCompile(`\smtu\s+(\d+)`)
+	addrInetRe = regexp.MustCompile(`\sinet6?\s+(\S+)`)
+)
+
+// inspectInterface reads the runtime state of iface configured as name for the verify action.
+// Only show commands are run: link flags/MTU of name, global addresses of the device carrying
+// the IP, and the rules and routes of the table the options assign to the interface.
+func inspectInterface(ctx context.Context, run commandFunc, opts Options, iface entities.NetworkInterface, name string) (*interfaces.InterfaceState, error) {
+	out, err := run(ctx, "ip", "-o", "link", "show", "dev", name)
+	if err != nil {
+		return nil, errors.NewNetworkError(fmt.Sprintf("failed to read link %s", name), err)
+	}
+	state := &interfaces.InterfaceState{}
+	state.Up, state.LowerUp, state.MTU = parseLinkState(string(out))
+
+	if out, err := run(ctx, "ip", "-o", "addr", "show", "dev", iface.AddressDevice(name), "scope", "global"); err == nil {
+		state.Addresses = parseGlobalAddresses(string(out))
+	}
+
+	opts = opts.forInterface(iface)
+	switch {
+	case iface.VRF() != nil:
+		state.PolicyTable = opts.vrfTable(iface, name)
+	case opts.policyRouting(iface):
+		state.PolicyTable = opts.routingTable(name)
+		state.Rules = showLines(ctx, run, "rule", "show", "table", strconv.Itoa(state.PolicyTable))
+	}
+	if state.PolicyTable > 0 {
+		state.Routes = showLines(ctx, run, "route", "show", "table", strconv.Itoa(state.PolicyTable))
+	}
+	return state, nil
+}
+
+// parseLinkState returns the UP/LOWER_UP flags and the MTU of one "ip -o link show dev" line
+func parseLinkState(out string) (up, lowerUp bool, mtu int) {
+	if links := adapters.ParseLinks([]byte(out)); len(links) > 0 {
+		up, lowerUp = links[0].HasFlag("UP"), links[0].HasFlag("LOWER_UP")
+	}
+	if m := linkMTURe.FindStringSubmatch(out); len(m) == 2 {
+		mtu, _ = strconv.Atoi(m[1])
+	}
+	return up, lowerUp, mtu
+}
+
+// parseGlobalAddresses returns the address/prefix of every line of "ip -o addr show"
+func parseGlobalAddresses(out string) []string {
+	var addrs []string
+	for _, line := range strings.Split(out, "\n") {
+		if m := addrInetRe.FindStringSubmatch(line); len(m) == 2 {
+			addrs = append(addrs, m[1])
+		}
+	}
+	return addrs
+}
+
+// showLines runs an "ip ... show" command for both families and returns its non-empty lines.
+// A failing family (e.g. IPv6 disabled on the host) just contributes nothing.
+func showLines(ctx context.Context, run commandFunc, args ...string) []string {
+	var lines []string
+	for _, family := range [][]string{nil, {"-6"}} {
+		out, err := run(ctx, "ip", append(append([]string{}, family...), args...)...)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
@@ -7,8 +7,8 @@ import (
 	"multinic-agent/internal/domain/entities"
 	"multinic-agent/internal/domain/errors"
 	"multinic-agent/internal/domain/interfaces"
+	"multinic-agent/internal/domain/services"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +23,9 @@ type NetplanAdapter struct {
 	logger          *logrus.Logger
 	configDir       string
 	opts            Options
+	routing         *services.RoutingCoordinator
+	applied         appliedOptions
+	dns             appliedDNS
 }
 
 // exec is a small helper wrapping command execution with a sensible timeout
@@ -53,6 +56,14 @@ func NewNetplanAdapterWithOptions(
 		logger:          logger,
 		configDir:       constants.NetplanConfigDir,
 		opts:            opts.normalize(),
+		routing:         services.NewRoutingCoordinator(logger),
+	}
+}
+
+// SetRoutingCoordinator shares a process-wide routing lock with the adapter
+func (a *NetplanAdapter) SetRoutingCoordinator(rc *services.RoutingCoordinator) {
+	if rc != nil {
+		a.routing = rc
 	}
 }
 
@@ -63,26 +74,17 @@ func (a *NetplanAdapter) GetConfigDir() string {
 
 // Configure configures a network interface
 func (a *NetplanAdapter) Configure(ctx context.Context, iface entities.NetworkInterface, name entities.InterfaceName) error {
-    // 1) Runtime apply via ip: rename/mtu/address/link-up
+    // 1) Runtime apply via ip: rename (or bond create)/mtu/address/link-up
     target := name.String()
-    curName, wasUp, found := a.findInterfaceByMAC(ctx, iface.MacAddress())
-    if !found || strings.TrimSpace(curName) == "" {
-        return errors.NewNetworkError("MAC not found on system for runtime apply", fmt.Errorf("mac=%s", iface.MacAddress()))
-    }
-
-    // Rename if needed (attempt without down first to reduce disruption; fallback to down)
-    if curName != target {
-        if _, err := a.exec(ctx, "ip", "link", "set", curName, "name", target); err != nil {
-            a.logger.WithFields(logrus.Fields{"from": curName, "to": target, "err": err}).Debug("rename without down failed; retry with down")
-            // bring down, rename, then restore up if previously up
-            _, _ = a.exec(ctx, "ip", "link", "set", curName, "down")
-            if _, err2 := a.exec(ctx, "ip", "link", "set", curName, "name", target); err2 != nil {
-                return errors.NewNetworkError("failed to rename interface", err2)
-            }
-            if wasUp {
-                _, _ = a.exec(ctx, "ip", "link", "set", target, "up")
-            }
+    // the node config may override the agent options for this interface
+    opts := a.opts.forInterface(iface)
+    a.applied.remember(target, opts)
+    if iface.IsBond() {
+        if err := applyBond(ctx, a.exec, a.logger, iface, target); err != nil {
+            return err
         }
+    } else if err := a.renameByMAC(ctx, iface.MacAddress(), target); err != nil {
+        return err
     }
 
     // MTU
@@ -92,20 +94,32 @@ func (a *NetplanAdapter) Configure(ctx context.Context, iface entities.NetworkIn
         }
     }
 
-    // IPv4
-    if addr := strings.TrimSpace(iface.Address()); addr != "" && strings.TrimSpace(iface.CIDR()) != "" {
-        parts := strings.Split(iface.CIDR(), "/")
-        if len(parts) == 2 {
-            full := fmt.Sprintf("%s/%s", addr, parts[1])
-            args := []string{"addr", "replace", full, "dev", target}
-            if a.opts.UseNoprefixroute {
-                args = append(args, "noprefixroute")
-            }
-            if _, err := a.exec(ctx, "ip", args...); err != nil {
-                return errors.NewNetworkError("failed to set IPv4 address", err)
-            }
-        } else {
-            a.logger.WithFields(logrus.Fields{"address": addr, "cidr": iface.CIDR()}).Warn("invalid CIDR; skipping ip addr replace")
+    // Bridge: the port is attached first so that moved addresses land on the bridge only
+    dev := iface.AddressDevice(target)
+    if iface.Bridge() != nil {
+        if err := applyBridge(ctx, a.exec, a.logger, iface, target); err != nil {
+            return err
+        }
+    }
+    // VRF: the address device joins its routing domain before it gets addresses
+    if v := iface.VRF(); v != nil {
+        if err := applyVRF(ctx, a.exec, a.logger, v.Name(), opts.vrfTable(iface, target), dev); err != nil {
+            return err
+        }
+    }
+
+    // Addresses: primary plus secondaries, IPv4/IPv6 (family is derived from each address)
+    for _, ad := range iface.Addresses() {
+        if ad.IsIPv6() {
+            // IPv6 may be disabled per-link on some images; addr replace fails otherwise
+            a.setSysctl(ctx, fmt.Sprintf("net.ipv6.conf.%s.disable_ipv6", dev), "0")
+        }
+        args := ipArgs(ad.Address(), "addr", "replace", ad.WithPrefix(), "dev", dev)
+        if opts.noPrefixRoute(iface) {
+            args = append(args, "noprefixroute")
+        }
+        if _, err := a.exec(ctx, "ip", args...); err != nil {
+            return errors.NewNetworkError(fmt.Sprintf("failed to set %s address %s", ipFamilyName(ad.Address()), ad.WithPrefix()), err)
         }
     }
 
@@ -113,30 +127,71 @@ func (a *NetplanAdapter) Configure(ctx context.Context, iface entities.NetworkIn
     if _, err := a.exec(ctx, "ip", "link", "set", target, "up"); err != nil {
         return errors.NewNetworkError("failed to set link up", err)
     }
-
-    // Policy routing per interface (keeps source-addressed traffic symmetric)
-    if a.opts.EnablePolicyRouting {
-        if err := a.applyPolicyRouting(ctx, iface, target); err != nil {
-            return err
+    // DHCP families: the lease lands on the address device once the link is up
+    if err := applyDHCP(ctx, a.exec, a.logger, iface, target, dev); err != nil {
+        return err
+    }
+    applyDNS(ctx, a.exec, a.logger, &a.dns, iface, dev)
+
+    // Policy routing (keeps source-addressed traffic symmetric) and spec routes, serialized
+    // with other route changes on the node
+    if err := a.routing.ExecuteWithLock(ctx, target, func(ctx context.Context) error {
+        if opts.policyRouting(iface) {
+            if err := a.applyPolicyRouting(ctx, opts, iface, target, dev); err != nil {
+                return err
+            }
         }
+        return applyStaticRoutes(ctx, a.exec, a.logger, opts, iface, target, dev)
+    }); err != nil {
+        return err
     }
     // Interface-specific sysctl hardening
-    a.applySysctls(ctx, target)
+    a.applySysctls(ctx, opts, dev)
+
+    // 802.1Q children ride on the renamed parent
+    if err := applyVLANs(ctx, a.exec, a.logger, iface, target); err != nil {
+        return err
+    }
 
     // 2) Persist via Netplan YAML (write-only, no apply)
     index := extractInterfaceIndex(target)
-    configPath := filepath.Join(a.configDir, fmt.Sprintf("9%d-%s.yaml", index, target))
+    configPath := filepath.Join(a.configDir, constants.ConfigFileName(index, target, ".yaml"))
     config := a.generateNetplanConfig(iface, target)
     data, err := yaml.Marshal(config)
     if err != nil { return errors.NewSystemError("failed to marshal Netplan configuration", err) }
     if err := a.fileSystem.WriteFile(configPath, data, 0600); err != nil {
         return errors.NewSystemError("failed to save Netplan configuration file", err)
     }
+    removeStaleConfigFiles(a.fileSystem, a.logger, a.configDir, target, ".yaml", filepath.Base(configPath))
     a.logger.WithFields(logrus.Fields{"interface": target, "config_path": configPath}).Info("Netplan configuration file created (persist-only)")
 
     return nil
 }
 
+// renameByMAC renames the device owning mac to target
+func (a *NetplanAdapter) renameByMAC(ctx context.Context, mac, target string) error {
+    curName, wasUp, found := a.findInterfaceByMAC(ctx, mac)
+    if !found || strings.TrimSpace(curName) == "" {
+        return errors.NewNetworkError("MAC not found on system for runtime apply", fmt.Errorf("mac=%s", mac))
+    }
+
+    // Rename if needed (attempt without down first to reduce disruption; fallback to down)
+    if curName != target {
+        if _, err := a.exec(ctx, "ip", "link", "set", curName, "name", target); err != nil {
+            a.logger.WithFields(logrus.Fields{"from": curName, "to": target, "err": err}).Debug("rename without down failed; retry with down")
+            // bring down, rename, then restore up if previously up
+            _, _ = a.exec(ctx, "ip", "link", "set", curName, "down")
+            if _, err2 := a.exec(ctx, "ip", "link", "set", curName, "name", target); err2 != nil {
+                return errors.NewNetworkError("failed to rename interface", err2)
+            }
+            if wasUp {
+                _, _ = a.exec(ctx, "ip", "link", "set", target, "up")
+            }
+        }
+    }
+    return nil
+}
+
 // Validate verifies that the configured interface is working properly
 func (a *NetplanAdapter) Validate(ctx context.Context, name entities.InterfaceName) error {
 	// Check if interface exists
@@ -157,7 +212,9 @@ func (a *NetplanAdapter) Validate(ctx context.Context, name entities.InterfaceNa
 // Rollback reverts the interface configuration to the previous state
 func (a *NetplanAdapter) Rollback(ctx context.Context, name string) error {
 	index := extractInterfaceIndex(name)
-	configPath := filepath.Join(a.configDir, fmt.Sprintf("9%d-%s.yaml", index, name))
+	configPath := filepath.Join(a.configDir, constants.ConfigFileName(index, name, ".yaml"))
+	// the file is the only record of per-CR routing options once their Job has exited
+	tables, persisted := persistedPolicyTables(a.fileSystem, netplanPolicyTables, configPath)
 
 	// Remove configuration file
 	if a.fileSystem.Exists(configPath) {
@@ -167,8 +224,19 @@ func (a *NetplanAdapter) Rollback(ctx context.Context, name string) error {
 	}
 
 	// Backup restore logic removed - simply remove configuration file
-
-    a.cleanupRouting(ctx, name)
+	removeStaleConfigFiles(a.fileSystem, a.logger, a.configDir, name, ".yaml", "")
+
+    releaseDHCP(ctx, a.exec, a.logger, name)
+    revertDNS(ctx, a.exec, a.logger, name)
+    a.dns.set(name, false)
+    // VLAN children are persisted in the parent file; drop their runtime links as well
+    a.removeChildVLANs(ctx, name)
+    // VRF and bridge live in the same file; drop them before the port they were built on
+    removeInterfaceVRF(ctx, a.exec, a.logger, name)
+    removePortBridge(ctx, a.exec, a.fileSystem, a.logger, name)
+    // a bond master only exists at runtime through us; deleting it releases the members
+    removeBondDevice(ctx, a.exec, a.fileSystem, a.logger, name)
+    a.cleanupRouting(ctx, name, a.applied.policyTables(name, tables, persisted, a.opts))
     a.logger.WithField("interface", name).Info("network configuration rollback completed")
     return nil
 }
@@ -189,112 +257,242 @@ func (a *NetplanAdapter) applyNetplan(ctx context.Context) error {
 
 // generateNetplanConfig generates Netplan configuration
 func (a *NetplanAdapter) generateNetplanConfig(iface entities.NetworkInterface, interfaceName string) map[string]interface{} {
-    ethernetConfig := map[string]interface{}{
-        "match": map[string]interface{}{
+    opts := a.opts.forInterface(iface)
+    ethernetConfig := map[string]interface{}{}
+    if !iface.IsBond() {
+        ethernetConfig["match"] = map[string]interface{}{
             "macaddress": iface.MacAddress(),
-        },
+        }
+        // Always include set-name for persistent rename on Ubuntu/Debian
+        ethernetConfig["set-name"] = interfaceName
     }
-    // Always include set-name for persistent rename on Ubuntu/Debian
-    ethernetConfig["set-name"] = interfaceName
-
-	// Static IP configuration: Both Address and CIDR must be present
-    if iface.Address() != "" && iface.CIDR() != "" {
-        // Extract prefix from CIDR (e.g., "10.0.0.0/24" -> "24")
-        parts := strings.Split(iface.CIDR(), "/")
-        if len(parts) == 2 {
-            prefix := parts[1]
-            fullAddress := fmt.Sprintf("%s/%s", iface.Address(), prefix)
-
-            ethernetConfig["dhcp4"] = false
-            ethernetConfig["addresses"] = []string{fullAddress}
-            if iface.MTU() > 0 {
-                ethernetConfig["mtu"] = iface.MTU()
+
+	// Static IP configuration: every declared address is persisted; DHCP families are declared
+	// with use-routes off so that the lease never replaces the node default route
+    addrs := iface.Addresses()
+    if len(addrs) > 0 || iface.UsesDHCP() {
+        _, v6 := addressesByFamily(addrs)
+        ethernetConfig["dhcp4"] = iface.IPv4Mode() == entities.AddressModeDHCP
+        if len(v6) > 0 || iface.IPv6Mode() == entities.AddressModeDHCP {
+            ethernetConfig["dhcp6"] = iface.IPv6Mode() == entities.AddressModeDHCP
+        }
+        for key, mode := range map[string]entities.AddressMode{"dhcp4-overrides": iface.IPv4Mode(), "dhcp6-overrides": iface.IPv6Mode()} {
+            if mode == entities.AddressModeDHCP {
+                ethernetConfig[key] = map[string]interface{}{"use-routes": false}
+            }
+        }
+        if len(addrs) > 0 {
+            addresses := make([]string, 0, len(addrs))
+            for _, ad := range addrs {
+                addresses = append(addresses, ad.WithPrefix())
             }
-            if a.opts.EnablePolicyRouting {
-                table := a.opts.routingTable(interfaceName)
-                metric := a.opts.routeMetric(interfaceName)
-                ethernetConfig["routes"] = []map[string]interface{}{
-                    {
-                        "to":     iface.CIDR(),
-                        "table":  table,
-                        "metric": metric,
-                    },
-                }
-                ethernetConfig["routing-policy"] = []map[string]interface{}{
-                    {
-                        "from":  fmt.Sprintf("%s/%s", iface.Address(), prefix),
-                        "table": table,
-                    },
-                }
+            ethernetConfig["addresses"] = addresses
+        }
+        if iface.MTU() > 0 {
+            ethernetConfig["mtu"] = iface.MTU()
+        }
+        if opts.policyRouting(iface) && len(addrs) > 0 {
+            table := opts.routingTable(interfaceName)
+            metric := opts.routeMetric(interfaceName)
+            routes := []map[string]interface{}{}
+            for _, ad := range connectedNetworks(addrs) {
+                routes = append(routes, map[string]interface{}{
+                    "to":     ad.Network(),
+                    "table":  table,
+                    "metric": metric,
+                })
             }
-        } else {
-            a.logger.WithFields(logrus.Fields{
-                "address": iface.Address(),
-                "cidr":    iface.CIDR(),
-            }).Warn("Invalid CIDR format, skipping IP configuration")
+            // one rule per source address
+            policies := []map[string]interface{}{}
+            for _, ad := range addrs {
+                policies = append(policies, map[string]interface{}{
+                    "from":  hostPrefix(ad.Address()),
+                    "table": table,
+                })
+            }
+            ethernetConfig["routes"] = routes
+            ethernetConfig["routing-policy"] = policies
         }
     }
+    if dns := iface.DNS(); dns != nil {
+        nameservers := map[string]interface{}{}
+        if servers := dns.Servers(); len(servers) > 0 {
+            nameservers["addresses"] = servers
+        }
+        if search := dns.Search(); len(search) > 0 {
+            nameservers["search"] = search
+        }
+        ethernetConfig["nameservers"] = nameservers
+    }
+    if iface.IPv6Mode() == entities.AddressModeDisabled {
+        // no SLAAC/link-local on a link whose IPv6 is turned off
+        ethernetConfig["accept-ra"] = false
+        ethernetConfig["link-local"] = []string{}
+    }
 
-	config := map[string]interface{}{
-		"network": map[string]interface{}{
-			"version": 2,
-			"ethernets": map[string]interface{}{
-				interfaceName: ethernetConfig,
-			},
+    // Spec routes and gateway (table defaults to the policy table when enabled, else main)
+    if declared := iface.EffectiveRoutes(); len(declared) > 0 {
+        routes, _ := ethernetConfig["routes"].([]map[string]interface{})
+        for _, r := range declared {
+            route := map[string]interface{}{
+                "to":     r.To(),
+                "metric": opts.staticRouteMetric(r, interfaceName),
+            }
+            if r.Via() != "" {
+                route["via"] = r.Via()
+            }
+            if table := opts.routeTable(iface, r, interfaceName); table > 0 {
+                route["table"] = table
+            }
+            if r.OnLink() {
+                route["on-link"] = true
+            }
+            routes = append(routes, route)
+        }
+        ethernetConfig["routes"] = routes
+    }
+
+	netCfg := map[string]interface{}{
+		"version": 2,
+		"ethernets": map[string]interface{}{
+			interfaceName: ethernetConfig,
 		},
 	}
+	if bond := iface.Bond(); bond != nil {
+		// Members are matched by MAC without set-name (they keep kernel names); the addressing
+		// built above belongs to the bond master
+		ports := map[string]interface{}{}
+		var portIDs []string
+		for i, mac := range bond.Members() {
+			id := bondPortID(interfaceName, i)
+			ports[id] = map[string]interface{}{
+				"match": map[string]interface{}{"macaddress": mac},
+			}
+			portIDs = append(portIDs, id)
+		}
+		params := map[string]interface{}{
+			"mode":                 bond.Mode(),
+			"mii-monitor-interval": bond.MIIMon(),
+		}
+		if bond.XmitHashPolicy() != "" {
+			params["transmit-hash-policy"] = bond.XmitHashPolicy()
+		}
+		ethernetConfig["interfaces"] = portIDs
+		ethernetConfig["parameters"] = params
+		ethernetConfig["macaddress"] = strings.ToLower(iface.MacAddress())
+		netCfg["ethernets"] = ports
+		netCfg["bonds"] = map[string]interface{}{interfaceName: ethernetConfig}
+	}
+	if br := iface.Bridge(); br != nil {
+		// the port (ethernet or bond) joins the bridge; with moveIP its L3 keys follow the address
+		bridgeConfig := map[string]interface{}{
+			"interfaces": []string{interfaceName},
+			"parameters": map[string]interface{}{"stp": br.STP()},
+		}
+		if br.MoveIP() {
+			for _, key := range []string{"dhcp4", "dhcp6", "dhcp4-overrides", "dhcp6-overrides", "accept-ra", "link-local", "addresses", "nameservers", "routes", "routing-policy"} {
+				if v, ok := ethernetConfig[key]; ok {
+					bridgeConfig[key] = v
+					delete(ethernetConfig, key)
+				}
+			}
+		}
+		netCfg["bridges"] = map[string]interface{}{br.Name(): bridgeConfig}
+	}
+	if v := iface.VRF(); v != nil {
+		netCfg["vrfs"] = map[string]interface{}{
+			v.Name(): map[string]interface{}{
+				"table":      opts.vrfTable(iface, interfaceName),
+				"interfaces": []string{iface.AddressDevice(interfaceName)},
+			},
+		}
+	}
+	if vlans := a.generateNetplanVLANs(iface, interfaceName); len(vlans) > 0 {
+		netCfg["vlans"] = vlans
+	}
+
+	return map[string]interface{}{"network": netCfg}
+}
 
-	return config
+// generateNetplanVLANs renders the "vlans:" section for the children of interfaceName
+func (a *NetplanAdapter) generateNetplanVLANs(iface entities.NetworkInterface, interfaceName string) map[string]interface{} {
+	vlans := map[string]interface{}{}
+	for _, v := range iface.VLANs() {
+		cfg := map[string]interface{}{
+			"id":   v.ID(),
+			"link": interfaceName,
+		}
+		if addrs := v.Addresses(); len(addrs) > 0 {
+			cfg["dhcp4"] = false
+			if addrs[0].IsIPv6() {
+				cfg["dhcp6"] = false
+			}
+			cfg["addresses"] = []string{addrs[0].WithPrefix()}
+		}
+		if v.MTU() > 0 {
+			cfg["mtu"] = v.MTU()
+		}
+		vlans[v.Name(interfaceName)] = cfg
+	}
+	return vlans
 }
 
-// applyPolicyRouting wires per-interface rule + route to keep traffic symmetric.
-func (a *NetplanAdapter) applyPolicyRouting(ctx context.Context, iface entities.NetworkInterface, target string) error {
-	addr := strings.TrimSpace(iface.Address())
-	cidr := strings.TrimSpace(iface.CIDR())
-	if addr == "" || cidr == "" {
+// applyPolicyRouting wires per-interface rules + routes to keep traffic symmetric.
+// The table is derived from the multinic name; routes point at dev, which is the bridge when
+// the addresses moved there.
+// Every address gets its own source rule; each connected network is routed once.
+func (a *NetplanAdapter) applyPolicyRouting(ctx context.Context, opts Options, iface entities.NetworkInterface, target, dev string) error {
+	addrs := iface.Addresses()
+	if len(addrs) == 0 {
 		return nil
 	}
-	table := a.opts.routingTable(target)
-	metric := a.opts.routeMetric(target)
+	table := opts.routingTable(target)
+	metric := opts.routeMetric(target)
+	networks := connectedNetworks(addrs)
 
 	// Remove main-table connected route if present to avoid ECMP within same CIDR.
-	if a.opts.UseNoprefixroute {
-		if _, err := a.exec(ctx, "ip", "route", "del", cidr, "dev", target); err != nil {
-			a.logger.WithError(err).WithFields(logrus.Fields{
-				"interface": target,
-				"cidr":      cidr,
-			}).Debug("ignored: failed to delete main-table route")
+	if opts.UseNoprefixroute {
+		for _, ad := range networks {
+			if _, err := a.exec(ctx, "ip", ipArgs(ad.Address(), "route", "del", ad.Network(), "dev", dev)...); err != nil {
+				a.logger.WithError(err).WithFields(logrus.Fields{
+					"interface": dev,
+					"cidr":      ad.Network(),
+				}).Debug("ignored: failed to delete main-table route")
+			}
 		}
 	}
 
 	// Refresh rule: delete if present, then add (replace is not supported on some iproute versions)
-	ruleArgs := []string{"rule", "del", "from", fmt.Sprintf("%s/32", addr), "table", fmt.Sprintf("%d", table)}
-	_, _ = a.exec(ctx, "ip", ruleArgs...)
-	addArgs := []string{"rule", "add", "from", fmt.Sprintf("%s/32", addr), "table", fmt.Sprintf("%d", table)}
-	if _, err := a.exec(ctx, "ip", addArgs...); err != nil {
-		// tolerate "File exists" to be idempotent
-		if !strings.Contains(err.Error(), "File exists") {
-			return errors.NewNetworkError("failed to install policy rule", err)
+	// IPv4 uses a /32 source selector, IPv6 a /128 one via "ip -6 rule"
+	for _, ad := range addrs {
+		addr := ad.Address()
+		ruleArgs := ipArgs(addr, "rule", "del", "from", hostPrefix(addr), "table", fmt.Sprintf("%d", table))
+		_, _ = a.exec(ctx, "ip", ruleArgs...)
+		addArgs := ipArgs(addr, "rule", "add", "from", hostPrefix(addr), "table", fmt.Sprintf("%d", table))
+		if _, err := a.exec(ctx, "ip", addArgs...); err != nil {
+			// tolerate "File exists" to be idempotent
+			if !strings.Contains(err.Error(), "File exists") {
+				return errors.NewNetworkError("failed to install policy rule", err)
+			}
 		}
 	}
 
-	args := []string{"route", "replace", cidr, "dev", target, "table", fmt.Sprintf("%d", table), "metric", fmt.Sprintf("%d", metric)}
-	if addr != "" {
-		args = append(args, "src", addr)
-	}
-	if _, err := a.exec(ctx, "ip", args...); err != nil {
-		return errors.NewNetworkError("failed to install policy route", err)
+	for _, ad := range networks {
+		args := ipArgs(ad.Address(), "route", "replace", ad.Network(), "dev", dev, "table", fmt.Sprintf("%d", table), "metric", fmt.Sprintf("%d", metric), "src", ad.Address())
+		if _, err := a.exec(ctx, "ip", args...); err != nil {
+			return errors.NewNetworkError("failed to install policy route", err)
+		}
 	}
 
 	return nil
 }
 
 // applySysctls tunes per-interface ARP/rp_filter to reduce ARP flux and strict RPF drops.
-func (a *NetplanAdapter) applySysctls(ctx context.Context, iface string) {
-	if a.opts.SetLooseRPFilter {
+func (a *NetplanAdapter) applySysctls(ctx context.Context, opts Options, iface string) {
+	if opts.SetLooseRPFilter {
 		a.setSysctl(ctx, fmt.Sprintf("net.ipv4.conf.%s.rp_filter", iface), "2")
 	}
-	if a.opts.SetArpSysctls {
+	if opts.SetArpSysctls {
 		a.setSysctl(ctx, fmt.Sprintf("net.ipv4.conf.%s.arp_ignore", iface), "1")
 		a.setSysctl(ctx, fmt.Sprintf("net.ipv4.conf.%s.arp_announce", iface), "2")
 	}
@@ -306,27 +504,64 @@ func (a *NetplanAdapter) setSysctl(ctx context.Context, key, value string) {
 	}
 }
 
-func (a *NetplanAdapter) cleanupRouting(ctx context.Context, name string) {
-	if !a.opts.EnablePolicyRouting {
-		return
+func (a *NetplanAdapter) cleanupRouting(ctx context.Context, name string, tables []int) {
+	flushStaticRoutes(ctx, a.exec, a.logger, name)
+	for _, table := range tables {
+		if _, err := a.exec(ctx, "ip", "rule", "delete", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to delete policy rule (ignored)")
+		}
+		if _, err := a.exec(ctx, "ip", "route", "flush", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to flush policy routes (ignored)")
+		}
+		// IPv6 rules/routes live in a separate family; the spec is unknown here so clean both
+		if _, err := a.exec(ctx, "ip", "-6", "rule", "delete", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to delete IPv6 policy rule (ignored)")
+		}
+		if _, err := a.exec(ctx, "ip", "-6", "route", "flush", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to flush IPv6 policy routes (ignored)")
+		}
 	}
-	table := a.opts.routingTable(name)
-	if _, err := a.exec(ctx, "ip", "rule", "delete", "table", fmt.Sprintf("%d", table)); err != nil {
-		a.logger.WithError(err).WithField("table", table).Debug("failed to delete policy rule (ignored)")
+}
+
+// Inspect reads the runtime state of the interface without changing anything (verify action)
+func (a *NetplanAdapter) Inspect(ctx context.Context, iface entities.NetworkInterface, name string) (*interfaces.InterfaceState, error) {
+	return inspectInterface(ctx, a.exec, a.opts, iface, name)
+}
+
+// ListVLANs returns the VLAN links currently stacked on parent
+func (a *NetplanAdapter) ListVLANs(ctx context.Context, parent string) ([]string, error) {
+	return listVLANLinks(ctx, a.exec, parent)
+}
+
+// RemoveVLAN deletes a VLAN link at runtime. Its netplan definition lives in the parent
+// file, which is rewritten from the spec on the next Configure.
+func (a *NetplanAdapter) RemoveVLAN(ctx context.Context, name string) error {
+	if err := deleteVLANLink(ctx, a.exec, name); err != nil {
+		return err
+	}
+	a.logger.WithField("vlan", name).Info("VLAN sub-interface removed")
+	return nil
+}
+
+// removeChildVLANs deletes every VLAN stacked on parent (best effort)
+func (a *NetplanAdapter) removeChildVLANs(ctx context.Context, parent string) {
+	children, err := listVLANLinks(ctx, a.exec, parent)
+	if err != nil {
+		a.logger.WithError(err).WithField("interface", parent).Debug("failed to list VLAN links (ignored)")
+		return
 	}
-	if _, err := a.exec(ctx, "ip", "route", "flush", "table", fmt.Sprintf("%d", table)); err != nil {
-		a.logger.WithError(err).WithField("table", table).Debug("failed to flush policy routes (ignored)")
+	for _, child := range children {
+		if err := a.RemoveVLAN(ctx, child); err != nil {
+			a.logger.WithError(err).WithField("vlan", child).Warn("failed to remove VLAN link")
+		}
 	}
 }
 
 // extractInterfaceIndex extracts the index from interface name
 func extractInterfaceIndex(name string) int {
 	// multinic0 -> 0, multinic1 -> 1 etc
-	if strings.HasPrefix(name, constants.InterfacePrefix) {
-		indexStr := strings.TrimPrefix(name, constants.InterfacePrefix)
-		if index, err := strconv.Atoi(indexStr); err == nil {
-			return index
-		}
+	if index, ok := constants.ParseInterfaceIndex(name); ok {
+		return index
 	}
 	return 0
 }
@@ -341,6 +576,7 @@ func (a *NetplanAdapter) findInterfaceByMAC(ctx context.Context, mac string) (na
             parts := strings.SplitN(line, ":", 3)
             if len(parts) >= 2 {
                 n := strings.TrimSpace(parts[1])
+                if isStackedLink(n) { continue }
                 isUp := strings.Contains(line, "state UP") || (strings.Contains(line, ",UP,") && strings.Contains(line, "LOWER_UP"))
                 return n, isUp, true
             }
@@ -8,6 +8,7 @@ import (
     "time"
 
     "github.com/sirupsen/logrus"
+    "gopkg.in/yaml.v3"
     "multinic-agent/internal/domain/entities"
 )
 
@@ -29,6 +30,10 @@ func (s *stubExec) ExecuteWithTimeout(ctx context.Context, _ time.Duration, cmd
         out := "2: ens7: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc fq_codel state UP mode DEFAULT group default qlen 1000\\tlink/ether fa:16:3e:11:4c:d1 brd ff:ff:ff:ff:ff:ff"
         return []byte(out), nil
     }
+    // leased address for the DHCP check (ip -o -4 addr show dev X scope global dynamic)
+    if cmd == "ip" && len(args) > 0 && args[len(args)-1] == "dynamic" {
+        return []byte("3: multinic0    inet 10.20.0.50/24 brd 10.20.0.255 scope global dynamic multinic0"), nil
+    }
     return []byte(""), nil
 }
 
@@ -104,9 +109,561 @@ func TestNetplanRollback_RemovesFileOnly(t *testing.T) {
     }
 }
 
+func TestNetplanConfigure_IPv6_AddressAndPolicyRouting(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, err := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "2001:db8:10::107", "2001:db8:10::/64", 1450)
+    if err != nil { t.Fatalf("new iface: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic1")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    want := map[string]bool{
+        "ip -6 addr replace 2001:db8:10::107/64 dev multinic1 noprefixroute": false,
+        "ip -6 rule add from 2001:db8:10::107/128 table 101":                  false,
+        "ip -6 route replace 2001:db8:10::/64 dev multinic1 table 101 metric 101 src 2001:db8:10::107": false,
+    }
+    for _, c := range exec.calls {
+        if _, ok := want[strings.Join(c, " ")]; ok { want[strings.Join(c, " ")] = true }
+        if len(c) > 3 && c[1] == "rule" && strings.Contains(strings.Join(c, " "), "/32") {
+            t.Fatalf("unexpected IPv4 rule for IPv6 address: %#v", c)
+        }
+    }
+    for cmd, seen := range want {
+        if !seen { t.Fatalf("expected command not executed: %s", cmd) }
+    }
+
+    b, err := fs.ReadFile("/etc/netplan/91-multinic1.yaml")
+    if err != nil { t.Fatalf("read cfg: %v", err) }
+    s := string(b)
+    for _, frag := range []string{"2001:db8:10::107/64", "dhcp6: false", "from: 2001:db8:10::107/128"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+}
+
+func TestNetplanConfigure_MultipleAddresses(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, err := entities.NewNetworkInterface(2, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err != nil { t.Fatalf("new iface: %v", err) }
+    if err := ni.AddAddress("11.11.11.200", "11.11.11.0/24"); err != nil { t.Fatalf("add vip: %v", err) }
+    if err := ni.AddAddress("2001:db8:11::107", "2001:db8:11::/64"); err != nil { t.Fatalf("add v6: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic2")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    var rules, routes []string
+    for _, c := range exec.calls {
+        line := strings.Join(c, " ")
+        if strings.Contains(line, " rule add ") { rules = append(rules, line) }
+        if strings.Contains(line, " route replace ") { routes = append(routes, line) }
+    }
+    // 주소마다 rule 1개, 네트워크마다 route 1개
+    if len(rules) != 3 { t.Fatalf("expected 3 policy rules, got %v", rules) }
+    if len(routes) != 2 { t.Fatalf("expected 2 table routes (one per network), got %v", routes) }
+
+    b, _ := fs.ReadFile("/etc/netplan/92-multinic2.yaml")
+    s := string(b)
+    for _, frag := range []string{"- 11.11.11.107/24", "- 11.11.11.200/24", "- 2001:db8:11::107/64", "from: 11.11.11.200/32"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+}
+
+func TestNetplanConfigure_StaticRoutesAndGateway(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, _ := entities.NewNetworkInterface(2, "fa:16:3e:11:4c:d1", "node", "192.168.192.10", "192.168.192.0/24", 1450)
+    _ = ni.AddRoute("10.20.0.0/16", "192.168.192.1", 0, 0, false)
+    _ = ni.AddRoute("172.16.0.0/24", "192.168.192.254", 50, 200, true)
+    _ = ni.SetGateway("192.168.192.1")
+    name, _ := entities.NewInterfaceName("multinic2")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    var flushIdx, routeIdx = -1, -1
+    want := map[string]bool{
+        "ip route replace 10.20.0.0/16 via 192.168.192.1 dev multinic2 table 102 metric 102 proto static":          false,
+        "ip route replace 172.16.0.0/24 via 192.168.192.254 dev multinic2 table 200 metric 50 proto static onlink": false,
+        "ip route replace default via 192.168.192.1 dev multinic2 table 102 metric 102 proto static":               false,
+    }
+    for i, c := range exec.calls {
+        line := strings.Join(c, " ")
+        if _, ok := want[line]; ok {
+            want[line] = true
+            if routeIdx < 0 { routeIdx = i }
+        }
+        if line == "ip route flush dev multinic2 proto static table all" { flushIdx = i }
+    }
+    for cmd, seen := range want {
+        if !seen { t.Fatalf("expected command not executed: %s", cmd) }
+    }
+    if flushIdx < 0 || flushIdx > routeIdx { t.Fatalf("stale static routes must be flushed before install (flush=%d route=%d)", flushIdx, routeIdx) }
+
+    b, _ := fs.ReadFile("/etc/netplan/92-multinic2.yaml")
+    s := string(b)
+    for _, frag := range []string{"to: 10.20.0.0/16", "via: 192.168.192.254", "on-link: true", "to: default", "table: 200"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+
+    // rollback removes spec routes too
+    exec.calls = nil
+    _ = adapter.Rollback(context.Background(), "multinic2")
+    flushed := false
+    for _, c := range exec.calls {
+        if strings.Join(c, " ") == "ip route flush dev multinic2 proto static table all" { flushed = true }
+    }
+    if !flushed { t.Fatalf("expected static route flush on rollback; calls=%v", exec.calls) }
+}
+
+// vlanStubExec reports VLAN links stacked on managed interfaces
+type vlanStubExec struct {
+    stubExec
+    vlans string
+}
+
+func (s *vlanStubExec) ExecuteWithTimeout(ctx context.Context, d time.Duration, cmd string, args ...string) ([]byte, error) {
+    if cmd == "ip" && strings.Join(args, " ") == "-o link show type vlan" {
+        s.calls = append(s.calls, append([]string{cmd}, args...))
+        return []byte(s.vlans), nil
+    }
+    return s.stubExec.ExecuteWithTimeout(ctx, d, cmd, args...)
+}
+
+func TestNetplanConfigure_VLANs(t *testing.T) {
+    exec := &vlanStubExec{vlans: "7: multinic0.100@multinic0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1400\n8: storage@multinic0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1450\n9: other.5@eth0: <BROADCAST> mtu 1500\n"}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    _ = ni.AddVLAN(100, "", "192.168.100.10", "192.168.100.0/24", 1400)
+    _ = ni.AddVLAN(200, "storage", "", "", 0)
+    name, _ := entities.NewInterfaceName("multinic0")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    want := map[string]bool{
+        "ip link add link multinic0 name multinic0.100 type vlan id 100": false,
+        "ip link set multinic0.100 mtu 1400":                               false,
+        "ip addr replace 192.168.100.10/24 dev multinic0.100":              false,
+        "ip link set multinic0.100 up":                                     false,
+        "ip link add link multinic0 name storage type vlan id 200":         false,
+        "ip link set storage up":                                           false,
+    }
+    for _, c := range exec.calls {
+        if _, ok := want[strings.Join(c, " ")]; ok { want[strings.Join(c, " ")] = true }
+    }
+    for cmd, seen := range want {
+        if !seen { t.Fatalf("expected command not executed: %s", cmd) }
+    }
+
+    b, _ := fs.ReadFile("/etc/netplan/90-multinic0.yaml")
+    s := string(b)
+    for _, frag := range []string{"vlans:", "multinic0.100:", "id: 100", "link: multinic0", "- 192.168.100.10/24", "mtu: 1400", "storage:", "id: 200"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+
+    // rollback of the parent deletes only its own VLAN links
+    exec.calls = nil
+    _ = adapter.Rollback(context.Background(), "multinic0")
+    deleted := map[string]bool{}
+    for _, c := range exec.calls {
+        if len(c) == 4 && c[0] == "ip" && c[1] == "link" && c[2] == "delete" { deleted[c[3]] = true }
+    }
+    if !deleted["multinic0.100"] || !deleted["storage"] || deleted["other.5"] {
+        t.Fatalf("unexpected VLAN deletions: %v", deleted)
+    }
+}
+
+// bondStubExec lists two free ports and one port already enslaved to the bond
+type bondStubExec struct {
+    stubExec
+    links string
+}
+
+func (s *bondStubExec) ExecuteWithTimeout(ctx context.Context, d time.Duration, cmd string, args ...string) ([]byte, error) {
+    if cmd == "ip" && strings.Join(args, " ") == "-o link show" {
+        s.calls = append(s.calls, append([]string{cmd}, args...))
+        return []byte(s.links), nil
+    }
+    return s.stubExec.ExecuteWithTimeout(ctx, d, cmd, args...)
+}
+
+func TestNetplanConfigure_Bond(t *testing.T) {
+    exec := &bondStubExec{links: "2: ens7: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 state UP    link/ether fa:16:3e:11:4c:d1 brd ff:ff:ff:ff:ff:ff\n" +
+        "3: ens8: <BROADCAST,MULTICAST> mtu 1500 state DOWN    link/ether fa:16:3e:11:4c:d2 brd ff:ff:ff:ff:ff:ff\n"}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 9000)
+    if err := ni.SetBond([]string{"fa:16:3e:11:4c:d1", "fa:16:3e:11:4c:d2"}, "802.3ad", 0, "layer3+4"); err != nil {
+        t.Fatalf("set bond: %v", err)
+    }
+    name, _ := entities.NewInterfaceName("multinic0")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    want := []string{
+        "ip link add name multinic0 type bond mode 802.3ad miimon 100 xmit_hash_policy layer3+4",
+        "ip link set multinic0 address fa:16:3e:11:4c:d1",
+        "ip link set ens7 down",
+        "ip link set ens7 master multinic0",
+        "ip link set ens8 down",
+        "ip link set ens8 master multinic0",
+    }
+    next := 0
+    for _, c := range exec.calls {
+        if next < len(want) && strings.Join(c, " ") == want[next] { next++ }
+        // member ports must never be renamed
+        if len(c) >= 4 && c[1] == "link" && c[2] == "set" && (c[3] == "ens7" || c[3] == "ens8") && len(c) >= 5 && c[4] == "name" {
+            t.Fatalf("bond member renamed: %v", c)
+        }
+    }
+    if next != len(want) {
+        t.Fatalf("expected command not executed in order: %s\ncalls: %v", want[next], exec.calls)
+    }
+
+    b, _ := fs.ReadFile("/etc/netplan/90-multinic0.yaml")
+    s := string(b)
+    for _, frag := range []string{"bonds:", "multinic0-port0:", "macaddress: fa:16:3e:11:4c:d2", "interfaces:", "mode: 802.3ad", "mii-monitor-interval: 100", "transmit-hash-policy: layer3+4", "- 11.11.11.107/24", "mtu: 9000"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+    if strings.Contains(s, "set-name") { t.Fatalf("bond ports must not use set-name:\n%s", s) }
+}
+
+func TestNetplanConfigure_BridgeMovesIP(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapterWithOptions(exec, fs, newTestLogger(), Options{EnablePolicyRouting: true})
+
+    ni, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    _ = ni.SetGateway("11.11.11.1")
+    if err := ni.SetBridge("br-vm", true, true); err != nil { t.Fatalf("set bridge: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic0")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    want := []string{
+        "ip link add name br-vm type bridge",
+        "ip link set br-vm type bridge stp_state 1",
+        "ip link set multinic0 master br-vm",
+        "ip addr del 11.11.11.107/24 dev multinic0",
+        "ip link set br-vm up",
+        "ip addr replace 11.11.11.107/24 dev br-vm",
+        "ip route replace 11.11.11.0/24 dev br-vm table 100 metric 100 src 11.11.11.107",
+        "ip route replace default via 11.11.11.1 dev br-vm table 100 metric 100 proto static",
+    }
+    next := 0
+    for _, c := range exec.calls {
+        if next < len(want) && strings.Join(c, " ") == want[next] { next++ }
+    }
+    if next != len(want) {
+        t.Fatalf("expected command not executed in order: %s\ncalls: %v", want[next], exec.calls)
+    }
+
+    b, _ := fs.ReadFile("/etc/netplan/90-multinic0.yaml")
+    var doc struct {
+        Network struct {
+            Ethernets map[string]map[string]interface{} `yaml:"ethernets"`
+            Bridges   map[string]map[string]interface{} `yaml:"bridges"`
+        } `yaml:"network"`
+    }
+    if err := yaml.Unmarshal(b, &doc); err != nil { t.Fatalf("yaml: %v", err) }
+    port := doc.Network.Ethernets["multinic0"]
+    br := doc.Network.Bridges["br-vm"]
+    if port == nil || br == nil { t.Fatalf("expected port and bridge entries:\n%s", b) }
+    if _, ok := port["addresses"]; ok { t.Fatalf("port must not keep addresses:\n%s", b) }
+    for _, key := range []string{"interfaces", "parameters", "addresses", "routes", "routing-policy"} {
+        if _, ok := br[key]; !ok { t.Fatalf("expected %q on bridge:\n%s", key, b) }
+    }
+    if !strings.Contains(string(b), "stp: true") { t.Fatalf("expected stp on bridge:\n%s", b) }
+}
+
+func TestNetplanConfigure_VRF(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapterWithOptions(exec, fs, newTestLogger(), DefaultOptions())
+
+    ni, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    _ = ni.SetGateway("11.11.11.1")
+    if err := ni.SetVRF("vrf-tenant", 0); err != nil { t.Fatalf("set vrf: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic2")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    want := []string{
+        "ip link add vrf-tenant type vrf table 102",
+        "ip link set vrf-tenant up",
+        "ip link set multinic2 master vrf-tenant",
+        // the connected route must reach the VRF table, so no noprefixroute
+        "ip addr replace 11.11.11.107/24 dev multinic2",
+        "ip route replace default via 11.11.11.1 dev multinic2 table 102 metric 102 proto static",
+    }
+    next := 0
+    for _, c := range exec.calls {
+        joined := strings.Join(c, " ")
+        if next < len(want) && joined == want[next] { next++ }
+        if len(c) > 2 && c[1] == "rule" { t.Fatalf("no source rules expected inside a VRF: %v", c) }
+    }
+    if next != len(want) {
+        t.Fatalf("expected command not executed in order: %s\ncalls: %v", want[next], exec.calls)
+    }
+
+    b, _ := fs.ReadFile("/etc/netplan/92-multinic2.yaml")
+    s := string(b)
+    for _, frag := range []string{"vrfs:", "vrf-tenant:", "table: 102", "- multinic2"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+    if strings.Contains(s, "routing-policy") { t.Fatalf("routing-policy not expected inside a VRF:\n%s", s) }
+}
+
+func TestNetplanConfigure_DHCP(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapterWithOptions(exec, fs, newTestLogger(), DefaultOptions())
+
+    ni, _ := entities.NewNetworkInterfaceWithoutAddress(0, "", "fa:16:3e:11:4c:d1", "node", 1450)
+    if err := ni.SetAddressModes("dhcp", "disabled"); err != nil { t.Fatalf("set modes: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic0")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    want := []string{
+        "sysctl -w net.ipv6.conf.multinic0.disable_ipv6=1",
+        "dhclient -r -pf /run/multinic-dhclient-multinic0.pid -lf /run/multinic-dhclient-multinic0.lease multinic0",
+        "dhclient -1 -pf /run/multinic-dhclient-multinic0.pid -lf /run/multinic-dhclient-multinic0.lease multinic0",
+        "ip -o -4 addr show dev multinic0 scope global dynamic",
+        "ip route del default dev multinic0 table main",
+    }
+    next := 0
+    for _, c := range exec.calls {
+        joined := strings.Join(c, " ")
+        if next < len(want) && joined == want[next] { next++ }
+        if len(c) > 2 && c[1] == "rule" { t.Fatalf("no source rules expected without static addresses: %v", c) }
+        if len(c) > 1 && c[0] == "dhclient" && c[1] == "-6" { t.Fatalf("no DHCPv6 expected when IPv6 is disabled: %v", c) }
+    }
+    if next != len(want) {
+        t.Fatalf("expected command not executed in order: %s\ncalls: %v", want[next], exec.calls)
+    }
+
+    b, _ := fs.ReadFile("/etc/netplan/90-multinic0.yaml")
+    s := string(b)
+    for _, frag := range []string{"dhcp4: true", "dhcp4-overrides:", "use-routes: false", "accept-ra: false", "mtu: 1450"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in netplan yaml, got:\n%s", frag, s) }
+    }
+    if strings.Contains(s, "addresses:") { t.Fatalf("no static addresses expected:\n%s", s) }
+
+    _ = adapter.Rollback(context.Background(), "multinic0")
+    released := false
+    for _, c := range exec.calls {
+        if strings.Join(c, " ") == "dhclient -r -pf /run/multinic-dhclient-multinic0.pid -lf /run/multinic-dhclient-multinic0.lease" { released = true }
+    }
+    if !released { t.Fatalf("expected DHCP client released on rollback: %v", exec.calls) }
+}
+
+func TestNetplanConfigure_DNS(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapterWithOptions(exec, fs, newTestLogger(), DefaultOptions())
+
+    ni, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err := ni.SetDNS([]string{"11.11.11.53", "2001:db8::53"}, []string{"storage.example.com"}); err != nil { t.Fatalf("set dns: %v", err) }
+    if err := ni.SetBridge("br-storage", false, true); err != nil { t.Fatalf("set bridge: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic0")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    // resolvers follow the address device
+    want := []string{
+        "resolvectl dns br-storage 11.11.11.53 2001:db8::53",
+        "resolvectl domain br-storage storage.example.com",
+    }
+    next := 0
+    for _, c := range exec.calls {
+        if next < len(want) && strings.Join(c, " ") == want[next] { next++ }
+    }
+    if next != len(want) {
+        t.Fatalf("expected command not executed in order: %s\ncalls: %v", want[next], exec.calls)
+    }
+
+    b, _ := fs.ReadFile("/etc/netplan/90-multinic0.yaml")
+    var doc struct {
+        Network struct {
+            Ethernets map[string]map[string]interface{} `yaml:"ethernets"`
+            Bridges   map[string]struct {
+                Nameservers struct {
+                    Addresses []string `yaml:"addresses"`
+                    Search    []string `yaml:"search"`
+                } `yaml:"nameservers"`
+            } `yaml:"bridges"`
+        } `yaml:"network"`
+    }
+    if err := yaml.Unmarshal(b, &doc); err != nil { t.Fatalf("unmarshal: %v", err) }
+    if _, ok := doc.Network.Ethernets["multinic0"]["nameservers"]; ok { t.Fatalf("nameservers should move to the bridge:\n%s", b) }
+    ns := doc.Network.Bridges["br-storage"].Nameservers
+    if strings.Join(ns.Addresses, ",") != "11.11.11.53,2001:db8::53" || strings.Join(ns.Search, ",") != "storage.example.com" {
+        t.Fatalf("unexpected nameservers in netplan yaml:\n%s", b)
+    }
+}
+
+// link DNS is only reverted on links the agent set it on
+func TestNetplanConfigure_DNSRevertedOnlyWhenApplied(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapterWithOptions(exec, fs, newTestLogger(), DefaultOptions())
+    name, _ := entities.NewInterfaceName("multinic0")
+    reverts := func() int {
+        n := 0
+        for _, c := range exec.calls {
+            if strings.Join(c, " ") == "resolvectl revert multinic0" { n++ }
+        }
+        return n
+    }
+
+    plain, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err := adapter.Configure(context.Background(), *plain, *name); err != nil { t.Fatalf("configure: %v", err) }
+    if n := reverts(); n != 0 { t.Fatalf("a link without agent DNS must not be reverted, got %d reverts", n) }
+
+    withDNS, _ := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err := withDNS.SetDNS([]string{"11.11.11.53"}, nil); err != nil { t.Fatalf("set dns: %v", err) }
+    if err := adapter.Configure(context.Background(), *withDNS, *name); err != nil { t.Fatalf("configure: %v", err) }
+    if err := adapter.Configure(context.Background(), *plain, *name); err != nil { t.Fatalf("configure: %v", err) }
+    if err := adapter.Configure(context.Background(), *plain, *name); err != nil { t.Fatalf("configure: %v", err) }
+    if n := reverts(); n != 1 { t.Fatalf("expected one revert after dns was removed from the spec, got %d", n) }
+}
+
 // minimal JSON logger without output
 func newTestLogger() *logrus.Logger {
     l := logrus.New()
     l.SetLevel(logrus.PanicLevel)
     return l
 }
+
+func TestNetplanConfigure_NodeConfigOptions(t *testing.T) {
+    exec := &stubExec{}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, err := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err != nil { t.Fatalf("new iface: %v", err) }
+    base, metric, off := 2000, 50, false
+    if err := ni.SetOptions(entities.NetworkOptions{RoutingTableBase: &base, RouteMetric: &metric, NoPrefixRoute: &off, LooseRPFilter: &off}); err != nil {
+        t.Fatalf("set options: %v", err)
+    }
+    name, _ := entities.NewInterfaceName("multinic1")
+
+    if err := adapter.Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+    want := map[string]bool{
+        "ip addr replace 11.11.11.107/24 dev multinic1":                                       false,
+        "ip rule add from 11.11.11.107/32 table 2001":                                         false,
+        "ip route replace 11.11.11.0/24 dev multinic1 table 2001 metric 51 src 11.11.11.107": false,
+    }
+    for _, c := range exec.calls {
+        line := strings.Join(c, " ")
+        if _, ok := want[line]; ok { want[line] = true }
+        if strings.Contains(line, "rp_filter") { t.Fatalf("rp_filter must be left alone when disabled by the node config: %s", line) }
+    }
+    for cmd, seen := range want {
+        if !seen { t.Fatalf("expected command not executed: %s", cmd) }
+    }
+    b, _ := fs.ReadFile("/etc/netplan/91-multinic1.yaml")
+    if !strings.Contains(string(b), "table: 2001") { t.Fatalf("expected table 2001 in netplan yaml, got:\n%s", b) }
+
+    // rollback of the same interface flushes the table it was configured with
+    exec.calls = nil
+    if err := adapter.Rollback(context.Background(), "multinic1"); err != nil { t.Fatalf("rollback: %v", err) }
+    flushed := false
+    for _, c := range exec.calls {
+        if strings.Join(c, " ") == "ip route flush table 2001" { flushed = true }
+    }
+    if !flushed { t.Fatalf("expected rollback to flush table 2001, got %v", exec.calls) }
+}
+
+// a cleanup Job runs a new agent: it has only the env options and learns the CR table from the file
+func TestNetplanRollback_FreshAdapterUsesPersistedTable(t *testing.T) {
+    fs := &memFS{files: map[string][]byte{}}
+    envOpts := DefaultOptions()
+    envOpts.EnablePolicyRouting = false
+    ni, err := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err != nil { t.Fatalf("new iface: %v", err) }
+    base, on := 2000, true
+    if err := ni.SetOptions(entities.NetworkOptions{RoutingTableBase: &base, PolicyRouting: &on}); err != nil { t.Fatalf("set options: %v", err) }
+    name, _ := entities.NewInterfaceName("multinic1")
+    if err := NewNetplanAdapterWithOptions(&stubExec{}, fs, newTestLogger(), envOpts).Configure(context.Background(), *ni, *name); err != nil {
+        t.Fatalf("configure: %v", err)
+    }
+
+    exec := &stubExec{}
+    if err := NewNetplanAdapterWithOptions(exec, fs, newTestLogger(), envOpts).Rollback(context.Background(), "multinic1"); err != nil {
+        t.Fatalf("rollback: %v", err)
+    }
+    want := map[string]bool{"ip rule delete table 2001": false, "ip route flush table 2001": false}
+    for _, c := range exec.calls {
+        if _, ok := want[strings.Join(c, " ")]; ok { want[strings.Join(c, " ")] = true }
+    }
+    for cmd, seen := range want {
+        if !seen { t.Fatalf("expected command not executed: %s (calls %v)", cmd, exec.calls) }
+    }
+}
+
+// inspectStubExec answers the read-only show commands of Inspect from a fixed table
+type inspectStubExec struct {
+    stubExec
+    out map[string]string
+}
+
+func (s *inspectStubExec) ExecuteWithTimeout(ctx context.Context, d time.Duration, cmd string, args ...string) ([]byte, error) {
+    s.calls = append(s.calls, append([]string{cmd}, args...))
+    return []byte(s.out[strings.Join(append([]string{cmd}, args...), " ")]), nil
+}
+
+func TestNetplanInspect_ReadsLinkAddressesAndPolicyTable(t *testing.T) {
+    exec := &inspectStubExec{out: map[string]string{
+        "ip -o link show dev multinic1":              "5: multinic1: <BROADCAST,MULTICAST,UP> mtu 1400 qdisc fq_codel state DOWN mode DEFAULT\\    link/ether fa:16:3e:11:4c:d1 brd ff:ff:ff:ff:ff:ff",
+        "ip -o addr show dev multinic1 scope global": "5: multinic1    inet 11.11.11.107/24 scope global multinic1\\       valid_lft forever preferred_lft forever",
+        "ip rule show table 101":                     "32765:\tfrom 11.11.11.107 lookup 101\n",
+        "ip route show table 101":                    "11.11.11.0/24 dev multinic1 scope link src 11.11.11.107 metric 101\n",
+    }}
+    fs := &memFS{files: map[string][]byte{}}
+    adapter := NewNetplanAdapter(exec, fs, newTestLogger())
+
+    ni, err := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err != nil { t.Fatalf("new iface: %v", err) }
+    state, err := adapter.Inspect(context.Background(), *ni, "multinic1")
+    if err != nil { t.Fatalf("inspect: %v", err) }
+
+    if !state.Up || state.LowerUp || state.MTU != 1400 { t.Fatalf("unexpected link state %+v", state) }
+    if len(state.Addresses) != 1 || state.Addresses[0] != "11.11.11.107/24" { t.Fatalf("unexpected addresses %v", state.Addresses) }
+    if state.PolicyTable != 101 || len(state.Rules) != 1 || len(state.Routes) != 1 { t.Fatalf("unexpected policy table state %+v", state) }
+    for _, c := range exec.calls {
+        line := strings.Join(c, " ")
+        if strings.Contains(line, " add ") || strings.Contains(line, " replace ") || strings.Contains(line, " del ") {
+            t.Fatalf("inspect must only read, ran: %s", line)
+        }
+    }
+}
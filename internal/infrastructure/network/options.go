@@ -1,5 +1,11 @@
 package network
 
+import (
+	"sync"
+
+	"multinic-agent/internal/domain/entities"
+)
+
 // Options controls how network adapters configure runtime and persistent state.
 // Defaults are tuned for stability when attaching multiple interfaces in the same CIDR.
 type Options struct {
@@ -45,3 +51,85 @@ func (o Options) routeMetric(name string) int {
 	idx := extractInterfaceIndex(name)
 	return o.RouteMetric + idx
 }
+
+// vrfTable returns the table bound to the interface VRF: the declared one, otherwise the
+// same per-index table the rule-based mode would use.
+func (o Options) vrfTable(iface entities.NetworkInterface, name string) int {
+	if v := iface.VRF(); v != nil && v.Table() > 0 {
+		return v.Table()
+	}
+	return o.routingTable(name)
+}
+
+// policyRouting reports whether source rules are installed for iface.
+// A VRF already isolates the interface through its l3mdev rule, so rules are skipped there.
+func (o Options) policyRouting(iface entities.NetworkInterface) bool {
+	return o.EnablePolicyRouting && iface.VRF() == nil
+}
+
+// noPrefixRoute reports whether addresses are added with noprefixroute. Inside a VRF the
+// kernel connected route is what lands in the VRF table, so it must be kept.
+func (o Options) noPrefixRoute(iface entities.NetworkInterface) bool {
+	return o.UseNoprefixroute && iface.VRF() == nil
+}
+
+// forInterface applies the options the node config sets for iface (spec.options and
+// interfaces[].options) on top of the agent-wide options.
+func (o Options) forInterface(iface entities.NetworkInterface) Options {
+	ov := iface.Options()
+	if ov == nil {
+		return o
+	}
+	if ov.PolicyRouting != nil {
+		o.EnablePolicyRouting = *ov.PolicyRouting
+	}
+	if ov.RoutingTableBase != nil {
+		o.RoutingTableBase = *ov.RoutingTableBase
+	}
+	if ov.RouteMetric != nil {
+		o.RouteMetric = *ov.RouteMetric
+	}
+	if ov.NoPrefixRoute != nil {
+		o.UseNoprefixroute = *ov.NoPrefixRoute
+	}
+	if ov.ArpSysctls != nil {
+		o.SetArpSysctls = *ov.ArpSysctls
+	}
+	if ov.LooseRPFilter != nil {
+		o.SetLooseRPFilter = *ov.LooseRPFilter
+	}
+	return o.normalize()
+}
+
+// appliedOptions remembers the options each interface was configured with, so that a
+// rollback, which only knows the interface name, removes the table it actually used.
+type appliedOptions struct {
+	mu     sync.Mutex
+	byName map[string]Options
+}
+
+func (m *appliedOptions) remember(name string, o Options) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.byName == nil {
+		m.byName = map[string]Options{}
+	}
+	m.byName[name] = o
+}
+
+// policyTables returns the policy routing tables to tear down for name: the ones recorded in
+// its persisted files plus the one it was configured with in this run. The agent options def
+// are only used when neither is known (found reports whether any persisted file was read).
+func (m *appliedOptions) policyTables(name string, persisted []int, found bool, def Options) []int {
+	m.mu.Lock()
+	o, ok := m.byName[name]
+	m.mu.Unlock()
+	tables := append([]int(nil), persisted...)
+	switch {
+	case ok && o.EnablePolicyRouting:
+		tables = appendTable(tables, o.routingTable(name))
+	case !ok && !found && def.EnablePolicyRouting:
+		tables = appendTable(tables, def.routingTable(name))
+	}
+	return tables
+}
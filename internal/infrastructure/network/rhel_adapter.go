@@ -7,11 +7,11 @@ import (
     "strings"
     "time"
 
-    "strconv"
     "multinic-agent/internal/domain/constants"
     "multinic-agent/internal/domain/entities"
     "multinic-agent/internal/domain/errors"
     "multinic-agent/internal/domain/interfaces"
+    "multinic-agent/internal/domain/services"
 
 	"github.com/sirupsen/logrus"
 )
@@ -24,6 +24,9 @@ type RHELAdapter struct {
 	isContainer            bool // indicates if running in container
 	enableSELinuxRestore   bool // whether to run restorecon on created files
 	opts                   Options
+	routing                *services.RoutingCoordinator
+	applied                appliedOptions
+	dns                    appliedDNS
 }
 
 // NewRHELAdapter creates a new RHELAdapter.
@@ -66,6 +69,14 @@ func NewRHELAdapterWithSELinux(
 		isContainer:          isContainer,
 		enableSELinuxRestore: enableSELinuxRestore,
 		opts:                 opts.normalize(),
+		routing:              services.NewRoutingCoordinator(logger),
+	}
+}
+
+// SetRoutingCoordinator shares a process-wide routing lock with the adapter
+func (a *RHELAdapter) SetRoutingCoordinator(rc *services.RoutingCoordinator) {
+	if rc != nil {
+		a.routing = rc
 	}
 }
 
@@ -89,13 +100,109 @@ func (a *RHELAdapter) execCommand(ctx context.Context, command string, args ...s
 func (a *RHELAdapter) Configure(ctx context.Context, iface entities.NetworkInterface, name entities.InterfaceName) error {
 	ifaceName := name.String()
     macAddress := iface.MacAddress()
+    // the node config may override the agent options for this interface
+    opts := a.opts.forInterface(iface)
+    a.applied.remember(ifaceName, opts)
 
 	a.logger.WithFields(logrus.Fields{
 		"interface": ifaceName,
 		"mac":       macAddress,
 	}).Info("Starting RHEL interface configuration with device rename approach")
 
-	// 1. Find the actual device name by MAC address
+	// 1-2. Bond masters are created from their members; plain ports are renamed by MAC
+	if iface.IsBond() {
+		if err := applyBond(ctx, a.execCommand, a.logger, iface, ifaceName); err != nil {
+			return err
+		}
+	} else if err := a.renameByMAC(ctx, macAddress, ifaceName); err != nil {
+		return err
+	}
+
+    // 3. Runtime MTU/bridge/IP (addresses live on the bridge when the IP moves there)
+    if iface.MTU() > 0 { if _, err := a.execCommand(ctx, "ip", "link", "set", ifaceName, "mtu", fmt.Sprintf("%d", iface.MTU())); err != nil { return errors.NewNetworkError("Failed to set MTU", err) } }
+    dev := iface.AddressDevice(ifaceName)
+    if br := iface.Bridge(); br != nil {
+        if err := applyBridge(ctx, a.execCommand, a.logger, iface, ifaceName); err != nil { return err }
+        if !br.MoveIP() && len(iface.Addresses()) > 0 {
+            a.logger.WithFields(logrus.Fields{"interface": ifaceName, "bridge": br.Name()}).Warn("addresses kept on a bridge port are runtime-only: NetworkManager does not persist IP settings on bridge ports")
+        }
+    }
+    if v := iface.VRF(); v != nil {
+        if err := applyVRF(ctx, a.execCommand, a.logger, v.Name(), opts.vrfTable(iface, ifaceName), dev); err != nil { return err }
+    }
+    for _, ad := range iface.Addresses() {
+        if ad.IsIPv6() {
+            a.setSysctl(ctx, fmt.Sprintf("net.ipv6.conf.%s.disable_ipv6", dev), "0")
+        }
+        args := ipArgs(ad.Address(), "addr", "replace", ad.WithPrefix(), "dev", dev)
+        if opts.noPrefixRoute(iface) {
+            args = append(args, "noprefixroute")
+        }
+        if _, err := a.execCommand(ctx, "ip", args...); err != nil { return errors.NewNetworkError(fmt.Sprintf("Failed to set %s %s", ipFamilyName(ad.Address()), ad.WithPrefix()), err) }
+    }
+    if _, err := a.execCommand(ctx, "ip", "link", "set", ifaceName, "up"); err != nil { return errors.NewNetworkError("Failed to set link up", err) }
+    if err := applyDHCP(ctx, a.execCommand, a.logger, iface, ifaceName, dev); err != nil { return err }
+    applyDNS(ctx, a.execCommand, a.logger, &a.dns, iface, dev)
+
+    // Policy routing + spec routes under the node-wide routing lock
+    if err := a.routing.ExecuteWithLock(ctx, ifaceName, func(ctx context.Context) error {
+        if opts.policyRouting(iface) {
+            if err := a.applyPolicyRouting(ctx, opts, iface, ifaceName, dev); err != nil { return err }
+        }
+        return applyStaticRoutes(ctx, a.execCommand, a.logger, opts, iface, ifaceName, dev)
+    }); err != nil { return err }
+    a.applySysctls(ctx, opts, dev)
+    if err := applyVLANs(ctx, a.execCommand, a.logger, iface, ifaceName); err != nil { return err }
+
+    // 4. Persist files: .link + .nmconnection with 9X prefix
+    idx := extractIndexRHEL(ifaceName)
+    // Ensure parent directories exist
+    _ = a.fileSystem.MkdirAll(constants.SystemdNetworkDir, 0755)
+    _ = a.fileSystem.MkdirAll(a.GetConfigDir(), 0755)
+    linkPath := filepath.Join(constants.SystemdNetworkDir, constants.ConfigFileName(idx, ifaceName, ".link"))
+    nmPath := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, ifaceName, ".nmconnection"))
+    if iface.IsBond() {
+        // no .link for bonds: matching the bond MAC would rename the member that owns it
+        if err := a.writeBondPorts(iface, ifaceName, idx); err != nil { return err }
+    } else {
+        linkContent := fmt.Sprintf("[Match]\nMACAddress=%s\n[Link]\nName=%s\n", strings.ToLower(macAddress), ifaceName)
+        if err := a.fileSystem.WriteFile(linkPath, []byte(linkContent), 0644); err != nil { return errors.NewSystemError("failed to write .link", err) }
+    }
+    nmContent := a.generateNMConnection(iface, ifaceName)
+    if err := a.fileSystem.WriteFile(nmPath, []byte(nmContent), 0600); err != nil { return errors.NewSystemError("failed to write .nmconnection", err) }
+    if v := iface.VRF(); v != nil {
+        vrfPath := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, v.Name(), ".nmconnection"))
+        if err := a.fileSystem.WriteFile(vrfPath, []byte(a.generateVRFConnection(iface, ifaceName)), 0600); err != nil {
+            return errors.NewSystemError(fmt.Sprintf("failed to write VRF .nmconnection for %s", v.Name()), err)
+        }
+    }
+    if br := iface.Bridge(); br != nil {
+        brPath := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, br.Name(), ".nmconnection"))
+        if err := a.fileSystem.WriteFile(brPath, []byte(a.generateBridgeConnection(iface, ifaceName)), 0600); err != nil {
+            return errors.NewSystemError(fmt.Sprintf("failed to write bridge .nmconnection for %s", br.Name()), err)
+        }
+    }
+    // VLAN children get their own type=vlan connection next to the parent
+    for _, v := range iface.VLANs() {
+        vlanName := v.Name(ifaceName)
+        vlanPath := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, vlanName, ".nmconnection"))
+        if err := a.fileSystem.WriteFile(vlanPath, []byte(a.generateVLANConnection(v, ifaceName)), 0600); err != nil {
+            return errors.NewSystemError(fmt.Sprintf("failed to write VLAN .nmconnection for %s", vlanName), err)
+        }
+    }
+    removeStaleConfigFiles(a.fileSystem, a.logger, constants.SystemdNetworkDir, ifaceName, ".link", filepath.Base(linkPath))
+    removeStaleConfigFiles(a.fileSystem, a.logger, a.GetConfigDir(), ifaceName, ".nmconnection", filepath.Base(nmPath))
+    a.logger.WithFields(logrus.Fields{"link": linkPath, "nmconnection": nmPath}).Info("RHEL persist files written (no immediate reload)")
+    
+    // 5. Optional SELinux context restoration
+    a.restoreSELinuxContext(ctx)
+    
+    return nil
+}
+
+// renameByMAC renames the device owning macAddress to ifaceName
+func (a *RHELAdapter) renameByMAC(ctx context.Context, macAddress, ifaceName string) error {
+	// Find the actual device name by MAC address
 	actualDevice, err := a.findDeviceByMAC(ctx, macAddress)
 	if err != nil {
 		return errors.NewNetworkError(fmt.Sprintf("Failed to find device with MAC %s", macAddress), err)
@@ -107,7 +214,7 @@ func (a *RHELAdapter) Configure(ctx context.Context, iface entities.NetworkInter
 		"mac":           macAddress,
 	}).Debug("Found actual device for MAC address")
 
-    // 2. Check if device name needs to be changed
+    // Check if device name needs to be changed
     if actualDevice != ifaceName {
 		a.logger.WithFields(logrus.Fields{
 			"from": actualDevice,
@@ -125,44 +232,7 @@ func (a *RHELAdapter) Configure(ctx context.Context, iface entities.NetworkInter
 
 		a.logger.WithField("interface", ifaceName).Info("Interface renamed successfully")
 	}
-
-    // 3. Runtime MTU/IP
-    if iface.MTU() > 0 { if _, err := a.execCommand(ctx, "ip", "link", "set", ifaceName, "mtu", fmt.Sprintf("%d", iface.MTU())); err != nil { return errors.NewNetworkError("Failed to set MTU", err) } }
-    if addr := strings.TrimSpace(iface.Address()); addr != "" && strings.TrimSpace(iface.CIDR()) != "" {
-        parts := strings.Split(iface.CIDR(), "/"); if len(parts) == 2 {
-            full := fmt.Sprintf("%s/%s", addr, parts[1])
-            args := []string{"addr", "replace", full, "dev", ifaceName}
-            if a.opts.UseNoprefixroute {
-                args = append(args, "noprefixroute")
-            }
-            if _, err := a.execCommand(ctx, "ip", args...); err != nil { return errors.NewNetworkError("Failed to set IPv4", err) }
-        }
-    }
-    if _, err := a.execCommand(ctx, "ip", "link", "set", ifaceName, "up"); err != nil { return errors.NewNetworkError("Failed to set link up", err) }
-
-    // Policy routing per interface (keeps source-addressed traffic symmetric)
-    if a.opts.EnablePolicyRouting {
-        if err := a.applyPolicyRouting(ctx, iface, ifaceName); err != nil { return err }
-    }
-    a.applySysctls(ctx, ifaceName)
-
-    // 4. Persist files: .link + .nmconnection with 9X prefix
-    idx := extractIndexRHEL(ifaceName)
-    // Ensure parent directories exist
-    _ = a.fileSystem.MkdirAll(constants.SystemdNetworkDir, 0755)
-    _ = a.fileSystem.MkdirAll(a.GetConfigDir(), 0755)
-    linkPath := filepath.Join(constants.SystemdNetworkDir, fmt.Sprintf("9%d-%s.link", idx, ifaceName))
-    nmPath := filepath.Join(a.GetConfigDir(), fmt.Sprintf("9%d-%s.nmconnection", idx, ifaceName))
-    linkContent := fmt.Sprintf("[Match]\nMACAddress=%s\n[Link]\nName=%s\n", strings.ToLower(macAddress), ifaceName)
-    if err := a.fileSystem.WriteFile(linkPath, []byte(linkContent), 0644); err != nil { return errors.NewSystemError("failed to write .link", err) }
-    nmContent := a.generateNMConnection(iface, ifaceName)
-    if err := a.fileSystem.WriteFile(nmPath, []byte(nmContent), 0600); err != nil { return errors.NewSystemError("failed to write .nmconnection", err) }
-    a.logger.WithFields(logrus.Fields{"link": linkPath, "nmconnection": nmPath}).Info("RHEL persist files written (no immediate reload)")
-    
-    // 5. Optional SELinux context restoration
-    a.restoreSELinuxContext(ctx)
-    
-    return nil
+	return nil
 }
 
 // Validate verifies that the configured interface exists.
@@ -178,9 +248,13 @@ func (a *RHELAdapter) Validate(ctx context.Context, name entities.InterfaceName)
 
     // Check if persist files exist
     idx := extractIndexRHEL(ifaceName)
-    linkPath := filepath.Join("/etc/systemd/network", fmt.Sprintf("9%d-%s.link", idx, ifaceName))
-    nmPath := filepath.Join(a.GetConfigDir(), fmt.Sprintf("9%d-%s.nmconnection", idx, ifaceName))
-    if !a.fileSystem.Exists(linkPath) || !a.fileSystem.Exists(nmPath) {
+    linkPath := filepath.Join("/etc/systemd/network", constants.ConfigFileName(idx, ifaceName, ".link"))
+    nmPath := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, ifaceName, ".nmconnection"))
+    if !a.fileSystem.Exists(nmPath) {
+        return errors.NewNetworkError("persist files not found", nil)
+    }
+    // bond masters are persisted without a .link file
+    if !a.fileSystem.Exists(linkPath) && !a.isBondConnection(nmPath) {
         return errors.NewNetworkError("persist files not found", nil)
     }
 
@@ -197,15 +271,38 @@ func (a *RHELAdapter) Rollback(ctx context.Context, name string) error {
 	a.logger.WithField("interface", name).Info("Starting RHEL interface rollback/deletion")
 
     idx := extractIndexRHEL(name)
-    linkPath := filepath.Join("/etc/systemd/network", fmt.Sprintf("9%d-%s.link", idx, name))
-    nmPath := filepath.Join(a.GetConfigDir(), fmt.Sprintf("9%d-%s.nmconnection", idx, name))
+    linkPath := filepath.Join("/etc/systemd/network", constants.ConfigFileName(idx, name, ".link"))
+    nmPath := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, name, ".nmconnection"))
+    // bridge/VRF keyfiles are found through the master= keys, so read them before removal
+    var masterPaths []string
+    for _, master := range a.connectionMasters(idx, nmPath) {
+        masterPaths = append(masterPaths, filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, master, ".nmconnection")))
+    }
+    // the keyfiles are the only record of per-CR routing options once their Job has exited;
+    // the rules sit on the bridge keyfile when the addresses moved there
+    tables, persisted := persistedPolicyTables(a.fileSystem, nmPolicyTables, append([]string{nmPath}, masterPaths...)...)
+    for _, masterPath := range masterPaths {
+        if err := a.fileSystem.Remove(masterPath); err != nil {
+            a.logger.WithError(err).WithField("nm", masterPath).Debug("Error removing master .nmconnection (ignored)")
+        }
+    }
     if err := a.fileSystem.Remove(linkPath); err != nil {
         a.logger.WithError(err).WithField("link", linkPath).Debug("Error removing .link (ignored)")
     }
     if err := a.fileSystem.Remove(nmPath); err != nil {
         a.logger.WithError(err).WithField("nm", nmPath).Debug("Error removing .nmconnection (ignored)")
     }
-    a.cleanupRouting(ctx, name)
+    removeStaleConfigFiles(a.fileSystem, a.logger, constants.SystemdNetworkDir, name, ".link", "")
+    removeStaleConfigFiles(a.fileSystem, a.logger, a.GetConfigDir(), name, ".nmconnection", "")
+    releaseDHCP(ctx, a.execCommand, a.logger, name)
+    revertDNS(ctx, a.execCommand, a.logger, name)
+    a.dns.set(name, false)
+    a.removeChildVLANs(ctx, name)
+    a.removeBondPorts(name, idx)
+    removeInterfaceVRF(ctx, a.execCommand, a.logger, name)
+    removePortBridge(ctx, a.execCommand, a.fileSystem, a.logger, name)
+    removeBondDevice(ctx, a.execCommand, a.fileSystem, a.logger, name)
+    a.cleanupRouting(ctx, name, a.applied.policyTables(name, tables, persisted, a.opts))
     a.logger.WithField("interface", name).Info("RHEL interface rollback (files removed; no immediate reload)")
     return nil
 }
@@ -238,6 +335,10 @@ func (a *RHELAdapter) findDeviceByMAC(ctx context.Context, macAddress string) (s
 			parts := strings.Split(line, ":")
 			if len(parts) >= 2 {
 				currentDevice = strings.TrimSpace(parts[1])
+				// VLANs share the parent MAC ("multinic0.100@multinic0"); never match them
+				if isStackedLink(currentDevice) {
+					currentDevice = ""
+				}
 			}
 		} else if strings.Contains(line, "link/ether") && currentDevice != "" {
 			// This line contains MAC address
@@ -261,48 +362,54 @@ func (a *RHELAdapter) findDeviceByMAC(ctx context.Context, macAddress string) (s
 	return "", fmt.Errorf("no device found with MAC address %s", macAddress)
 }
 
-// applyPolicyRouting wires per-interface rule + route to keep traffic symmetric.
-func (a *RHELAdapter) applyPolicyRouting(ctx context.Context, iface entities.NetworkInterface, ifaceName string) error {
-	addr := strings.TrimSpace(iface.Address())
-	cidr := strings.TrimSpace(iface.CIDR())
-	if addr == "" || cidr == "" {
+// applyPolicyRouting wires per-interface rules + routes to keep traffic symmetric.
+// The table is derived from the multinic name; routes point at dev, which is the bridge when
+// the addresses moved there.
+func (a *RHELAdapter) applyPolicyRouting(ctx context.Context, opts Options, iface entities.NetworkInterface, ifaceName, dev string) error {
+	addrs := iface.Addresses()
+	if len(addrs) == 0 {
 		return nil
 	}
-	table := a.opts.routingTable(ifaceName)
-	metric := a.opts.routeMetric(ifaceName)
-
-	if a.opts.UseNoprefixroute {
-		if _, err := a.execCommand(ctx, "ip", "route", "del", cidr, "dev", ifaceName); err != nil {
-			a.logger.WithError(err).WithFields(logrus.Fields{"interface": ifaceName, "cidr": cidr}).Debug("ignored: failed to delete main-table route")
+	table := opts.routingTable(ifaceName)
+	metric := opts.routeMetric(ifaceName)
+	networks := connectedNetworks(addrs)
+
+	if opts.UseNoprefixroute {
+		for _, ad := range networks {
+			if _, err := a.execCommand(ctx, "ip", ipArgs(ad.Address(), "route", "del", ad.Network(), "dev", dev)...); err != nil {
+				a.logger.WithError(err).WithFields(logrus.Fields{"interface": dev, "cidr": ad.Network()}).Debug("ignored: failed to delete main-table route")
+			}
 		}
 	}
 
-	// Refresh rule: delete then add (replace may be unsupported on some versions)
-	_, _ = a.execCommand(ctx, "ip", "rule", "del", "from", fmt.Sprintf("%s/32", addr), "table", fmt.Sprintf("%d", table))
-	if _, err := a.execCommand(ctx, "ip", "rule", "add", "from", fmt.Sprintf("%s/32", addr), "table", fmt.Sprintf("%d", table)); err != nil {
-		// tolerate File exists to stay idempotent
-		if !strings.Contains(err.Error(), "File exists") {
-			return errors.NewNetworkError("failed to install policy rule", err)
+	// Refresh rule per source address: delete then add (replace may be unsupported on some versions)
+	for _, ad := range addrs {
+		addr := ad.Address()
+		_, _ = a.execCommand(ctx, "ip", ipArgs(addr, "rule", "del", "from", hostPrefix(addr), "table", fmt.Sprintf("%d", table))...)
+		if _, err := a.execCommand(ctx, "ip", ipArgs(addr, "rule", "add", "from", hostPrefix(addr), "table", fmt.Sprintf("%d", table))...); err != nil {
+			// tolerate File exists to stay idempotent
+			if !strings.Contains(err.Error(), "File exists") {
+				return errors.NewNetworkError("failed to install policy rule", err)
+			}
 		}
 	}
 
-	args := []string{"route", "replace", cidr, "dev", ifaceName, "table", fmt.Sprintf("%d", table), "metric", fmt.Sprintf("%d", metric)}
-	if addr != "" {
-		args = append(args, "src", addr)
-	}
-	if _, err := a.execCommand(ctx, "ip", args...); err != nil {
-		return errors.NewNetworkError("failed to install policy route", err)
+	for _, ad := range networks {
+		args := ipArgs(ad.Address(), "route", "replace", ad.Network(), "dev", dev, "table", fmt.Sprintf("%d", table), "metric", fmt.Sprintf("%d", metric), "src", ad.Address())
+		if _, err := a.execCommand(ctx, "ip", args...); err != nil {
+			return errors.NewNetworkError("failed to install policy route", err)
+		}
 	}
 
 	return nil
 }
 
 // applySysctls tunes per-interface ARP/rp_filter to reduce ARP flux and strict RPF drops.
-func (a *RHELAdapter) applySysctls(ctx context.Context, iface string) {
-	if a.opts.SetLooseRPFilter {
+func (a *RHELAdapter) applySysctls(ctx context.Context, opts Options, iface string) {
+	if opts.SetLooseRPFilter {
 		a.setSysctl(ctx, fmt.Sprintf("net.ipv4.conf.%s.rp_filter", iface), "2")
 	}
-	if a.opts.SetArpSysctls {
+	if opts.SetArpSysctls {
 		a.setSysctl(ctx, fmt.Sprintf("net.ipv4.conf.%s.arp_ignore", iface), "1")
 		a.setSysctl(ctx, fmt.Sprintf("net.ipv4.conf.%s.arp_announce", iface), "2")
 	}
@@ -314,51 +421,323 @@ func (a *RHELAdapter) setSysctl(ctx context.Context, key, value string) {
 	}
 }
 
-func (a *RHELAdapter) cleanupRouting(ctx context.Context, ifaceName string) {
-	if !a.opts.EnablePolicyRouting {
-		return
-	}
-	table := a.opts.routingTable(ifaceName)
-	if _, err := a.execCommand(ctx, "ip", "rule", "delete", "table", fmt.Sprintf("%d", table)); err != nil {
-		a.logger.WithError(err).WithField("table", table).Debug("failed to delete policy rule (ignored)")
-	}
-	if _, err := a.execCommand(ctx, "ip", "route", "flush", "table", fmt.Sprintf("%d", table)); err != nil {
-		a.logger.WithError(err).WithField("table", table).Debug("failed to flush policy routes (ignored)")
+func (a *RHELAdapter) cleanupRouting(ctx context.Context, ifaceName string, tables []int) {
+	flushStaticRoutes(ctx, a.execCommand, a.logger, ifaceName)
+	for _, table := range tables {
+		if _, err := a.execCommand(ctx, "ip", "rule", "delete", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to delete policy rule (ignored)")
+		}
+		if _, err := a.execCommand(ctx, "ip", "route", "flush", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to flush policy routes (ignored)")
+		}
+		if _, err := a.execCommand(ctx, "ip", "-6", "rule", "delete", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to delete IPv6 policy rule (ignored)")
+		}
+		if _, err := a.execCommand(ctx, "ip", "-6", "route", "flush", "table", fmt.Sprintf("%d", table)); err != nil {
+			a.logger.WithError(err).WithField("table", table).Debug("failed to flush IPv6 policy routes (ignored)")
+		}
 	}
 }
 
-// generateIfcfgContent generates the ifcfg file content
+// generateNMConnection generates the NetworkManager keyfile content
 func (a *RHELAdapter) generateNMConnection(iface entities.NetworkInterface, ifaceName string) string {
     b := &strings.Builder{}
     fmt.Fprintf(b, "[connection]\n")
     fmt.Fprintf(b, "id=%s\n", ifaceName)
-    fmt.Fprintf(b, "type=ethernet\n")
-    fmt.Fprintf(b, "interface-name=%s\nautoconnect=true\n\n", ifaceName)
-    fmt.Fprintf(b, "[ethernet]\nmac-address=%s\n", strings.ToLower(iface.MacAddress()))
-    if iface.MTU() > 0 { fmt.Fprintf(b, "mtu=%d\n", iface.MTU()) }
-    fmt.Fprintf(b, "\n[ipv4]\nmethod=manual\n")
-    if iface.Address() != "" && iface.CIDR() != "" {
-        parts := strings.Split(iface.CIDR(), "/"); if len(parts) == 2 {
-            fmt.Fprintf(b, "address1=%s/%s\n", iface.Address(), parts[1])
-        }
+    // bridge ports are NetworkManager slaves: they carry no IP configuration of their own
+    portOf := ""
+    if br := iface.Bridge(); br != nil {
+        portOf = fmt.Sprintf("master=%s\nslave-type=bridge\n", br.Name())
+    }
+    // a VRF member keeps its own IP settings
+    vrfOf := ""
+    if v := iface.VRF(); v != nil && iface.AddressDevice(ifaceName) == ifaceName {
+        vrfOf = fmt.Sprintf("master=%s\nslave-type=vrf\n", v.Name())
+    }
+    if bond := iface.Bond(); bond != nil {
+        fmt.Fprintf(b, "type=bond\n")
+        fmt.Fprintf(b, "interface-name=%s\nautoconnect=true\n%s%s\n", ifaceName, portOf, vrfOf)
+        fmt.Fprintf(b, "[ethernet]\ncloned-mac-address=%s\n", strings.ToLower(iface.MacAddress()))
+        if iface.MTU() > 0 { fmt.Fprintf(b, "mtu=%d\n", iface.MTU()) }
+        fmt.Fprintf(b, "\n[bond]\nmode=%s\nmiimon=%d\n", bond.Mode(), bond.MIIMon())
+        if bond.XmitHashPolicy() != "" { fmt.Fprintf(b, "xmit_hash_policy=%s\n", bond.XmitHashPolicy()) }
+    } else {
+        fmt.Fprintf(b, "type=ethernet\n")
+        fmt.Fprintf(b, "interface-name=%s\nautoconnect=true\n%s%s\n", ifaceName, portOf, vrfOf)
+        fmt.Fprintf(b, "[ethernet]\nmac-address=%s\n", strings.ToLower(iface.MacAddress()))
+        if iface.MTU() > 0 { fmt.Fprintf(b, "mtu=%d\n", iface.MTU()) }
+    }
+    if portOf != "" {
+        return b.String()
+    }
+    a.writeNMIPSections(b, iface, ifaceName)
+    return b.String()
+}
+
+// generateBridgeConnection generates the type=bridge keyfile for the bridge ifaceName is a port of.
+// Addresses, policy routing and spec routes are rendered here when the IP moves to the bridge.
+func (a *RHELAdapter) generateBridgeConnection(iface entities.NetworkInterface, ifaceName string) string {
+    br := iface.Bridge()
+    b := &strings.Builder{}
+    fmt.Fprintf(b, "[connection]\nid=%s\ntype=bridge\ninterface-name=%s\nautoconnect=true\n", br.Name(), br.Name())
+    if v := iface.VRF(); v != nil && br.MoveIP() {
+        fmt.Fprintf(b, "master=%s\nslave-type=vrf\n", v.Name())
+    }
+    fmt.Fprintf(b, "\n")
+    fmt.Fprintf(b, "[bridge]\nstp=%t\n", br.STP())
+    if br.MoveIP() {
+        a.writeNMIPSections(b, iface, ifaceName)
+    } else {
+        fmt.Fprintf(b, "\n[ipv4]\nmethod=disabled\n\n[ipv6]\nmethod=ignore\n")
+    }
+    return b.String()
+}
+
+// generateVRFConnection generates the type=vrf keyfile binding the VRF device to its table
+func (a *RHELAdapter) generateVRFConnection(iface entities.NetworkInterface, ifaceName string) string {
+    opts := a.opts.forInterface(iface)
+    v := iface.VRF()
+    b := &strings.Builder{}
+    fmt.Fprintf(b, "[connection]\nid=%s\ntype=vrf\ninterface-name=%s\nautoconnect=true\n\n", v.Name(), v.Name())
+    fmt.Fprintf(b, "[vrf]\ntable=%d\n", opts.vrfTable(iface, ifaceName))
+    fmt.Fprintf(b, "\n[ipv4]\nmethod=disabled\n\n[ipv6]\nmethod=ignore\n")
+    return b.String()
+}
+
+// writeNMIPSections renders the [ipv4]/[ipv6] sections. Each family gets its own section;
+// a family without addresses is left unmanaged.
+func (a *RHELAdapter) writeNMIPSections(b *strings.Builder, iface entities.NetworkInterface, ifaceName string) {
+    v4, v6 := addressesByFamily(iface.Addresses())
+    r4, r6 := routesByFamily(iface.EffectiveRoutes())
+    var dns4, dns6, search []string
+    if dns := iface.DNS(); dns != nil {
+        dns4, dns6 = dns.ServersByFamily()
+        search = dns.Search()
+    }
+    ipv4On := true
+    switch {
+    case iface.IPv4Mode() == entities.AddressModeDHCP:
+        // DHCP routes are ignored like on Ubuntu (use-routes: false); spec routes still apply
+        fmt.Fprintf(b, "\n[ipv4]\nmethod=auto\n")
+        a.writeNMAddressing(b, iface, v4, r4, ifaceName)
+        fmt.Fprintf(b, "ignore-auto-routes=true\nnever-default=true\n")
+    case iface.IPv4Mode() == entities.AddressModeDisabled || (len(v6) > 0 && len(v4) == 0):
+        fmt.Fprintf(b, "\n[ipv4]\nmethod=disabled\n")
+        ipv4On = false
+    default:
+        fmt.Fprintf(b, "\n[ipv4]\nmethod=manual\n")
+        a.writeNMAddressing(b, iface, v4, r4, ifaceName)
+        fmt.Fprintf(b, "never-default=true\n")
+    }
+    if ipv4On {
+        writeNMDNS(b, dns4, search)
+        search = nil
     }
-    if a.opts.EnablePolicyRouting && iface.Address() != "" && iface.CIDR() != "" {
-        table := a.opts.routingTable(ifaceName)
-        metric := a.opts.routeMetric(ifaceName)
+    switch {
+    case iface.IPv6Mode() == entities.AddressModeDHCP:
+        fmt.Fprintf(b, "\n[ipv6]\nmethod=auto\n")
+        a.writeNMAddressing(b, iface, v6, r6, ifaceName)
+        fmt.Fprintf(b, "ignore-auto-routes=true\nnever-default=true\n")
+        writeNMDNS(b, dns6, search)
+    case iface.IPv6Mode() == entities.AddressModeDisabled:
+        fmt.Fprintf(b, "\n[ipv6]\nmethod=disabled\n")
+    case len(v6) > 0:
+        fmt.Fprintf(b, "\n[ipv6]\nmethod=manual\n")
+        a.writeNMAddressing(b, iface, v6, r6, ifaceName)
+        fmt.Fprintf(b, "never-default=true\n")
+        writeNMDNS(b, dns6, search)
+    default:
+        fmt.Fprintf(b, "\n[ipv6]\nmethod=ignore\n")
+    }
+}
+
+// writeNMDNS renders dns/dns-search of the current section. NetworkManager keeps servers per
+// family, and search domains are written once, in the first enabled family.
+func writeNMDNS(b *strings.Builder, servers, search []string) {
+    if len(servers) > 0 {
+        fmt.Fprintf(b, "dns=%s\n", nmList(servers))
+    }
+    if len(search) > 0 {
+        fmt.Fprintf(b, "dns-search=%s\n", nmList(search))
+    }
+}
+
+// writeNMAddressing renders addressN/routeN/routing-ruleN keys of one family into the current section.
+// Spec routes follow the connected routes; route-table applies to every route without an explicit table.
+// VRF members only get route-table: the VRF itself steers traffic into its table.
+func (a *RHELAdapter) writeNMAddressing(b *strings.Builder, iface entities.NetworkInterface, addrs []entities.InterfaceAddress, routes []entities.Route, ifaceName string) {
+    opts := a.opts.forInterface(iface)
+    for i, ad := range addrs {
+        fmt.Fprintf(b, "address%d=%s\n", i+1, ad.WithPrefix())
+    }
+    n := 0
+    if iface.VRF() != nil {
+        fmt.Fprintf(b, "route-table=%d\n", opts.vrfTable(iface, ifaceName))
+    } else if opts.EnablePolicyRouting && len(addrs) > 0 {
+        table := opts.routingTable(ifaceName)
+        metric := opts.routeMetric(ifaceName)
         priority := 10000 + table
         fmt.Fprintf(b, "route-table=%d\n", table)
-        fmt.Fprintf(b, "route1=%s,,%d\n", iface.CIDR(), metric)
-        fmt.Fprintf(b, "routing-rules=priority %d from %s/32 table %d\n", priority, iface.Address(), table)
+        for _, ad := range connectedNetworks(addrs) {
+            n++
+            fmt.Fprintf(b, "route%d=%s,,%d\n", n, ad.Network(), metric)
+        }
+        for i, ad := range addrs {
+            fmt.Fprintf(b, "routing-rule%d=priority %d from %s table %d\n", i+1, priority, hostPrefix(ad.Address()), table)
+        }
+    }
+    for _, r := range routes {
+        n++
+        to := r.To()
+        if r.IsDefault() {
+            to = "0.0.0.0/0"
+            if r.IsIPv6() { to = "::/0" }
+        }
+        fmt.Fprintf(b, "route%d=%s,%s,%d\n", n, to, r.Via(), opts.staticRouteMetric(r, ifaceName))
+        var routeOpts []string
+        if r.Table() > 0 { routeOpts = append(routeOpts, fmt.Sprintf("table=%d", r.Table())) }
+        if r.OnLink() { routeOpts = append(routeOpts, "onlink=true") }
+        if len(routeOpts) > 0 { fmt.Fprintf(b, "route%d_options=%s\n", n, strings.Join(routeOpts, ",")) }
+    }
+}
+
+// generateVLANConnection generates the NetworkManager keyfile of a VLAN child of parent.
+// VLAN addresses are not part of the parent policy table, so only addresses are rendered.
+func (a *RHELAdapter) generateVLANConnection(v entities.VLAN, parent string) string {
+    name := v.Name(parent)
+    b := &strings.Builder{}
+    fmt.Fprintf(b, "[connection]\n")
+    fmt.Fprintf(b, "id=%s\n", name)
+    fmt.Fprintf(b, "type=vlan\n")
+    fmt.Fprintf(b, "interface-name=%s\nautoconnect=true\n\n", name)
+    fmt.Fprintf(b, "[vlan]\nparent=%s\nid=%d\n", parent, v.ID())
+    if v.MTU() > 0 { fmt.Fprintf(b, "\n[ethernet]\nmtu=%d\n", v.MTU()) }
+
+    v4, v6 := addressesByFamily(v.Addresses())
+    if len(v4) > 0 {
+        fmt.Fprintf(b, "\n[ipv4]\nmethod=manual\naddress1=%s\nnever-default=true\n", v4[0].WithPrefix())
+    } else {
+        fmt.Fprintf(b, "\n[ipv4]\nmethod=disabled\n")
+    }
+    if len(v6) > 0 {
+        fmt.Fprintf(b, "\n[ipv6]\nmethod=manual\naddress1=%s\nnever-default=true\n", v6[0].WithPrefix())
+    } else {
+        fmt.Fprintf(b, "\n[ipv6]\nmethod=ignore\n")
     }
-    fmt.Fprintf(b, "never-default=true\n\n[ipv6]\nmethod=ignore\n")
     return b.String()
 }
 
-func extractIndexRHEL(name string) int {
-    if strings.HasPrefix(name, constants.InterfacePrefix) {
-        idx := strings.TrimPrefix(name, constants.InterfacePrefix)
-        if n, err := strconv.Atoi(idx); err == nil { return n }
+// writeBondPorts writes one bond-slave connection per member, matched by MAC so that the
+// members keep their kernel names
+func (a *RHELAdapter) writeBondPorts(iface entities.NetworkInterface, ifaceName string, idx int) error {
+    for i, mac := range iface.Bond().Members() {
+        id := bondPortID(ifaceName, i)
+        b := &strings.Builder{}
+        fmt.Fprintf(b, "[connection]\nid=%s\ntype=ethernet\nmaster=%s\nslave-type=bond\nautoconnect=true\n\n", id, ifaceName)
+        fmt.Fprintf(b, "[ethernet]\nmac-address=%s\n", mac)
+        path := filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, id, ".nmconnection"))
+        if err := a.fileSystem.WriteFile(path, []byte(b.String()), 0600); err != nil {
+            return errors.NewSystemError(fmt.Sprintf("failed to write bond port .nmconnection for %s", mac), err)
+        }
     }
+    return nil
+}
+
+// removeBondPorts removes the bond-slave connections written for ifaceName (best effort)
+func (a *RHELAdapter) removeBondPorts(ifaceName string, idx int) {
+    files, err := a.fileSystem.ListFiles(a.GetConfigDir())
+    if err != nil {
+        return
+    }
+    prefix := constants.ConfigFilePrefix(idx) + ifaceName + "-port"
+    for _, f := range files {
+        if strings.HasPrefix(f, prefix) && strings.HasSuffix(f, ".nmconnection") {
+            if err := a.fileSystem.Remove(filepath.Join(a.GetConfigDir(), f)); err != nil {
+                a.logger.WithError(err).WithField("nm", f).Debug("Error removing bond port .nmconnection (ignored)")
+            }
+        }
+    }
+}
+
+// isBondConnection reports whether the keyfile at path describes a bond master
+func (a *RHELAdapter) isBondConnection(path string) bool {
+    content, err := a.fileSystem.ReadFile(path)
+    return err == nil && strings.Contains(string(content), "\ntype=bond\n")
+}
+
+// connectionMasters returns the bridge/VRF masters referenced from the keyfile at path,
+// following a bridge keyfile on to its VRF
+func (a *RHELAdapter) connectionMasters(idx int, path string) []string {
+    var masters []string
+    for hops := 0; hops < 2; hops++ {
+        content, err := a.fileSystem.ReadFile(path)
+        if err != nil {
+            break
+        }
+        text := string(content)
+        if !strings.Contains(text, "\nslave-type=bridge\n") && !strings.Contains(text, "\nslave-type=vrf\n") {
+            break
+        }
+        master := ""
+        for _, line := range strings.Split(text, "\n") {
+            if v, ok := strings.CutPrefix(line, "master="); ok {
+                master = strings.TrimSpace(v)
+            }
+        }
+        if master == "" {
+            break
+        }
+        masters = append(masters, master)
+        path = filepath.Join(a.GetConfigDir(), constants.ConfigFileName(idx, master, ".nmconnection"))
+    }
+    return masters
+}
+
+// Inspect reads the runtime state through the same nsenter path Configure uses
+func (a *RHELAdapter) Inspect(ctx context.Context, iface entities.NetworkInterface, name string) (*interfaces.InterfaceState, error) {
+    return inspectInterface(ctx, a.execCommand, a.opts, iface, name)
+}
+
+// ListVLANs returns the VLAN links currently stacked on parent
+func (a *RHELAdapter) ListVLANs(ctx context.Context, parent string) ([]string, error) {
+    return listVLANLinks(ctx, a.execCommand, parent)
+}
+
+// RemoveVLAN deletes a VLAN link and its type=vlan connection file
+func (a *RHELAdapter) RemoveVLAN(ctx context.Context, name string) error {
+    if err := deleteVLANLink(ctx, a.execCommand, name); err != nil { return err }
+    // The file carries the parent index prefix (9X-<name>.nmconnection); match by name
+    files, err := a.fileSystem.ListFiles(a.GetConfigDir())
+    if err != nil {
+        a.logger.WithError(err).WithField("vlan", name).Debug("failed to list NetworkManager connections (ignored)")
+    }
+    for _, f := range files {
+        if n, ok := constants.ParseConfigFileName(f, ".nmconnection"); ok && n == name {
+            if err := a.fileSystem.Remove(filepath.Join(a.GetConfigDir(), f)); err != nil {
+                a.logger.WithError(err).WithField("nm", f).Debug("Error removing VLAN .nmconnection (ignored)")
+            }
+        }
+    }
+    a.logger.WithField("vlan", name).Info("VLAN sub-interface removed")
+    return nil
+}
+
+// removeChildVLANs deletes every VLAN stacked on parent (best effort)
+func (a *RHELAdapter) removeChildVLANs(ctx context.Context, parent string) {
+    children, err := listVLANLinks(ctx, a.execCommand, parent)
+    if err != nil {
+        a.logger.WithError(err).WithField("interface", parent).Debug("failed to list VLAN links (ignored)")
+        return
+    }
+    for _, child := range children {
+        if err := a.RemoveVLAN(ctx, child); err != nil {
+            a.logger.WithError(err).WithField("vlan", child).Warn("failed to remove VLAN link")
+        }
+    }
+}
+
+func extractIndexRHEL(name string) int {
+    if n, ok := constants.ParseInterfaceIndex(name); ok { return n }
     return 0
 }
 
@@ -2,6 +2,7 @@ package network
 
 import (
     "context"
+    "fmt"
     "os"
     "strings"
     "testing"
@@ -21,6 +22,9 @@ func (s *rhelStubExec) ExecuteWithTimeout(ctx context.Context, _ time.Duration,
         out := "2: ens7: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1450 qdisc fq_codel state UP mode DEFAULT group default qlen 1000\tlink/ether fa:16:3e:11:4c:d1 brd ff:ff:ff:ff:ff:ff"
         return []byte(out), nil
     }
+    if len(args) > 0 && args[len(args)-1] == "dynamic" { // DHCP lease check (direct or via nsenter)
+        return []byte("3: multinic1    inet 10.20.0.50/24 brd 10.20.0.255 scope global dynamic multinic1"), nil
+    }
     if cmd == "ip" && len(args) >= 1 && args[0] == "link" { // non -o path used in findDeviceByMAC
         out := "2: ens7: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1450 qdisc fq_codel state UP mode DEFAULT group default qlen 1000\n    link/ether fa:16:3e:11:4c:d1 brd ff:ff:ff:ff:ff:ff"
         return []byte(out), nil
@@ -73,3 +77,332 @@ func TestRHELConfigure_PersistOnly_90Prefix(t *testing.T) {
     // ensure no systemctl restart NetworkManager
     for _, c := range exec.calls { if len(c) > 0 && c[0] == "systemctl" { t.Fatalf("unexpected systemctl call: %#v", c) } }
 }
+
+func TestRHELConfigure_IPv6_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, err := entities.NewNetworkInterface(0, "fa:16:3e:11:4c:d1", "node", "2001:db8:10::107", "2001:db8:10::/64", 1450)
+    if err != nil { t.Fatalf("ni: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic0")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    b, _ := fs.ReadFile("/etc/NetworkManager/system-connections/90-multinic0.nmconnection")
+    s := string(b)
+    for _, frag := range []string{
+        "[ipv4]\nmethod=disabled\n",
+        "[ipv6]\nmethod=manual\naddress1=2001:db8:10::107/64\n",
+        "routing-rule1=priority 10100 from 2001:db8:10::107/128 table 100",
+    } {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in nmconnection:\n%s", frag, s) }
+    }
+
+    found := false
+    for _, c := range exec.calls {
+        if strings.HasSuffix(strings.Join(c, " "), "ip -6 rule add from 2001:db8:10::107/128 table 100") { found = true }
+    }
+    if !found { t.Fatalf("expected ip -6 rule add for IPv6 source; calls=%v", exec.calls) }
+}
+
+func TestRHELConfigure_DualStack_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    _ = ni.AddAddress("11.11.11.200", "11.11.11.0/24")
+    _ = ni.AddAddress("2001:db8:11::107", "2001:db8:11::/64")
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    b, _ := fs.ReadFile("/etc/NetworkManager/system-connections/91-multinic1.nmconnection")
+    s := string(b)
+    for _, frag := range []string{
+        "[ipv4]\nmethod=manual\naddress1=11.11.11.107/24\naddress2=11.11.11.200/24\n",
+        "route1=11.11.11.0/24,,101\n",
+        "routing-rule2=priority 10101 from 11.11.11.200/32 table 101",
+        "[ipv6]\nmethod=manual\naddress1=2001:db8:11::107/64\n",
+    } {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in nmconnection:\n%s", frag, s) }
+    }
+    if strings.Contains(s, "route2=") { t.Fatalf("shared subnet must produce a single route:\n%s", s) }
+}
+
+func TestRHELConfigure_StaticRoutes_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(2, "fa:16:3e:11:4c:d1", "node", "192.168.192.10", "192.168.192.0/24", 1450)
+    _ = ni.AddRoute("10.20.0.0/16", "192.168.192.1", 0, 0, false)
+    _ = ni.AddRoute("172.16.0.0/24", "192.168.192.254", 50, 200, true)
+    _ = ni.SetGateway("192.168.192.1")
+    nm, _ := entities.NewInterfaceName("multinic2")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    b, _ := fs.ReadFile("/etc/NetworkManager/system-connections/92-multinic2.nmconnection")
+    s := string(b)
+    for _, frag := range []string{
+        "route1=192.168.192.0/24,,102\n",
+        "route2=10.20.0.0/16,192.168.192.1,102\n",
+        "route3=172.16.0.0/24,192.168.192.254,50\nroute3_options=table=200,onlink=true\n",
+        "route4=0.0.0.0/0,192.168.192.1,102\n",
+    } {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in nmconnection:\n%s", frag, s) }
+    }
+}
+
+func TestRHELConfigure_VLAN_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    _ = ni.AddVLAN(300, "", "2001:db8:300::10", "2001:db8:300::/64", 1400)
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    b, err := fs.ReadFile("/etc/NetworkManager/system-connections/91-multinic1.300.nmconnection")
+    if err != nil { t.Fatalf("expected VLAN nmconnection: %v", err) }
+    s := string(b)
+    for _, frag := range []string{
+        "type=vlan\ninterface-name=multinic1.300\n",
+        "[vlan]\nparent=multinic1\nid=300\n",
+        "[ethernet]\nmtu=1400\n",
+        "[ipv4]\nmethod=disabled\n",
+        "[ipv6]\nmethod=manual\naddress1=2001:db8:300::10/64\n",
+    } {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in VLAN nmconnection:\n%s", frag, s) }
+    }
+
+    created := false
+    for _, c := range exec.calls {
+        if strings.HasSuffix(strings.Join(c, " "), "ip link add link multinic1 name multinic1.300 type vlan id 300") { created = true }
+    }
+    if !created { t.Fatalf("expected VLAN link creation; calls=%v", exec.calls) }
+}
+
+// rhelBondStubExec answers "ip -o link show" (direct or via nsenter) with two free bond ports
+type rhelBondStubExec struct{ rhelStubExec }
+func (s *rhelBondStubExec) ExecuteWithTimeout(ctx context.Context, d time.Duration, cmd string, args ...string) ([]byte, error) {
+    full := strings.Join(append([]string{cmd}, args...), " ")
+    if strings.HasSuffix(full, "ip -o link show") {
+        s.calls = append(s.calls, append([]string{cmd}, args...))
+        return []byte("2: ens7: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1450 state UP\tlink/ether fa:16:3e:11:4c:d1 brd ff:ff:ff:ff:ff:ff\n" +
+            "3: ens8: <BROADCAST,MULTICAST> mtu 1450 state DOWN\tlink/ether fa:16:3e:11:4c:d2 brd ff:ff:ff:ff:ff:ff\n"), nil
+    }
+    return s.rhelStubExec.ExecuteWithTimeout(ctx, d, cmd, args...)
+}
+
+func TestRHELConfigure_Bond_NMConnection(t *testing.T) {
+    exec := &rhelBondStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err := ni.SetBond([]string{"fa:16:3e:11:4c:d1", "fa:16:3e:11:4c:d2"}, "", 0, ""); err != nil { t.Fatalf("set bond: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    dir := "/etc/NetworkManager/system-connections/"
+    b, err := fs.ReadFile(dir + "91-multinic1.nmconnection")
+    if err != nil { t.Fatalf("expected bond nmconnection: %v", err) }
+    s := string(b)
+    for _, frag := range []string{
+        "type=bond\ninterface-name=multinic1\n",
+        "cloned-mac-address=fa:16:3e:11:4c:d1\n",
+        "[bond]\nmode=active-backup\nmiimon=100\n",
+        "address1=11.11.11.107/24\n",
+    } {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in bond nmconnection:\n%s", frag, s) }
+    }
+    for i, mac := range []string{"fa:16:3e:11:4c:d1", "fa:16:3e:11:4c:d2"} {
+        p, err := fs.ReadFile(fmt.Sprintf("%s91-multinic1-port%d.nmconnection", dir, i))
+        if err != nil { t.Fatalf("expected port %d nmconnection: %v", i, err) }
+        for _, frag := range []string{"type=ethernet\n", "master=multinic1\n", "slave-type=bond\n", "mac-address=" + mac + "\n"} {
+            if !strings.Contains(string(p), frag) { t.Fatalf("expected %q in port nmconnection:\n%s", frag, p) }
+        }
+    }
+    if fs.Exists("/etc/systemd/network/91-multinic1.link") { t.Fatalf("bond must not get a .link rename file") }
+
+    enslaved := 0
+    for _, c := range exec.calls {
+        j := strings.Join(c, " ")
+        if strings.HasSuffix(j, "ip link set ens7 master multinic1") || strings.HasSuffix(j, "ip link set ens8 master multinic1") { enslaved++ }
+    }
+    if enslaved != 2 { t.Fatalf("expected both members enslaved; calls=%v", exec.calls) }
+}
+
+func TestRHELConfigure_Bridge_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err := ni.SetBridge("br-vm", false, true); err != nil { t.Fatalf("set bridge: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    dir := "/etc/NetworkManager/system-connections/"
+    port, _ := fs.ReadFile(dir + "91-multinic1.nmconnection")
+    for _, frag := range []string{"type=ethernet\n", "master=br-vm\nslave-type=bridge\n", "mac-address=fa:16:3e:11:4c:d1\n"} {
+        if !strings.Contains(string(port), frag) { t.Fatalf("expected %q in port nmconnection:\n%s", frag, port) }
+    }
+    if strings.Contains(string(port), "[ipv4]") { t.Fatalf("bridge port must not carry IP settings:\n%s", port) }
+
+    br, err := fs.ReadFile(dir + "91-br-vm.nmconnection")
+    if err != nil { t.Fatalf("expected bridge nmconnection: %v", err) }
+    for _, frag := range []string{"type=bridge\ninterface-name=br-vm\n", "[bridge]\nstp=false\n", "[ipv4]\nmethod=manual\naddress1=11.11.11.107/24\n"} {
+        if !strings.Contains(string(br), frag) { t.Fatalf("expected %q in bridge nmconnection:\n%s", frag, br) }
+    }
+
+    moved := false
+    for _, c := range exec.calls {
+        if strings.Contains(strings.Join(c, " "), "ip addr replace 11.11.11.107/24 dev br-vm") { moved = true }
+    }
+    if !moved { t.Fatalf("expected address on bridge; calls=%v", exec.calls) }
+
+    // rollback removes the bridge keyfile found through the port keyfile
+    _ = ad.Rollback(context.Background(), "multinic1")
+    if fs.Exists(dir + "91-br-vm.nmconnection") { t.Fatalf("bridge nmconnection not removed on rollback") }
+}
+
+func TestRHELConfigure_VRF_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    if err := ni.SetVRF("vrf-tenant", 1001); err != nil { t.Fatalf("set vrf: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    dir := "/etc/NetworkManager/system-connections/"
+    port, _ := fs.ReadFile(dir + "91-multinic1.nmconnection")
+    for _, frag := range []string{"master=vrf-tenant\nslave-type=vrf\n", "address1=11.11.11.107/24\n", "route-table=1001\n"} {
+        if !strings.Contains(string(port), frag) { t.Fatalf("expected %q in port nmconnection:\n%s", frag, port) }
+    }
+    if strings.Contains(string(port), "routing-rule") { t.Fatalf("no routing rules expected inside a VRF:\n%s", port) }
+
+    vrf, err := fs.ReadFile(dir + "91-vrf-tenant.nmconnection")
+    if err != nil { t.Fatalf("expected VRF nmconnection: %v", err) }
+    if !strings.Contains(string(vrf), "type=vrf\ninterface-name=vrf-tenant\n") || !strings.Contains(string(vrf), "[vrf]\ntable=1001\n") {
+        t.Fatalf("unexpected VRF nmconnection:\n%s", vrf)
+    }
+
+    _ = ad.Rollback(context.Background(), "multinic1")
+    if fs.Exists(dir + "91-vrf-tenant.nmconnection") { t.Fatalf("VRF nmconnection not removed on rollback") }
+}
+
+func TestRHELConfigure_DHCP_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "2001:db8::10", "2001:db8::/64", 1450)
+    if err := ni.SetAddressModes("dhcp", "static"); err != nil { t.Fatalf("set modes: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    b, _ := fs.ReadFile("/etc/NetworkManager/system-connections/91-multinic1.nmconnection")
+    s := string(b)
+    for _, frag := range []string{"[ipv4]\nmethod=auto\n", "ignore-auto-routes=true\n", "never-default=true\n", "[ipv6]\nmethod=manual\n", "address1=2001:db8::10/64\n"} {
+        if !strings.Contains(s, frag) { t.Fatalf("expected %q in nmconnection:\n%s", frag, s) }
+    }
+    started := false
+    for _, c := range exec.calls {
+        if strings.Contains(strings.Join(c, " "), "dhclient -1 ") { started = true }
+    }
+    if !started { t.Fatalf("expected DHCP client started: %v", exec.calls) }
+}
+
+func TestRHELConfigure_DNS_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    _ = ni.AddAddress("2001:db8::10", "2001:db8::/64")
+    if err := ni.SetDNS([]string{"11.11.11.53", "2001:db8::53"}, []string{"storage.example.com", "example.com"}); err != nil { t.Fatalf("set dns: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    b, _ := fs.ReadFile("/etc/NetworkManager/system-connections/91-multinic1.nmconnection")
+    s := string(b)
+    v4 := s[strings.Index(s, "[ipv4]"):strings.Index(s, "[ipv6]")]
+    v6 := s[strings.Index(s, "[ipv6]"):]
+    if !strings.Contains(v4, "dns=11.11.11.53;\n") || !strings.Contains(v4, "dns-search=storage.example.com;example.com;\n") {
+        t.Fatalf("expected IPv4 dns settings:\n%s", s)
+    }
+    if !strings.Contains(v6, "dns=2001:db8::53;\n") || strings.Contains(v6, "dns-search") {
+        t.Fatalf("expected IPv6 servers only in [ipv6]:\n%s", s)
+    }
+}
+
+func TestRHELConfigure_NodeConfigOptions_NMConnection(t *testing.T) {
+    exec := &rhelStubExec{}
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    ad := NewRHELAdapter(exec, fs, lg)
+
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    off := false
+    if err := ni.SetOptions(entities.NetworkOptions{PolicyRouting: &off}); err != nil { t.Fatalf("set options: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+
+    if err := ad.Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    for _, c := range exec.calls {
+        if line := strings.Join(c, " "); strings.Contains(line, " rule add ") { t.Fatalf("unexpected policy rule with policy routing disabled: %s", line) }
+    }
+    b, _ := fs.ReadFile("/etc/NetworkManager/system-connections/91-multinic1.nmconnection")
+    s := string(b)
+    if strings.Contains(s, "route-table=") || strings.Contains(s, "routing-rule") {
+        t.Fatalf("expected no policy routing keys in nmconnection:\n%s", s)
+    }
+    if !strings.Contains(s, "address1=11.11.11.107/24") { t.Fatalf("expected address in nmconnection:\n%s", s) }
+}
+
+// a cleanup Job runs a new agent: it has only the env options and learns the CR table from the keyfile
+func TestRHELRollback_FreshAdapterUsesPersistedTable(t *testing.T) {
+    fs := &rhelMemFS{files: map[string][]byte{}}
+    lg := logrus.New(); lg.SetLevel(logrus.PanicLevel)
+    envOpts := DefaultOptions()
+    envOpts.EnablePolicyRouting = false
+    ni, _ := entities.NewNetworkInterface(1, "fa:16:3e:11:4c:d1", "node", "11.11.11.107", "11.11.11.0/24", 1450)
+    base, on := 3000, true
+    if err := ni.SetOptions(entities.NetworkOptions{RoutingTableBase: &base, PolicyRouting: &on}); err != nil { t.Fatalf("set options: %v", err) }
+    nm, _ := entities.NewInterfaceName("multinic1")
+    if err := NewRHELAdapterWithOptions(&rhelStubExec{}, fs, lg, envOpts).Configure(context.Background(), *ni, *nm); err != nil { t.Fatalf("configure: %v", err) }
+
+    exec := &rhelStubExec{}
+    if err := NewRHELAdapterWithOptions(exec, fs, lg, envOpts).Rollback(context.Background(), "multinic1"); err != nil { t.Fatalf("rollback: %v", err) }
+    want := map[string]bool{"ip rule delete table 3001": false, "ip route flush table 3001": false}
+    for _, c := range exec.calls {
+        line := strings.Join(c, " ")
+        if i := strings.Index(line, " ip "); strings.HasPrefix(line, "nsenter") && i >= 0 { line = line[i+1:] }
+        if _, ok := want[line]; ok { want[line] = true }
+    }
+    for cmd, seen := range want {
+        if !seen { t.Fatalf("expected command not executed: %s (calls %v)", cmd, exec.calls) }
+    }
+}
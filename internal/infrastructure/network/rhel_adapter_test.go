@@ -689,7 +689,7 @@ func TestRHELAdapter_SELinuxRestore(t *testing.T) {
 			tt.setupMocks(mockExecutor, mockFS)
 			
 			// Create adapter with SELinux option
-			adapter := NewRHELAdapterWithSELinux(mockExecutor, mockFS, logrus.New(), tt.enableSELinux)
+			adapter := NewRHELAdapterWithSELinux(mockExecutor, mockFS, logrus.New(), tt.enableSELinux, Options{})
 			
 			// Test the restoreSELinuxContext method directly
 			ctx := context.Background()
@@ -732,12 +732,22 @@ func TestRHELAdapter_Configure_WithSELinux(t *testing.T) {
 		Return([]byte(""), nil).Once()
 	mockExecutor.On("ExecuteWithTimeout", mock.Anything, 30*time.Second, "ip", "link", "set", "multinic0", "up").
 		Return([]byte(""), nil).Once()
+	// Spec static routes are refreshed (flush) even when none are declared
+	mockExecutor.On("ExecuteWithTimeout", mock.Anything, 30*time.Second, "ip", "route", "flush", "dev", "multinic0", "proto", "static", "table", "all").
+		Return([]byte(""), nil).Once()
+	mockExecutor.On("ExecuteWithTimeout", mock.Anything, 30*time.Second, "ip", "-6", "route", "flush", "dev", "multinic0", "proto", "static", "table", "all").
+		Return([]byte(""), nil).Once()
 	
 	// File writes
+	mockFS.On("MkdirAll", "/etc/systemd/network", os.FileMode(0755)).Return(nil).Once()
+	mockFS.On("MkdirAll", "/etc/NetworkManager/system-connections", os.FileMode(0755)).Return(nil).Once()
 	mockFS.On("WriteFile", "/etc/systemd/network/90-multinic0.link", mock.AnythingOfType("[]uint8"), os.FileMode(0644)).
 		Return(nil).Once()
 	mockFS.On("WriteFile", "/etc/NetworkManager/system-connections/90-multinic0.nmconnection", mock.AnythingOfType("[]uint8"), os.FileMode(0600)).
 		Return(nil).Once()
+	// files left under another index prefix are looked up after writing
+	mockFS.On("ListFiles", "/etc/systemd/network").Return([]string{"90-multinic0.link"}, nil).Once()
+	mockFS.On("ListFiles", "/etc/NetworkManager/system-connections").Return([]string{"90-multinic0.nmconnection"}, nil).Once()
 	
 	// SELinux restorecon (enabled)
 	mockFS.On("Exists", "/etc/NetworkManager/system-connections").Return(true)
@@ -749,8 +759,8 @@ func TestRHELAdapter_Configure_WithSELinux(t *testing.T) {
 		"restorecon -Rv /etc/systemd/network").
 		Return([]byte("restorecon: context restored"), nil).Once()
 	
-	// Create adapter with SELinux enabled
-	adapter := NewRHELAdapterWithSELinux(mockExecutor, mockFS, logrus.New(), true)
+	// Create adapter with SELinux enabled (policy routing/sysctls off to keep the command set minimal)
+	adapter := NewRHELAdapterWithSELinux(mockExecutor, mockFS, logrus.New(), true, Options{})
 	
 	// Test Configure
 	err := adapter.Configure(context.Background(), *iface, ifaceName)
@@ -0,0 +1,93 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// staticRouteProto marks routes installed from spec.routes/gateway so that they can be
+// flushed without touching kernel (connected) or policy routes on the same device.
+const staticRouteProto = "static"
+
+// commandFunc executes a command the way the owning adapter does (direct or via nsenter).
+type commandFunc func(ctx context.Context, cmd string, args ...string) ([]byte, error)
+
+// routeTable resolves the table for a declared route: explicit table wins, then the VRF table,
+// then the per-interface policy table when policy routing is enabled, otherwise main (0).
+func (o Options) routeTable(iface entities.NetworkInterface, r entities.Route, name string) int {
+	if r.Table() > 0 {
+		return r.Table()
+	}
+	if iface.VRF() != nil {
+		return o.vrfTable(iface, name)
+	}
+	if o.EnablePolicyRouting {
+		return o.routingTable(name)
+	}
+	return 0
+}
+
+// staticRouteMetric returns the declared metric or the per-interface default.
+func (o Options) staticRouteMetric(r entities.Route, name string) int {
+	if r.Metric() > 0 {
+		return r.Metric()
+	}
+	return o.routeMetric(name)
+}
+
+// staticRouteArgs builds "ip route replace" arguments for a declared route on dev.
+func staticRouteArgs(r entities.Route, dev string, table, metric int) []string {
+	family := "0.0.0.0"
+	if r.IsIPv6() {
+		family = "::"
+	}
+	args := ipArgs(family, "route", "replace", r.To())
+	if r.Via() != "" {
+		args = append(args, "via", r.Via())
+	}
+	args = append(args, "dev", dev)
+	if table > 0 {
+		args = append(args, "table", fmt.Sprintf("%d", table))
+	}
+	args = append(args, "metric", fmt.Sprintf("%d", metric), "proto", staticRouteProto)
+	if r.OnLink() {
+		args = append(args, "onlink")
+	}
+	return args
+}
+
+// applyStaticRoutes replaces the spec-declared routes of dev: previously installed static
+// routes are flushed first so that routes removed from the spec disappear at runtime too.
+// Table and metric follow the multinic name even when dev is a bridge carrying its addresses.
+func applyStaticRoutes(ctx context.Context, run commandFunc, logger *logrus.Logger, opts Options, iface entities.NetworkInterface, name, dev string) error {
+	flushStaticRoutes(ctx, run, logger, dev)
+	if dev != name {
+		flushStaticRoutes(ctx, run, logger, name)
+	}
+	for _, r := range iface.EffectiveRoutes() {
+		args := staticRouteArgs(r, dev, opts.routeTable(iface, r, name), opts.staticRouteMetric(r, name))
+		if _, err := run(ctx, "ip", args...); err != nil {
+			return errors.NewNetworkError(fmt.Sprintf("failed to install static route %s via %s", r.To(), r.Via()), err)
+		}
+	}
+	return nil
+}
+
+// flushStaticRoutes removes spec-declared routes of dev from every table and both families.
+func flushStaticRoutes(ctx context.Context, run commandFunc, logger *logrus.Logger, dev string) {
+	for _, family := range [][]string{nil, {"-6"}} {
+		args := append(append([]string{}, family...), "route", "flush", "dev", dev, "proto", staticRouteProto, "table", "all")
+		if _, err := run(ctx, "ip", args...); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"interface": dev,
+				"family":    strings.Join(family, ""),
+			}).Debug("failed to flush static routes (ignored)")
+		}
+	}
+}
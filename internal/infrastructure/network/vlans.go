@@ -0,0 +1,86 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	"multinic-agent/internal/domain/errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// applyVLANs creates the 802.1Q children declared on iface on top of parent and applies their
+// MTU/addresses. VLAN addresses keep the kernel connected route: they are not covered by the
+// per-interface policy table of the parent.
+func applyVLANs(ctx context.Context, run commandFunc, logger *logrus.Logger, iface entities.NetworkInterface, parent string) error {
+	for _, v := range iface.VLANs() {
+		name := v.Name(parent)
+		if _, err := run(ctx, "ip", "link", "add", "link", parent, "name", name, "type", "vlan", "id", strconv.Itoa(v.ID())); err != nil {
+			// tolerate "File exists" so that re-applying the same spec stays idempotent
+			if !strings.Contains(err.Error(), "File exists") {
+				return errors.NewNetworkError(fmt.Sprintf("failed to create VLAN %s (id %d) on %s", name, v.ID(), parent), err)
+			}
+		}
+		if v.MTU() > 0 {
+			if _, err := run(ctx, "ip", "link", "set", name, "mtu", strconv.Itoa(v.MTU())); err != nil {
+				return errors.NewNetworkError(fmt.Sprintf("failed to set MTU on VLAN %s", name), err)
+			}
+		}
+		for _, ad := range v.Addresses() {
+			if ad.IsIPv6() {
+				if _, err := run(ctx, "sysctl", "-w", fmt.Sprintf("net.ipv6.conf.%s.disable_ipv6=0", name)); err != nil {
+					logger.WithError(err).WithField("interface", name).Debug("failed to enable IPv6 (ignored)")
+				}
+			}
+			if _, err := run(ctx, "ip", ipArgs(ad.Address(), "addr", "replace", ad.WithPrefix(), "dev", name)...); err != nil {
+				return errors.NewNetworkError(fmt.Sprintf("failed to set %s address %s on VLAN %s", ipFamilyName(ad.Address()), ad.WithPrefix(), name), err)
+			}
+		}
+		if _, err := run(ctx, "ip", "link", "set", name, "up"); err != nil {
+			return errors.NewNetworkError(fmt.Sprintf("failed to set VLAN %s up", name), err)
+		}
+		logger.WithFields(logrus.Fields{"vlan": name, "id": v.ID(), "parent": parent}).Info("VLAN sub-interface applied")
+	}
+	return nil
+}
+
+// listVLANLinks returns the VLAN links stacked on parent.
+// "ip -o link show type vlan" prints them as "7: multinic0.100@multinic0: <...>".
+func listVLANLinks(ctx context.Context, run commandFunc, parent string) ([]string, error) {
+	out, err := run(ctx, "ip", "-o", "link", "show", "type", "vlan")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		name, link, ok := strings.Cut(strings.TrimSpace(parts[1]), "@")
+		if ok && link == parent {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// deleteVLANLink removes a VLAN link; a link that is already gone is not an error.
+func deleteVLANLink(ctx context.Context, run commandFunc, name string) error {
+	if _, err := run(ctx, "ip", "link", "delete", name); err != nil {
+		if strings.Contains(err.Error(), "Cannot find device") {
+			return nil
+		}
+		return errors.NewNetworkError(fmt.Sprintf("failed to delete VLAN %s", name), err)
+	}
+	return nil
+}
+
+// isStackedLink reports whether an "ip link" name column belongs to a stacked link
+// (e.g. "multinic0.100@multinic0"). VLANs share the parent MAC, so MAC lookups skip them.
+func isStackedLink(name string) bool {
+	return strings.Contains(name, "@")
+}
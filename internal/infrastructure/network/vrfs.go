@@ -0,0 +1,71 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"multinic-agent/internal/domain/errors"
+	"multinic-agent/internal/infrastructure/adapters"
+
+	"github.com/sirupsen/logrus"
+)
+
+// applyVRF creates the VRF device name bound to table (if missing) and enslaves dev to it.
+// Enslaving cycles dev, so it must run before addresses are applied.
+func applyVRF(ctx context.Context, run commandFunc, logger *logrus.Logger, name string, table int, dev string) error {
+	if _, err := run(ctx, "ip", "link", "add", name, "type", "vrf", "table", strconv.Itoa(table)); err != nil && !strings.Contains(err.Error(), "File exists") {
+		return errors.NewNetworkError(fmt.Sprintf("failed to create VRF %s (table %d)", name, table), err)
+	}
+	if _, err := run(ctx, "ip", "link", "set", name, "up"); err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to set VRF %s up", name), err)
+	}
+	if _, err := run(ctx, "ip", "link", "set", dev, "master", name); err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("failed to enslave %s to VRF %s", dev, name), err)
+	}
+	logger.WithFields(logrus.Fields{"vrf": name, "table": table, "device": dev}).Info("VRF applied")
+	return nil
+}
+
+// listVRFLinks returns the names of the VRF devices on the node
+func listVRFLinks(ctx context.Context, run commandFunc) (map[string]bool, error) {
+	out, err := run(ctx, "ip", "-o", "link", "show", "type", "vrf")
+	if err != nil {
+		return nil, err
+	}
+	vrfs := map[string]bool{}
+	for _, l := range adapters.ParseLinks(out) {
+		vrfs[l.Name] = true
+	}
+	return vrfs, nil
+}
+
+// removeInterfaceVRF deletes the VRF holding port, either directly or through the bridge the
+// port is attached to. It must run before the bridge is removed.
+func removeInterfaceVRF(ctx context.Context, run commandFunc, logger *logrus.Logger, port string) {
+	vrfs, err := listVRFLinks(ctx, run)
+	if err != nil || len(vrfs) == 0 {
+		return
+	}
+	links, err := listLinks(ctx, run)
+	if err != nil {
+		return
+	}
+	masters := map[string]string{}
+	for _, l := range links {
+		masters[l.Name] = l.Master
+	}
+	for dev, hops := port, 0; dev != "" && hops < 2; dev, hops = masters[dev], hops+1 {
+		master := masters[dev]
+		if !vrfs[master] {
+			continue
+		}
+		if _, err := run(ctx, "ip", "link", "delete", master); err != nil {
+			logger.WithError(err).WithField("vrf", master).Warn("failed to delete VRF device")
+			return
+		}
+		logger.WithFields(logrus.Fields{"vrf": master, "device": dev}).Info("VRF device removed")
+		return
+	}
+}
@@ -3,6 +3,8 @@ package persistence
 import (
     "context"
     "fmt"
+    "strings"
+
     "multinic-agent/internal/domain/entities"
     "multinic-agent/internal/domain/errors"
     "multinic-agent/internal/domain/interfaces"
@@ -14,6 +16,8 @@ import (
 type NodeConfig struct {
     NodeName   string
     Interfaces []NodeInterface
+    // Options are the node-wide network option overrides (spec.options)
+    Options *NodeOptions
 }
 
 // NodeInterface represents a single interface entry from the node CR spec
@@ -25,6 +29,103 @@ type NodeInterface struct {
     Address    string `yaml:"address"`
     CIDR       string `yaml:"cidr"`
     MTU        int    `yaml:"mtu"`
+    // Addresses lists additional (or, without Address, all) addresses of the interface
+    Addresses  []NodeAddress `yaml:"addresses,omitempty"`
+    Routes     []NodeRoute   `yaml:"routes,omitempty"`
+    Gateway    string        `yaml:"gateway,omitempty"`
+    VLANs      []NodeVLAN    `yaml:"vlans,omitempty"`
+    // Kind is "ethernet" (default) or "bond"; a bond uses MacAddress as the bond MAC
+    Kind       string        `yaml:"kind,omitempty"`
+    Bond       *NodeBond     `yaml:"bond,omitempty"`
+    Bridge     *NodeBridge   `yaml:"bridge,omitempty"`
+    VRF        *NodeVRF      `yaml:"vrf,omitempty"`
+    // IPv4Mode/IPv6Mode: static (default) | dhcp | disabled
+    IPv4Mode   string        `yaml:"ipv4Mode,omitempty"`
+    IPv6Mode   string        `yaml:"ipv6Mode,omitempty"`
+    DNS        *NodeDNS      `yaml:"dns,omitempty"`
+    // Options override the node options for this interface (spec.interfaces[].options)
+    Options    *NodeOptions  `yaml:"options,omitempty"`
+}
+
+// NodeOptions represents spec.options / spec.interfaces[].options (nil field = agent default)
+type NodeOptions struct {
+    PolicyRoutingEnabled *bool `yaml:"policyRoutingEnabled,omitempty"`
+    RoutingTableBase     *int  `yaml:"routingTableBase,omitempty"`
+    RouteMetric          *int  `yaml:"routeMetric,omitempty"`
+    UseNoPrefixRoute     *bool `yaml:"useNoPrefixRoute,omitempty"`
+    SetArpSysctls        *bool `yaml:"setArpSysctls,omitempty"`
+    SetLooseRPFilter     *bool `yaml:"setLooseRPFilter,omitempty"`
+}
+
+// MergeNodeOptions returns the options of one interface: fields set on the interface win over
+// the node-wide ones (nil when neither sets anything)
+func MergeNodeOptions(node, iface *NodeOptions) *NodeOptions {
+    if node == nil && iface == nil {
+        return nil
+    }
+    out := NodeOptions{}
+    for _, o := range []*NodeOptions{node, iface} {
+        if o == nil { continue }
+        if o.PolicyRoutingEnabled != nil { out.PolicyRoutingEnabled = o.PolicyRoutingEnabled }
+        if o.RoutingTableBase != nil { out.RoutingTableBase = o.RoutingTableBase }
+        if o.RouteMetric != nil { out.RouteMetric = o.RouteMetric }
+        if o.UseNoPrefixRoute != nil { out.UseNoPrefixRoute = o.UseNoPrefixRoute }
+        if o.SetArpSysctls != nil { out.SetArpSysctls = o.SetArpSysctls }
+        if o.SetLooseRPFilter != nil { out.SetLooseRPFilter = o.SetLooseRPFilter }
+    }
+    return &out
+}
+
+// NodeDNS represents spec.interfaces[].dns (resolvers contributed by the interface)
+type NodeDNS struct {
+    Servers []string `yaml:"servers,omitempty"`
+    Search  []string `yaml:"search,omitempty"`
+}
+
+// NodeVRF represents spec.interfaces[].vrf (table 0 = RoutingTableBase + index)
+type NodeVRF struct {
+    Name  string `yaml:"name"`
+    Table int    `yaml:"table,omitempty"`
+}
+
+// NodeBridge represents spec.interfaces[].bridge (Linux bridge with the interface as its port)
+type NodeBridge struct {
+    Name string `yaml:"name"`
+    STP  bool   `yaml:"stp,omitempty"`
+    // MoveIP defaults to true: addresses and routes are configured on the bridge
+    MoveIP *bool `yaml:"moveIP,omitempty"`
+}
+
+// NodeBond represents spec.interfaces[].bond (members are port MACs)
+type NodeBond struct {
+    Members        []string `yaml:"members"`
+    Mode           string   `yaml:"mode,omitempty"`
+    MIIMon         int      `yaml:"miimon,omitempty"`
+    XmitHashPolicy string   `yaml:"xmitHashPolicy,omitempty"`
+}
+
+// NodeVLAN represents one entry of spec.interfaces[].vlans (802.1Q child of the interface)
+type NodeVLAN struct {
+    ID      int    `yaml:"id"`
+    Name    string `yaml:"name,omitempty"`
+    Address string `yaml:"address,omitempty"`
+    CIDR    string `yaml:"cidr,omitempty"`
+    MTU     int    `yaml:"mtu,omitempty"`
+}
+
+// NodeRoute represents one entry of spec.interfaces[].routes
+type NodeRoute struct {
+    To     string `yaml:"to"`
+    Via    string `yaml:"via,omitempty"`
+    Metric int    `yaml:"metric,omitempty"`
+    Table  int    `yaml:"table,omitempty"`
+    OnLink bool   `yaml:"onlink,omitempty"`
+}
+
+// NodeAddress represents one entry of spec.interfaces[].addresses
+type NodeAddress struct {
+    Address string `yaml:"address"`
+    CIDR    string `yaml:"cidr"`
 }
 
 // NodeConfigSource abstracts how to obtain a node's CR spec (K8s, file, etc.)
@@ -93,28 +194,181 @@ func (r *NodeCRRepository) loadAll(ctx context.Context, nodeName string) ([]enti
 
     var out []entities.NetworkInterface
     for i, ni := range cfg.Interfaces {
-        id := ni.ID
-        if ni.Name != "" {
-            if parsed, err := entities.NewInterfaceName(ni.Name); err == nil {
-                id = parsed.Index()
+        ni.Options = MergeNodeOptions(cfg.Options, ni.Options)
+        ent, err := BuildNetworkInterface(i, cfg.NodeName, ni)
+        if err != nil {
+            r.logger.WithError(err).WithField("index", i).Warn("invalid interface entry in node config; skipping")
+            continue
+        }
+        // status defaults to pending
+        out = append(out, *ent)
+    }
+    return r.dropDuplicateVRFs(r.dropBondMemberConflicts(out)), nil
+}
+
+// BuildNetworkInterface maps the index-th spec entry to a validated entity. The agent uses it
+// to load the node config and the controller admission webhook to reject the same entries early.
+func BuildNetworkInterface(index int, nodeName string, ni NodeInterface) (*entities.NetworkInterface, error) {
+    id := ni.ID
+    if ni.Name != "" {
+        if parsed, err := entities.NewInterfaceName(ni.Name); err == nil {
+            id = parsed.Index()
+        }
+    }
+    if id == 0 && ni.Name == "" {
+        id = index + 1
+    }
+    // addresses[]만 지정된 경우 첫 항목을 기본 주소로 사용
+    primaryAddr, primaryCIDR, extra := ni.Address, ni.CIDR, ni.Addresses
+    if primaryAddr == "" && len(extra) > 0 {
+        primaryAddr, primaryCIDR, extra = extra[0].Address, extra[0].CIDR, extra[1:]
+    }
+    var ent *entities.NetworkInterface
+    var err error
+    if primaryAddr == "" && primaryCIDR == "" && (ni.IPv4Mode != "" || ni.IPv6Mode != "") {
+        // DHCP/disabled 인터페이스는 정적 주소 없이 만든다 (모드 검증은 applyInterfaceExtras에서)
+        ent, err = entities.NewNetworkInterfaceWithoutAddress(id, ni.Name, ni.MacAddress, nodeName, ni.MTU)
+    } else if ni.Name != "" {
+        ent, err = entities.NewNetworkInterfaceWithName(id, ni.Name, ni.MacAddress, nodeName, primaryAddr, primaryCIDR, ni.MTU)
+    } else {
+        ent, err = entities.NewNetworkInterface(id, ni.MacAddress, nodeName, primaryAddr, primaryCIDR, ni.MTU)
+    }
+    if err != nil {
+        return nil, err
+    }
+    if err := applyInterfaceExtras(ent, ni, extra); err != nil {
+        return nil, err
+    }
+    return ent, nil
+}
+
+// dropDuplicateVRFs keeps the first interface per VRF name: a VRF device is owned by exactly
+// one interface so that its persisted config and rollback stay per interface.
+func (r *NodeCRRepository) dropDuplicateVRFs(in []entities.NetworkInterface) []entities.NetworkInterface {
+    seen := map[string]int{}
+    out := make([]entities.NetworkInterface, 0, len(in))
+    for _, iface := range in {
+        if v := iface.VRF(); v != nil {
+            if owner, ok := seen[v.Name()]; ok {
+                r.logger.WithFields(logrus.Fields{"id": iface.ID(), "vrf": v.Name(), "owner_id": owner}).Warn("VRF already used by another interface; skipping")
+                continue
             }
+            seen[v.Name()] = iface.ID()
         }
-        if id == 0 && ni.Name == "" {
-            id = i + 1
+        out = append(out, iface)
+    }
+    return out
+}
+
+// dropBondMemberConflicts removes entries whose member MAC is already claimed by an earlier
+// bond, and standalone entries for a port that a bond enslaves (the bond wins).
+func (r *NodeCRRepository) dropBondMemberConflicts(in []entities.NetworkInterface) []entities.NetworkInterface {
+    claimed := map[string]string{} // member MAC -> bond interface name
+    skip := make([]bool, len(in))
+    for i, iface := range in {
+        if !iface.IsBond() {
+            continue
         }
-        var ent *entities.NetworkInterface
-        var err error
-        if ni.Name != "" {
-            ent, err = entities.NewNetworkInterfaceWithName(id, ni.Name, ni.MacAddress, cfg.NodeName, ni.Address, ni.CIDR, ni.MTU)
-        } else {
-            ent, err = entities.NewNetworkInterface(id, ni.MacAddress, cfg.NodeName, ni.Address, ni.CIDR, ni.MTU)
+        members := iface.Bond().Members()
+        for _, m := range members {
+            if owner, ok := claimed[m]; ok {
+                r.logger.WithFields(logrus.Fields{"id": iface.ID(), "mac": m, "bond": owner}).Warn("bond member already used by another bond; skipping")
+                skip[i] = true
+                break
+            }
         }
-        if err != nil {
-            r.logger.WithError(err).WithField("id", id).Warn("invalid interface entry in node config; skipping")
+        if skip[i] {
             continue
         }
-        // status defaults to pending
-        out = append(out, *ent)
+        for _, m := range members {
+            claimed[m] = iface.InterfaceName()
+        }
+    }
+
+    out := make([]entities.NetworkInterface, 0, len(in))
+    for i, iface := range in {
+        if skip[i] {
+            continue
+        }
+        if owner, ok := claimed[strings.ToLower(iface.MacAddress())]; ok && !iface.IsBond() {
+            r.logger.WithFields(logrus.Fields{"id": iface.ID(), "mac": iface.MacAddress(), "bond": owner}).Warn("interface is a member of a bond; skipping standalone entry")
+            continue
+        }
+        out = append(out, iface)
+    }
+    return out
+}
+
+// applyInterfaceExtras applies optional spec fields (secondary addresses, routes, gateway, VLANs, bond, bridge, VRF, DNS, options, address modes) to the entity
+func applyInterfaceExtras(ent *entities.NetworkInterface, ni NodeInterface, extra []NodeAddress) error {
+    for _, a := range extra {
+        if err := ent.AddAddress(a.Address, a.CIDR); err != nil {
+            return err
+        }
+    }
+    for _, rt := range ni.Routes {
+        if err := ent.AddRoute(rt.To, rt.Via, rt.Metric, rt.Table, rt.OnLink); err != nil {
+            return err
+        }
     }
-    return out, nil
+    if ni.Gateway != "" {
+        if err := ent.SetGateway(ni.Gateway); err != nil {
+            return err
+        }
+    }
+    for _, v := range ni.VLANs {
+        if err := ent.AddVLAN(v.ID, v.Name, v.Address, v.CIDR, v.MTU); err != nil {
+            return err
+        }
+    }
+    switch ni.Kind {
+    case "", "ethernet":
+        if ni.Bond != nil && ni.Kind == "ethernet" {
+            return errors.NewValidationError("bond settings require kind: bond", nil)
+        }
+    case "bond":
+        if ni.Bond == nil {
+            return errors.NewValidationError("kind: bond requires bond settings", nil)
+        }
+    default:
+        return errors.NewValidationError(fmt.Sprintf("unsupported interface kind %q", ni.Kind), nil)
+    }
+    if ni.Bond != nil {
+        if err := ent.SetBond(ni.Bond.Members, ni.Bond.Mode, ni.Bond.MIIMon, ni.Bond.XmitHashPolicy); err != nil {
+            return err
+        }
+    }
+    if ni.Bridge != nil {
+        moveIP := ni.Bridge.MoveIP == nil || *ni.Bridge.MoveIP
+        if err := ent.SetBridge(ni.Bridge.Name, ni.Bridge.STP, moveIP); err != nil {
+            return err
+        }
+    }
+    if ni.VRF != nil {
+        if err := ent.SetVRF(ni.VRF.Name, ni.VRF.Table); err != nil {
+            return err
+        }
+    }
+    if ni.DNS != nil {
+        if err := ent.SetDNS(ni.DNS.Servers, ni.DNS.Search); err != nil {
+            return err
+        }
+    }
+    if o := ni.Options; o != nil {
+        if err := ent.SetOptions(entities.NetworkOptions{
+            PolicyRouting:    o.PolicyRoutingEnabled,
+            RoutingTableBase: o.RoutingTableBase,
+            RouteMetric:      o.RouteMetric,
+            NoPrefixRoute:    o.UseNoPrefixRoute,
+            ArpSysctls:       o.SetArpSysctls,
+            LooseRPFilter:    o.SetLooseRPFilter,
+        }); err != nil {
+            return err
+        }
+    }
+    // 주소가 모두 추가된 뒤에 검증해야 dhcp 패밀리의 정적 주소 충돌을 잡을 수 있다
+    if err := ent.SetAddressModes(ni.IPv4Mode, ni.IPv6Mode); err != nil {
+        return err
+    }
+    return nil
 }
@@ -4,6 +4,9 @@ import (
     "context"
     "testing"
 
+    "multinic-agent/internal/domain/entities"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
     "github.com/sirupsen/logrus"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
@@ -49,6 +52,272 @@ func TestNodeCRRepository_MapsInterfaces_FromSource(t *testing.T) {
     assert.Equal(t, 1500, second.MTU())
 }
 
+func TestNodeCRRepository_MapsAddressesList(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            // address 필드 없이 addresses[]만 사용하는 경우
+            {ID: 1, MacAddress: "02:00:00:00:01:01", MTU: 1500, Addresses: []NodeAddress{
+                {Address: "192.168.100.10", CIDR: "192.168.100.0/24"},
+                {Address: "2001:db8:100::10", CIDR: "2001:db8:100::/64"},
+            }},
+            // address + 보조 VIP
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "192.168.200.10", CIDR: "192.168.200.0/24", MTU: 1500,
+                Addresses: []NodeAddress{{Address: "192.168.200.100", CIDR: "192.168.200.0/24"}}},
+            // 중복 주소는 잘못된 항목으로 건너뜀
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "192.168.250.10", CIDR: "192.168.250.0/24", MTU: 1500,
+                Addresses: []NodeAddress{{Address: "192.168.250.10", CIDR: "192.168.250.0/24"}}},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 2)
+
+    assert.Equal(t, "192.168.100.10", ifaces[0].Address())
+    require.Len(t, ifaces[0].Addresses(), 2)
+    assert.Equal(t, "2001:db8:100::10/64", ifaces[0].Addresses()[1].WithPrefix())
+
+    require.Len(t, ifaces[1].Addresses(), 2)
+    assert.Equal(t, "192.168.200.100/24", ifaces[1].Addresses()[1].WithPrefix())
+}
+
+func TestNodeCRRepository_MapsRoutesAndGateway(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "192.168.192.10", CIDR: "192.168.192.0/24", MTU: 1500,
+                Routes:  []NodeRoute{{To: "10.20.0.0/16", Via: "192.168.192.1", Table: 200, OnLink: true}},
+                Gateway: "192.168.192.1"},
+            // 잘못된 경로(주소 체계 불일치)는 항목 전체를 건너뜀
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "192.168.193.10", CIDR: "192.168.193.0/24", MTU: 1500,
+                Routes: []NodeRoute{{To: "2001:db8::/64", Via: "192.168.193.1"}}},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 1)
+
+    routes := ifaces[0].Routes()
+    require.Len(t, routes, 1)
+    assert.Equal(t, "10.20.0.0/16", routes[0].To())
+    assert.Equal(t, 200, routes[0].Table())
+    assert.True(t, routes[0].OnLink())
+    assert.Equal(t, "192.168.192.1", ifaces[0].Gateway())
+}
+
+func TestNodeCRRepository_MapsVLANs(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            {ID: 1, MacAddress: "02:00:00:00:01:01", Address: "192.168.100.10", CIDR: "192.168.100.0/24", MTU: 1500,
+                VLANs: []NodeVLAN{{ID: 100, Address: "10.100.0.10", CIDR: "10.100.0.0/24"}, {ID: 200, Name: "storage", MTU: 9000}}},
+            // 중복 VLAN ID는 항목 전체를 건너뜀
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "192.168.200.10", CIDR: "192.168.200.0/24", MTU: 1500,
+                VLANs: []NodeVLAN{{ID: 100}, {ID: 100}}},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 1)
+
+    vlans := ifaces[0].VLANs()
+    require.Len(t, vlans, 2)
+    assert.Equal(t, "multinic1.100", vlans[0].Name("multinic1"))
+    assert.Equal(t, "10.100.0.10/24", vlans[0].Addresses()[0].WithPrefix())
+    assert.Equal(t, "storage", vlans[1].Name("multinic1"))
+    assert.Equal(t, 9000, vlans[1].MTU())
+}
+
+func TestNodeCRRepository_MapsBonds(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            {ID: 1, MacAddress: "02:00:00:00:01:01", Address: "192.168.100.10", CIDR: "192.168.100.0/24", MTU: 9000,
+                Kind: "bond", Bond: &NodeBond{Members: []string{"02:00:00:00:01:01", "02:00:00:00:01:02"}, Mode: "802.3ad", XmitHashPolicy: "layer3+4"}},
+            // bond 멤버를 단독 인터페이스로 선언하면 bond가 우선
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "192.168.200.10", CIDR: "192.168.200.0/24", MTU: 1500},
+            // 다른 bond가 이미 사용 중인 멤버
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "192.168.30.10", CIDR: "192.168.30.0/24", MTU: 1500,
+                Kind: "bond", Bond: &NodeBond{Members: []string{"02:00:00:00:01:03", "02:00:00:00:01:02"}}},
+            // kind: bond 인데 bond 설정이 없음
+            {ID: 4, MacAddress: "02:00:00:00:01:04", Address: "192.168.40.10", CIDR: "192.168.40.0/24", MTU: 1500, Kind: "bond"},
+            {ID: 5, MacAddress: "02:00:00:00:01:05", Address: "192.168.50.10", CIDR: "192.168.50.0/24", MTU: 1500},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 2)
+
+    assert.True(t, ifaces[0].IsBond())
+    bond := ifaces[0].Bond()
+    assert.Equal(t, []string{"02:00:00:00:01:01", "02:00:00:00:01:02"}, bond.Members())
+    assert.Equal(t, "802.3ad", bond.Mode())
+    assert.Equal(t, 100, bond.MIIMon())
+    assert.Equal(t, "layer3+4", bond.XmitHashPolicy())
+    assert.Equal(t, 5, ifaces[1].ID())
+    assert.False(t, ifaces[1].IsBond())
+}
+
+func TestNodeCRRepository_MapsBridge(t *testing.T) {
+    t.Parallel()
+
+    keepIP := false
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            {ID: 1, MacAddress: "02:00:00:00:01:01", Address: "192.168.100.10", CIDR: "192.168.100.0/24", MTU: 1500,
+                Bridge: &NodeBridge{Name: "br-vm", STP: true}},
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "192.168.200.10", CIDR: "192.168.200.0/24", MTU: 1500,
+                Bridge: &NodeBridge{Name: "br-l2", MoveIP: &keepIP}},
+            // multinic 이름은 bridge로 사용할 수 없음
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "192.168.30.10", CIDR: "192.168.30.0/24", MTU: 1500,
+                Bridge: &NodeBridge{Name: "multinic7"}},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 2)
+
+    require.NotNil(t, ifaces[0].Bridge())
+    assert.True(t, ifaces[0].Bridge().STP())
+    assert.Equal(t, "br-vm", ifaces[0].AddressDevice("multinic1"))
+    assert.Equal(t, "multinic2", ifaces[1].AddressDevice("multinic2"))
+}
+
+func TestNodeCRRepository_MapsVRF(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            {ID: 1, MacAddress: "02:00:00:00:01:01", Address: "10.0.0.10", CIDR: "10.0.0.0/24", MTU: 1500,
+                VRF: &NodeVRF{Name: "vrf-a"}},
+            // 테넌트 CIDR이 겹쳐도 VRF가 다르면 허용
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "10.0.0.10", CIDR: "10.0.0.0/24", MTU: 1500,
+                VRF: &NodeVRF{Name: "vrf-b", Table: 2000}},
+            // 같은 VRF를 두 인터페이스가 사용할 수 없음
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "10.0.1.10", CIDR: "10.0.1.0/24", MTU: 1500,
+                VRF: &NodeVRF{Name: "vrf-a"}},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 2)
+    assert.Equal(t, "vrf-a", ifaces[0].VRF().Name())
+    assert.Equal(t, 0, ifaces[0].VRF().Table())
+    assert.Equal(t, 2000, ifaces[1].VRF().Table())
+}
+
+func TestNodeCRRepository_MapsAddressModes(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            // DHCP만 사용하는 인터페이스는 address/cidr 없이 허용
+            {ID: 1, MacAddress: "02:00:00:00:01:01", MTU: 1500, IPv4Mode: "dhcp", IPv6Mode: "disabled"},
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "2001:db8::10", CIDR: "2001:db8::/64", MTU: 1500, IPv4Mode: "dhcp"},
+            // DHCP 패밀리에 static 주소 → 제외
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "10.0.1.10", CIDR: "10.0.1.0/24", MTU: 1500, IPv4Mode: "dhcp"},
+            // 알 수 없는 모드 → 제외
+            {ID: 4, MacAddress: "02:00:00:00:01:04", Address: "10.0.2.10", CIDR: "10.0.2.0/24", MTU: 1500, IPv4Mode: "slaac"},
+            // 모드도 주소도 없으면 제외
+            {ID: 5, MacAddress: "02:00:00:00:01:05", MTU: 1500},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 2)
+    assert.Equal(t, entities.AddressModeDHCP, ifaces[0].IPv4Mode())
+    assert.Equal(t, entities.AddressModeDisabled, ifaces[0].IPv6Mode())
+    assert.Empty(t, ifaces[0].Address())
+    assert.True(t, ifaces[0].UsesDHCP())
+    assert.Equal(t, entities.AddressModeStatic, ifaces[1].IPv6Mode())
+    assert.Equal(t, "2001:db8::10", ifaces[1].Address())
+}
+
+func TestNodeCRRepository_MapsDNS(t *testing.T) {
+    t.Parallel()
+
+    src := &stubNodeSource{cfg: &NodeConfig{
+        NodeName: "worker-node-01",
+        Interfaces: []NodeInterface{
+            {ID: 1, MacAddress: "02:00:00:00:01:01", Address: "10.0.0.10", CIDR: "10.0.0.0/24", MTU: 1500,
+                DNS: &NodeDNS{Servers: []string{"10.0.0.53"}, Search: []string{"storage.example.com"}}},
+            // 잘못된 네임서버 → 제외
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "10.0.1.10", CIDR: "10.0.1.0/24", MTU: 1500,
+                DNS: &NodeDNS{Servers: []string{"ns1.example.com"}}},
+        },
+    }}
+    repo := NewNodeCRRepository(src, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 1)
+    require.NotNil(t, ifaces[0].DNS())
+    assert.Equal(t, []string{"10.0.0.53"}, ifaces[0].DNS().Servers())
+    assert.Equal(t, []string{"storage.example.com"}, ifaces[0].DNS().Search())
+}
+
+func TestNodeCRRepository_MapsOptions(t *testing.T) {
+    t.Parallel()
+
+    off, on := false, true
+    base, metric, badBase := int32(2000), int32(300), int32(0)
+    cr := &multinicv1alpha1.MultiNicNodeConfig{Spec: multinicv1alpha1.MultiNicNodeConfigSpec{
+        NodeName: "worker-node-01",
+        Options:  &multinicv1alpha1.NetworkOptions{RoutingTableBase: &base, PolicyRoutingEnabled: &on},
+        Interfaces: []multinicv1alpha1.InterfaceSpec{
+            {ID: 1, MacAddress: "02:00:00:00:01:01", Address: "10.0.0.10", CIDR: "10.0.0.0/24", MTU: 1500},
+            {ID: 2, MacAddress: "02:00:00:00:01:02", Address: "10.0.1.10", CIDR: "10.0.1.0/24", MTU: 1500,
+                Options: &multinicv1alpha1.NetworkOptions{PolicyRoutingEnabled: &off, RouteMetric: &metric}},
+            // 잘못된 테이블 기준값 → 제외
+            {ID: 3, MacAddress: "02:00:00:00:01:03", Address: "10.0.2.10", CIDR: "10.0.2.0/24", MTU: 1500,
+                Options: &multinicv1alpha1.NetworkOptions{RoutingTableBase: &badBase}},
+        },
+    }}
+    repo := NewNodeCRRepository(&stubNodeSource{cfg: NodeConfigFromAPI(cr)}, logrus.New())
+
+    ifaces, err := repo.GetAllNodeInterfaces(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    require.Len(t, ifaces, 2)
+
+    node := ifaces[0].Options()
+    require.NotNil(t, node)
+    assert.Equal(t, 2000, *node.RoutingTableBase)
+    assert.True(t, *node.PolicyRouting)
+    assert.Nil(t, node.RouteMetric)
+
+    // 인터페이스 값이 노드 값보다 우선하고, 지정하지 않은 필드는 노드 값을 물려받는다
+    iface := ifaces[1].Options()
+    require.NotNil(t, iface)
+    assert.False(t, *iface.PolicyRouting)
+    assert.Equal(t, 300, *iface.RouteMetric)
+    assert.Equal(t, 2000, *iface.RoutingTableBase)
+}
+
 func TestNodeCRRepository_UpdateInterfaceStatus_NoOp(t *testing.T) {
     t.Parallel()
 
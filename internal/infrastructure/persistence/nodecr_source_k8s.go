@@ -2,86 +2,127 @@ package persistence
 
 import (
     "context"
+    "encoding/json"
     "fmt"
 
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    "multinic-agent/pkg/generated/clientset/versioned"
+
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-    "k8s.io/apimachinery/pkg/runtime/schema"
-    "k8s.io/client-go/dynamic"
+    "k8s.io/apimachinery/pkg/types"
 )
 
-// K8sNodeConfigSource fetches MultiNicNodeConfig via Kubernetes Dynamic client
+// K8sNodeConfigSource fetches MultiNicNodeConfig via the typed multinic.io clientset
 type K8sNodeConfigSource struct {
-    client    dynamic.Interface
+    client    versioned.Interface
     namespace string
-    gvr       schema.GroupVersionResource
 }
 
 // NewK8sNodeConfigSource creates a K8s-backed NodeConfig source
-func NewK8sNodeConfigSource(client dynamic.Interface, namespace string) *K8sNodeConfigSource {
+func NewK8sNodeConfigSource(client versioned.Interface, namespace string) *K8sNodeConfigSource {
     return &K8sNodeConfigSource{
         client:    client,
         namespace: namespace,
-        gvr: schema.GroupVersionResource{
-            Group:    "multinic.io",
-            Version:  "v1alpha1",
-            Resource: "multinicnodeconfigs",
-        },
     }
 }
 
 func (s *K8sNodeConfigSource) GetNodeConfig(ctx context.Context, nodeName string) (*NodeConfig, error) {
-    u, err := s.client.Resource(s.gvr).Namespace(s.namespace).Get(ctx, nodeName, metav1.GetOptions{})
+    cr, err := s.client.MultinicV1alpha1().MultiNicNodeConfigs(s.namespace).Get(ctx, nodeName, metav1.GetOptions{})
     if err != nil {
         return nil, fmt.Errorf("failed to get MultiNicNodeConfig %s/%s: %w", s.namespace, nodeName, err)
     }
 
-    return unstructuredToNodeConfig(u), nil
+    return NodeConfigFromAPI(cr), nil
 }
 
-func unstructuredToNodeConfig(u *unstructured.Unstructured) *NodeConfig {
+// NodeConfigFromAPI converts the API object into the agent NodeConfig (metadata.name is the default node name)
+func NodeConfigFromAPI(cr *multinicv1alpha1.MultiNicNodeConfig) *NodeConfig {
     cfg := &NodeConfig{}
     // default node name from metadata.name
-    cfg.NodeName = u.GetName()
-
-    spec, _, _ := unstructured.NestedMap(u.Object, "spec")
-    if v, ok := spec["nodeName"].(string); ok && v != "" {
-        cfg.NodeName = v
+    cfg.NodeName = cr.Name
+    if cr.Spec.NodeName != "" {
+        cfg.NodeName = cr.Spec.NodeName
     }
+    cfg.Options = nodeOptionsFromAPI(cr.Spec.Options)
 
-    ifaces, _, _ := unstructured.NestedSlice(u.Object, "spec", "interfaces")
-    for _, it := range ifaces {
-        m, ok := it.(map[string]any)
-        if !ok {
-            continue
+    for _, it := range cr.Spec.Interfaces {
+        ni := NodeInterface{
+            ID:         int(it.ID),
+            PortID:     it.PortID,
+            Name:       it.Name,
+            MacAddress: it.MacAddress,
+            Address:    it.Address,
+            CIDR:       it.CIDR,
+            MTU:        int(it.MTU),
+            Gateway:    it.Gateway,
+            Kind:       it.Kind,
+            IPv4Mode:   it.IPv4Mode,
+            IPv6Mode:   it.IPv6Mode,
         }
-        ni := NodeInterface{}
-        if v, ok := m["id"].(int64); ok {
-            ni.ID = int(v)
-        } else if v, ok := m["id"].(int); ok {
-            ni.ID = v
+        for _, a := range it.Addresses {
+            ni.Addresses = append(ni.Addresses, NodeAddress{Address: a.Address, CIDR: a.CIDR})
         }
-        if v, ok := m["portId"].(string); ok {
-            ni.PortID = v
+        for _, r := range it.Routes {
+            ni.Routes = append(ni.Routes, NodeRoute{To: r.To, Via: r.Via, Metric: int(r.Metric), Table: int(r.Table), OnLink: r.OnLink})
         }
-        if v, ok := m["name"].(string); ok {
-            ni.Name = v
+        for _, v := range it.VLANs {
+            ni.VLANs = append(ni.VLANs, NodeVLAN{ID: int(v.ID), Name: v.Name, Address: v.Address, CIDR: v.CIDR, MTU: int(v.MTU)})
         }
-        if v, ok := m["macAddress"].(string); ok {
-            ni.MacAddress = v
+        if it.Bond != nil {
+            ni.Bond = &NodeBond{
+                Members:        append([]string(nil), it.Bond.Members...),
+                Mode:           it.Bond.Mode,
+                MIIMon:         int(it.Bond.MIIMon),
+                XmitHashPolicy: it.Bond.XmitHashPolicy,
+            }
         }
-        if v, ok := m["address"].(string); ok {
-            ni.Address = v
+        if it.Bridge != nil {
+            nbr := &NodeBridge{Name: it.Bridge.Name, STP: it.Bridge.STP}
+            if it.Bridge.MoveIP != nil {
+                v := *it.Bridge.MoveIP
+                nbr.MoveIP = &v
+            }
+            ni.Bridge = nbr
         }
-        if v, ok := m["cidr"].(string); ok {
-            ni.CIDR = v
+        if it.VRF != nil {
+            ni.VRF = &NodeVRF{Name: it.VRF.Name, Table: int(it.VRF.Table)}
         }
-        if v, ok := m["mtu"].(int64); ok {
-            ni.MTU = int(v)
-        } else if v, ok := m["mtu"].(int); ok {
-            ni.MTU = v
+        if it.DNS != nil {
+            ni.DNS = &NodeDNS{Servers: append([]string(nil), it.DNS.Servers...), Search: append([]string(nil), it.DNS.Search...)}
         }
+        ni.Options = nodeOptionsFromAPI(it.Options)
         cfg.Interfaces = append(cfg.Interfaces, ni)
     }
     return cfg
 }
+
+func nodeOptionsFromAPI(o *multinicv1alpha1.NetworkOptions) *NodeOptions {
+    if o == nil {
+        return nil
+    }
+    out := &NodeOptions{}
+    if o.PolicyRoutingEnabled != nil { v := *o.PolicyRoutingEnabled; out.PolicyRoutingEnabled = &v }
+    if o.RoutingTableBase != nil { v := int(*o.RoutingTableBase); out.RoutingTableBase = &v }
+    if o.RouteMetric != nil { v := int(*o.RouteMetric); out.RouteMetric = &v }
+    if o.UseNoPrefixRoute != nil { v := *o.UseNoPrefixRoute; out.UseNoPrefixRoute = &v }
+    if o.SetArpSysctls != nil { v := *o.SetArpSysctls; out.SetArpSysctls = &v }
+    if o.SetLooseRPFilter != nil { v := *o.SetLooseRPFilter; out.SetLooseRPFilter = &v }
+    return out
+}
+
+// ReportDrift stores the verify report of nodeName on its MultiNicNodeConfig annotation.
+// A merge patch on metadata only, so the controller-owned spec/status are never overwritten.
+func (s *K8sNodeConfigSource) ReportDrift(ctx context.Context, nodeName string, report []byte) error {
+    patch, err := json.Marshal(map[string]any{
+        "metadata": map[string]any{
+            "annotations": map[string]string{multinicv1alpha1.DriftReportAnnotation: string(report)},
+        },
+    })
+    if err != nil {
+        return err
+    }
+    if _, err := s.client.MultinicV1alpha1().MultiNicNodeConfigs(s.namespace).Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+        return fmt.Errorf("failed to report drift on MultiNicNodeConfig %s/%s: %w", s.namespace, nodeName, err)
+    }
+    return nil
+}
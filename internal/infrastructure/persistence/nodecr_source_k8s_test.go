@@ -6,51 +6,65 @@ import (
 
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-    "k8s.io/apimachinery/pkg/runtime"
-    "k8s.io/apimachinery/pkg/runtime/schema"
-    dynamicfake "k8s.io/client-go/dynamic/fake"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+    multinicfake "multinic-agent/pkg/generated/clientset/versioned/fake"
 )
 
 func TestK8sNodeConfigSource_GetNodeConfig_ParsesSpec(t *testing.T) {
-    scheme := runtime.NewScheme()
-    gvk := schema.GroupVersionKind{Group: "multinic.io", Version: "v1alpha1", Kind: "MultiNicNodeConfig"}
-
-    u := &unstructured.Unstructured{
-        Object: map[string]interface{}{
-            "apiVersion": "multinic.io/v1alpha1",
-            "kind":       "MultiNicNodeConfig",
-            "metadata": map[string]interface{}{
-                "name":      "worker-node-01",
-                "namespace": "multinic-system",
-            },
-            "spec": map[string]interface{}{
-                "nodeName": "worker-node-01",
-                "interfaces": []interface{}{
-                    map[string]interface{}{
-                        "id":         int64(1),
-                        "macAddress": "02:00:00:00:01:01",
-                        "address":    "192.168.100.10",
-                        "cidr":       "192.168.100.10/24",
-                        "mtu":        int64(1500),
+    moveIP := false
+    cr := &multinicv1alpha1.MultiNicNodeConfig{
+        ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01", Namespace: "multinic-system"},
+        Spec: multinicv1alpha1.MultiNicNodeConfigSpec{
+            NodeName: "worker-node-01",
+            Interfaces: []multinicv1alpha1.InterfaceSpec{
+                {
+                    ID:         1,
+                    MacAddress: "02:00:00:00:01:01",
+                    Address:    "192.168.100.10",
+                    CIDR:       "192.168.100.10/24",
+                    MTU:        1500,
+                    Kind:       "bond",
+                    Bond: &multinicv1alpha1.BondSpec{
+                        Members:        []string{"02:00:00:00:01:01", "02:00:00:00:01:11"},
+                        Mode:           "802.3ad",
+                        MIIMon:         200,
+                        XmitHashPolicy: "layer3+4",
+                    },
+                    Bridge:   &multinicv1alpha1.BridgeSpec{Name: "br-vm", STP: true, MoveIP: &moveIP},
+                    VRF:      &multinicv1alpha1.VRFSpec{Name: "vrf-tenant", Table: 1001},
+                    IPv6Mode: "disabled",
+                    DNS: &multinicv1alpha1.DNSSpec{
+                        Servers: []string{"10.0.0.53", "10.0.1.53"},
+                        Search:  []string{"storage.example.com"},
+                    },
+                },
+                {
+                    ID:         2,
+                    MacAddress: "02:00:00:00:01:02",
+                    Address:    "192.168.200.10",
+                    CIDR:       "192.168.200.10/24",
+                    MTU:        1500,
+                    Addresses: []multinicv1alpha1.AddressSpec{
+                        {Address: "2001:db8:200::10", CIDR: "2001:db8:200::/64"},
+                    },
+                    Routes: []multinicv1alpha1.RouteSpec{
+                        {To: "10.20.0.0/16", Via: "192.168.200.1", Metric: 50, OnLink: true},
                     },
-                    map[string]interface{}{
-                        "id":         int64(2),
-                        "macAddress": "02:00:00:00:01:02",
-                        "address":    "192.168.200.10",
-                        "cidr":       "192.168.200.10/24",
-                        "mtu":        int64(1500),
+                    Gateway: "192.168.200.1",
+                    VLANs: []multinicv1alpha1.VLANSpec{
+                        {ID: 100, Address: "10.100.0.10", CIDR: "10.100.0.0/24", MTU: 1400},
+                        {ID: 200, Name: "storage"},
                     },
                 },
             },
         },
     }
-    u.SetGroupVersionKind(gvk)
-    u.SetNamespace("multinic-system")
 
-    dyn := dynamicfake.NewSimpleDynamicClient(scheme, u)
+    client := multinicfake.NewSimpleClientset(cr)
 
-    src := NewK8sNodeConfigSource(dyn, "multinic-system")
+    src := NewK8sNodeConfigSource(client, "multinic-system")
 
     cfg, err := src.GetNodeConfig(context.Background(), "worker-node-01")
     require.NoError(t, err)
@@ -58,5 +72,48 @@ func TestK8sNodeConfigSource_GetNodeConfig_ParsesSpec(t *testing.T) {
     assert.Equal(t, "worker-node-01", cfg.NodeName)
     require.Len(t, cfg.Interfaces, 2)
     assert.Equal(t, "02:00:00:00:01:01", cfg.Interfaces[0].MacAddress)
+    assert.Equal(t, "bond", cfg.Interfaces[0].Kind)
+    assert.Equal(t, &NodeBond{
+        Members:        []string{"02:00:00:00:01:01", "02:00:00:00:01:11"},
+        Mode:           "802.3ad",
+        MIIMon:         200,
+        XmitHashPolicy: "layer3+4",
+    }, cfg.Interfaces[0].Bond)
+    require.NotNil(t, cfg.Interfaces[0].Bridge)
+    assert.Equal(t, "br-vm", cfg.Interfaces[0].Bridge.Name)
+    assert.True(t, cfg.Interfaces[0].Bridge.STP)
+    require.NotNil(t, cfg.Interfaces[0].Bridge.MoveIP)
+    assert.False(t, *cfg.Interfaces[0].Bridge.MoveIP)
+    assert.Equal(t, &NodeVRF{Name: "vrf-tenant", Table: 1001}, cfg.Interfaces[0].VRF)
+    assert.Equal(t, "disabled", cfg.Interfaces[0].IPv6Mode)
+    assert.Empty(t, cfg.Interfaces[0].IPv4Mode)
+    assert.Equal(t, &NodeDNS{Servers: []string{"10.0.0.53", "10.0.1.53"}, Search: []string{"storage.example.com"}}, cfg.Interfaces[0].DNS)
+    assert.Nil(t, cfg.Interfaces[1].DNS)
     assert.Equal(t, "192.168.200.10", cfg.Interfaces[1].Address)
+    require.Len(t, cfg.Interfaces[1].Addresses, 1)
+    assert.Equal(t, NodeAddress{Address: "2001:db8:200::10", CIDR: "2001:db8:200::/64"}, cfg.Interfaces[1].Addresses[0])
+    assert.Equal(t, []NodeRoute{{To: "10.20.0.0/16", Via: "192.168.200.1", Metric: 50, OnLink: true}}, cfg.Interfaces[1].Routes)
+    assert.Equal(t, "192.168.200.1", cfg.Interfaces[1].Gateway)
+    assert.Equal(t, []NodeVLAN{
+        {ID: 100, Address: "10.100.0.10", CIDR: "10.100.0.0/24", MTU: 1400},
+        {ID: 200, Name: "storage"},
+    }, cfg.Interfaces[1].VLANs)
+}
+
+func TestK8sNodeConfigSource_ReportDrift_SetsAnnotation(t *testing.T) {
+    cr := &multinicv1alpha1.MultiNicNodeConfig{
+        ObjectMeta: metav1.ObjectMeta{Name: "worker-node-01", Namespace: "multinic-system", Annotations: map[string]string{"keep": "me"}},
+        Spec:       multinicv1alpha1.MultiNicNodeConfigSpec{NodeName: "worker-node-01"},
+    }
+    client := multinicfake.NewSimpleClientset(cr)
+    src := NewK8sNodeConfigSource(client, "multinic-system")
+
+    report := `{"drifts":[],"timestamp":"2026-10-16T12:00:00Z"}`
+    require.NoError(t, src.ReportDrift(context.Background(), "worker-node-01", []byte(report)))
+
+    got, err := client.MultinicV1alpha1().MultiNicNodeConfigs("multinic-system").Get(context.Background(), "worker-node-01", metav1.GetOptions{})
+    require.NoError(t, err)
+    assert.Equal(t, report, got.Annotations[multinicv1alpha1.DriftReportAnnotation])
+    assert.Equal(t, "me", got.Annotations["keep"])
+    assert.Equal(t, "worker-node-01", got.Spec.NodeName)
 }
@@ -0,0 +1,35 @@
+package persistence
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+
+    multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StaticNodeConfigSource serves a spec handed over by the controller (NODE_CONFIG_SPEC).
+// The controller passes the effective spec when cluster policy defaults were merged into
+// the node config, so the agent must not re-read the raw CR.
+type StaticNodeConfigSource struct {
+    spec multinicv1alpha1.MultiNicNodeConfigSpec
+}
+
+// NewStaticNodeConfigSource decodes a JSON MultiNicNodeConfig spec
+func NewStaticNodeConfigSource(specJSON string) (*StaticNodeConfigSource, error) {
+    s := &StaticNodeConfigSource{}
+    if err := json.Unmarshal([]byte(specJSON), &s.spec); err != nil {
+        return nil, fmt.Errorf("failed to decode node config spec: %w", err)
+    }
+    return s, nil
+}
+
+func (s *StaticNodeConfigSource) GetNodeConfig(ctx context.Context, nodeName string) (*NodeConfig, error) {
+    if s.spec.NodeName != "" && s.spec.NodeName != nodeName {
+        return nil, fmt.Errorf("node config spec targets node %s, not %s", s.spec.NodeName, nodeName)
+    }
+    cr := &multinicv1alpha1.MultiNicNodeConfig{ObjectMeta: metav1.ObjectMeta{Name: nodeName}, Spec: s.spec}
+    return NodeConfigFromAPI(cr), nil
+}
@@ -0,0 +1,28 @@
+package persistence
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestStaticNodeConfigSource_GetNodeConfig(t *testing.T) {
+    src, err := NewStaticNodeConfigSource(`{"nodeName":"worker-node-01","interfaces":[{"macAddress":"02:00:00:00:01:01","address":"10.0.0.10","cidr":"10.0.0.0/24","mtu":9000,"dns":{"servers":["10.0.0.53"]}}]}`)
+    require.NoError(t, err)
+
+    cfg, err := src.GetNodeConfig(context.Background(), "worker-node-01")
+    require.NoError(t, err)
+    assert.Equal(t, "worker-node-01", cfg.NodeName)
+    require.Len(t, cfg.Interfaces, 1)
+    assert.Equal(t, 9000, cfg.Interfaces[0].MTU)
+    require.NotNil(t, cfg.Interfaces[0].DNS)
+    assert.Equal(t, []string{"10.0.0.53"}, cfg.Interfaces[0].DNS.Servers)
+
+    _, err = src.GetNodeConfig(context.Background(), "worker-node-02")
+    assert.Error(t, err)
+
+    _, err = NewStaticNodeConfigSource("{")
+    assert.Error(t, err)
+}
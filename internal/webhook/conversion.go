@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+	multinicv1beta1 "multinic-agent/pkg/apis/multinic/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// conversionReview mirrors apiextensions.k8s.io/v1 ConversionReview (only the fields the
+// API server sends/reads), so the controller does not depend on the apiextensions module.
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// ConversionHandler converts MultiNicNodeConfig objects between v1alpha1 and v1beta1
+type ConversionHandler struct{}
+
+// NewConversionHandler creates the CRD conversion webhook handler
+func NewConversionHandler() *ConversionHandler {
+	return &ConversionHandler{}
+}
+
+func (h *ConversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var review conversionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+		http.Error(w, "invalid ConversionReview", http.StatusBadRequest)
+		return
+	}
+	req := review.Request
+	resp := &conversionResponse{UID: req.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+	for i := range req.Objects {
+		out, err := ConvertObject(req.Objects[i].Raw, req.DesiredAPIVersion)
+		if err != nil {
+			log.Printf("conversion to %s failed: %v", req.DesiredAPIVersion, err)
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: out})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(conversionReview{TypeMeta: review.TypeMeta, Response: resp})
+}
+
+// ConvertObject converts one serialized MultiNicNodeConfig to desiredAPIVersion
+func ConvertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var meta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+	if meta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	alpha := multinicv1alpha1.SchemeGroupVersion.String()
+	beta := multinicv1beta1.SchemeGroupVersion.String()
+	switch {
+	case meta.APIVersion == alpha && desiredAPIVersion == beta:
+		var src multinicv1alpha1.MultiNicNodeConfig
+		if err := json.Unmarshal(raw, &src); err != nil {
+			return nil, err
+		}
+		var dst multinicv1beta1.MultiNicNodeConfig
+		if err := src.ConvertTo(&dst); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&dst)
+	case meta.APIVersion == beta && desiredAPIVersion == alpha:
+		var src multinicv1beta1.MultiNicNodeConfig
+		if err := json.Unmarshal(raw, &src); err != nil {
+			return nil, err
+		}
+		var dst multinicv1alpha1.MultiNicNodeConfig
+		if err := dst.ConvertFrom(&src); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&dst)
+	}
+	return nil, fmt.Errorf("unsupported conversion %s -> %s", meta.APIVersion, desiredAPIVersion)
+}
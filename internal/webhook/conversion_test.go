@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const alphaObject = `{
+  "apiVersion": "multinic.io/v1alpha1",
+  "kind": "MultiNicNodeConfig",
+  "metadata": {"name": "worker-1", "namespace": "multinic-system"},
+  "spec": {
+    "nodeName": "worker-1",
+    "interfaces": [{"id": 1, "macAddress": "fa:16:3e:00:00:01", "address": "10.0.0.10", "cidr": "10.0.0.0/24"}]
+  }
+}`
+
+func review(t *testing.T, desired string, objects ...string) *conversionReview {
+	t.Helper()
+	body := conversionReview{Request: &conversionRequest{UID: "req-1", DesiredAPIVersion: desired}}
+	body.APIVersion, body.Kind = "apiextensions.k8s.io/v1", "ConversionReview"
+	for _, o := range objects {
+		body.Request.Objects = append(body.Request.Objects, runtime.RawExtension{Raw: []byte(o)})
+	}
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	NewConversionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(raw)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var out conversionReview
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	require.NotNil(t, out.Response)
+	assert.Equal(t, "req-1", string(out.Response.UID))
+	return &out
+}
+
+func TestConversionHandler_AlphaToBeta(t *testing.T) {
+	out := review(t, "multinic.io/v1beta1", alphaObject)
+	require.Equal(t, "Success", out.Response.Result.Status)
+	require.Len(t, out.Response.ConvertedObjects, 1)
+
+	var obj map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Response.ConvertedObjects[0].Raw, &obj))
+	assert.Equal(t, "multinic.io/v1beta1", obj["apiVersion"])
+	iface := obj["spec"].(map[string]interface{})["interfaces"].([]interface{})[0].(map[string]interface{})
+	assert.NotContains(t, iface, "address")
+	addrs := iface["addresses"].([]interface{})
+	assert.Equal(t, "10.0.0.10", addrs[0].(map[string]interface{})["address"])
+}
+
+func TestConversionHandler_SameVersionPassesThrough(t *testing.T) {
+	out := review(t, "multinic.io/v1alpha1", alphaObject)
+	require.Len(t, out.Response.ConvertedObjects, 1)
+	assert.JSONEq(t, alphaObject, string(out.Response.ConvertedObjects[0].Raw))
+}
+
+func TestConversionHandler_UnsupportedVersionFails(t *testing.T) {
+	out := review(t, "multinic.io/v2", alphaObject)
+	assert.Equal(t, "Failure", out.Response.Result.Status)
+	assert.Empty(t, out.Response.ConvertedObjects)
+}
+
+func TestConversionHandler_BadRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewConversionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader([]byte("{"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+)
+
+// Server serves the controller webhooks (CRD conversion, admission) over HTTPS.
+// The serving certificate is read from CertDir (kubernetes.io/tls secret layout) and
+// reloaded when the file changes, so cert-manager rotations need no restart.
+type Server struct {
+	Port    int
+	CertDir string
+
+	mux *http.ServeMux
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// NewServer creates a webhook server listening on port
+func NewServer(port int, certDir string) *Server {
+	return &Server{Port: port, CertDir: certDir, mux: http.NewServeMux()}
+}
+
+// Handle registers a webhook handler on path
+func (s *Server) Handle(path string, h http.Handler) {
+	s.mux.Handle(path, h)
+}
+
+// CertsAvailable reports whether the serving certificate is mounted
+func (s *Server) CertsAvailable() bool {
+	_, err := os.Stat(filepath.Join(s.CertDir, certFile))
+	return err == nil
+}
+
+// Start serves until ctx is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	if _, err := s.getCertificate(nil); err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.Port),
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.getCertificate,
+		},
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	log.Printf("webhook server listening on :%d (certs: %s)", s.Port, s.CertDir)
+	if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// getCertificate returns the cached key pair, reloading it when tls.crt was modified
+func (s *Server) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	crt := filepath.Join(s.CertDir, certFile)
+	info, err := os.Stat(crt)
+	if err != nil {
+		return nil, fmt.Errorf("webhook certificate not found: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cert != nil && info.ModTime().Equal(s.modTime) {
+		return s.cert, nil
+	}
+	pair, err := tls.LoadX509KeyPair(crt, filepath.Join(s.CertDir, keyFile))
+	if err != nil {
+		if s.cert != nil {
+			// a rotation may be half written; keep serving the previous pair
+			return s.cert, nil
+		}
+		return nil, fmt.Errorf("failed to load webhook certificate: %w", err)
+	}
+	s.cert, s.modTime = &pair, info.ModTime()
+	return s.cert, nil
+}
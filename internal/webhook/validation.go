@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"multinic-agent/internal/domain/entities"
+	domainerrors "multinic-agent/internal/domain/errors"
+	"multinic-agent/internal/infrastructure/persistence"
+	multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+	multinicv1beta1 "multinic-agent/pkg/apis/multinic/v1beta1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// policyMTUPlaceholder stands in for an unset mtu while the rest of the entry is validated
+const policyMTUPlaceholder = 1500
+
+// ValidationHandler rejects MultiNicNodeConfig specs that the agent would skip or fail on the
+// node. It is registered for v1alpha1 with matchPolicy Equivalent, so the API server converts
+// v1beta1 requests before calling it.
+type ValidationHandler struct{}
+
+// NewValidationHandler creates the validating admission webhook handler
+func NewValidationHandler() *ValidationHandler {
+	return &ValidationHandler{}
+}
+
+func (h *ValidationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+		http.Error(w, "invalid AdmissionReview", http.StatusBadRequest)
+		return
+	}
+	resp := h.admit(review.Request)
+	resp.UID = review.Request.UID
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(admissionv1.AdmissionReview{TypeMeta: review.TypeMeta, Response: resp})
+}
+
+func (h *ValidationHandler) admit(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	cr, err := decodeNodeConfig(req.Object.Raw)
+	if err != nil {
+		return denied(metav1.StatusReasonBadRequest, http.StatusBadRequest, err.Error())
+	}
+	// finalizer removal and metadata-only edits must not be blocked by a spec that was
+	// accepted before (or written before the webhook existed)
+	if cr.DeletionTimestamp != nil {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		if old, err := decodeNodeConfig(req.OldObject.Raw); err == nil && equality.Semantic.DeepEqual(old.Spec, cr.Spec) {
+			return &admissionv1.AdmissionResponse{Allowed: true}
+		}
+	}
+
+	if errs := ValidateNodeConfig(cr); len(errs) > 0 {
+		msgs := make([]string, 0, len(errs))
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+		log.Printf("rejected MultiNicNodeConfig %s/%s: %s", cr.Namespace, cr.Name, strings.Join(msgs, "; "))
+		return denied(metav1.StatusReasonInvalid, http.StatusUnprocessableEntity, strings.Join(msgs, "; "))
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func denied(reason metav1.StatusReason, code int32, msg string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result:  &metav1.Status{Status: metav1.StatusFailure, Reason: reason, Code: code, Message: msg},
+	}
+}
+
+// decodeNodeConfig reads the admitted object as v1alpha1 (converting a v1beta1 payload)
+func decodeNodeConfig(raw []byte) (*multinicv1alpha1.MultiNicNodeConfig, error) {
+	var meta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+	cr := &multinicv1alpha1.MultiNicNodeConfig{}
+	if meta.APIVersion == multinicv1beta1.SchemeGroupVersion.String() {
+		var beta multinicv1beta1.MultiNicNodeConfig
+		if err := json.Unmarshal(raw, &beta); err != nil {
+			return nil, err
+		}
+		return cr, cr.ConvertFrom(&beta)
+	}
+	return cr, json.Unmarshal(raw, cr)
+}
+
+// ValidateNodeConfig runs the agent's entity validation on every interface entry and the
+// checks that span entries (MACs, device names, addresses, node name). Errors carry VAL codes.
+func ValidateNodeConfig(cr *multinicv1alpha1.MultiNicNodeConfig) []error {
+	var errs []error
+	cfg := persistence.NodeConfigFromAPI(cr)
+	if cr.Name != "" && cr.Spec.NodeName != "" && cr.Spec.NodeName != cr.Name {
+		errs = append(errs, domainerrors.NewValidationErrorWithCode("VAL039",
+			fmt.Sprintf("spec.nodeName %q must match metadata.name %q", cr.Spec.NodeName, cr.Name), nil))
+	}
+	if mw := cr.Spec.MaintenanceWindow; mw != nil {
+		if _, err := entities.NewMaintenanceWindow(mw.Schedule, mw.Duration, mw.TimeZone); err != nil {
+			errs = append(errs, fmt.Errorf("spec.maintenanceWindow: %w", err))
+		}
+	}
+	if dp := cr.Spec.DisruptionPolicy; dp != nil && dp.EvictPodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(dp.EvictPodSelector); err != nil {
+			errs = append(errs, domainerrors.NewValidationErrorWithCode("VAL044",
+				fmt.Sprintf("spec.disruptionPolicy.evictPodSelector is invalid: %v", err), nil))
+		}
+	}
+
+	macs := map[string]int{}
+	names := map[string]int{}
+	addrs := map[string]int{}
+	claim := func(seen map[string]int, key string, i int, code, what string) {
+		if key == "" {
+			return
+		}
+		if owner, ok := seen[key]; ok {
+			if owner != i {
+				errs = append(errs, domainerrors.NewValidationErrorWithCode(code,
+					fmt.Sprintf("interfaces[%d]: duplicate %s %s (already used by interfaces[%d])", i, what, key, owner), nil))
+			}
+			return
+		}
+		seen[key] = i
+	}
+
+	for i, ni := range cfg.Interfaces {
+		if ni.MTU == 0 {
+			// a MultiNicClusterPolicy may supply the MTU; the controller merges it before the Job runs
+			ni.MTU = policyMTUPlaceholder
+		}
+		ni.Options = persistence.MergeNodeOptions(cfg.Options, ni.Options)
+		ent, err := persistence.BuildNetworkInterface(i, cfg.NodeName, ni)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("interfaces[%d]: %w", i, err))
+			continue
+		}
+
+		// a bond owns its member ports, so they count as MACs of this entry
+		claim(macs, canonicalMAC(ent.MacAddress()), i, "VAL036", "MAC address")
+		if ent.IsBond() {
+			for _, m := range ent.Bond().Members() {
+				claim(macs, canonicalMAC(m), i, "VAL036", "MAC address")
+			}
+		}
+
+		// interface, VLAN, bridge and VRF names share the node's device namespace
+		claim(names, ent.InterfaceName(), i, "VAL037", "device name")
+		for _, v := range ent.VLANs() {
+			claim(names, v.Name(ent.InterfaceName()), i, "VAL037", "device name")
+		}
+		if br := ent.Bridge(); br != nil {
+			claim(names, br.Name(), i, "VAL037", "device name")
+		}
+		if vrf := ent.VRF(); vrf != nil {
+			claim(names, vrf.Name(), i, "VAL037", "device name")
+		}
+
+		for _, a := range ent.Addresses() {
+			claim(addrs, a.Address(), i, "VAL038", "address")
+		}
+		for _, v := range ent.VLANs() {
+			for _, a := range v.Addresses() {
+				claim(addrs, a.Address(), i, "VAL038", "address")
+			}
+		}
+	}
+	return errs
+}
+
+func canonicalMAC(mac string) string {
+	parsed, err := entities.NewMACAddress(mac)
+	if err != nil {
+		return strings.ToLower(mac)
+	}
+	return parsed.Canonical()
+}
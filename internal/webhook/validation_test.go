@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	multinicv1alpha1 "multinic-agent/pkg/apis/multinic/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func nodeConfig(ifaces ...multinicv1alpha1.InterfaceSpec) *multinicv1alpha1.MultiNicNodeConfig {
+	return &multinicv1alpha1.MultiNicNodeConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "multinic.io/v1alpha1", Kind: "MultiNicNodeConfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "multinic-system"},
+		Spec:       multinicv1alpha1.MultiNicNodeConfigSpec{NodeName: "worker-1", Interfaces: ifaces},
+	}
+}
+
+func TestValidateNodeConfig(t *testing.T) {
+	eth0 := multinicv1alpha1.InterfaceSpec{ID: 0, Name: "multinic0", MacAddress: "fa:16:3e:00:00:01", Address: "10.0.0.10", CIDR: "10.0.0.0/24", MTU: 1450}
+	eth1 := multinicv1alpha1.InterfaceSpec{ID: 1, Name: "multinic1", MacAddress: "fa:16:3e:00:00:02", Address: "10.0.1.10", CIDR: "10.0.1.0/24", MTU: 1450}
+
+	tests := []struct {
+		name   string
+		mutate func(cr *multinicv1alpha1.MultiNicNodeConfig)
+		code   string
+	}{
+		{name: "valid", mutate: func(*multinicv1alpha1.MultiNicNodeConfig) {}},
+		{
+			name:   "address outside cidr",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.Interfaces[1].Address = "10.0.9.10" },
+			code:   "VAL015",
+		},
+		{
+			name:   "invalid mac",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.Interfaces[1].MacAddress = "zz:16:3e:00:00:02" },
+			code:   "VAL005",
+		},
+		{
+			name:   "mtu too small",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.Interfaces[1].MTU = 10 },
+			code:   "VAL008",
+		},
+		{
+			name:   "mtu left to cluster policy",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.Interfaces[1].MTU = 0 },
+		},
+		{
+			name:   "duplicate mac (case insensitive)",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.Interfaces[1].MacAddress = "FA:16:3E:00:00:01" },
+			code:   "VAL036",
+		},
+		{
+			name:   "duplicate name",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.Interfaces[1].Name = "multinic0" },
+			code:   "VAL037",
+		},
+		{
+			name: "vlan name used by another interface",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.Interfaces[1].VLANs = []multinicv1alpha1.VLANSpec{{ID: 100, Name: "multinic0"}}
+			},
+			code: "VAL037",
+		},
+		{
+			name: "duplicate address",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.Interfaces[1].Addresses = []multinicv1alpha1.AddressSpec{{Address: "10.0.0.10", CIDR: "10.0.0.0/24"}}
+			},
+			code: "VAL038",
+		},
+		{
+			name:   "node name mismatch",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) { cr.Spec.NodeName = "worker-2" },
+			code:   "VAL039",
+		},
+		{
+			name: "maintenance window",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.MaintenanceWindow = &multinicv1alpha1.MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: "2h", TimeZone: "Asia/Seoul"}
+			},
+		},
+		{
+			name: "maintenance window with invalid schedule",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.MaintenanceWindow = &multinicv1alpha1.MaintenanceWindow{Schedule: "0 25 * * *", Duration: "2h"}
+			},
+			code: "VAL042",
+		},
+		{
+			name: "maintenance window with unknown time zone",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.MaintenanceWindow = &multinicv1alpha1.MaintenanceWindow{Schedule: "0 22 * * *", Duration: "2h", TimeZone: "Nowhere/City"}
+			},
+			code: "VAL043",
+		},
+		{
+			name: "disruption policy",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.DisruptionPolicy = &multinicv1alpha1.DisruptionPolicy{
+					Cordon:           true,
+					EvictPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sriov-consumer"}},
+				}
+			},
+		},
+		{
+			name: "disruption policy with invalid selector",
+			mutate: func(cr *multinicv1alpha1.MultiNicNodeConfig) {
+				cr.Spec.DisruptionPolicy = &multinicv1alpha1.DisruptionPolicy{
+					EvictPodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "In"}}},
+				}
+			},
+			code: "VAL044",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := nodeConfig(eth0, eth1)
+			tt.mutate(cr)
+			errs := ValidateNodeConfig(cr)
+			if tt.code == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tt.code)
+		})
+	}
+}
+
+func admit(t *testing.T, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	t.Helper()
+	req.UID = "req-1"
+	raw, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  req,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	NewValidationHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(raw)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var out admissionv1.AdmissionReview
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	require.NotNil(t, out.Response)
+	assert.Equal(t, "req-1", string(out.Response.UID))
+	return out.Response
+}
+
+func rawObject(t *testing.T, obj interface{}) runtime.RawExtension {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return runtime.RawExtension{Raw: raw}
+}
+
+func TestValidationHandler_DeniesWithCodes(t *testing.T) {
+	cr := nodeConfig(multinicv1alpha1.InterfaceSpec{MacAddress: "fa:16:3e:00:00:01", Address: "10.0.9.10", CIDR: "10.0.0.0/24", MTU: 1450})
+	resp := admit(t, &admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: rawObject(t, cr)})
+
+	assert.False(t, resp.Allowed)
+	require.NotNil(t, resp.Result)
+	assert.Contains(t, resp.Result.Message, "VAL015")
+	assert.Equal(t, metav1.StatusReasonInvalid, resp.Result.Reason)
+}
+
+func TestValidationHandler_AllowsUnchangedSpecUpdate(t *testing.T) {
+	old := nodeConfig(multinicv1alpha1.InterfaceSpec{MacAddress: "fa:16:3e:00:00:01", Address: "10.0.9.10", CIDR: "10.0.0.0/24", MTU: 1450})
+	cr := old.DeepCopy()
+	cr.Labels = map[string]string{"multinic.io/node-name": "worker-1"}
+
+	resp := admit(t, &admissionv1.AdmissionRequest{Operation: admissionv1.Update, Object: rawObject(t, cr), OldObject: rawObject(t, old)})
+	assert.True(t, resp.Allowed)
+}
+
+func TestValidationHandler_ValidatesV1beta1Payload(t *testing.T) {
+	beta := `{"apiVersion":"multinic.io/v1beta1","kind":"MultiNicNodeConfig","metadata":{"name":"worker-1"},
+	  "spec":{"nodeName":"worker-1","interfaces":[
+	    {"macAddress":"fa:16:3e:00:00:01","mtu":1450,"addresses":[{"address":"10.0.0.10","cidr":"10.0.0.0/24"}]},
+	    {"macAddress":"fa:16:3e:00:00:01","mtu":1450,"addresses":[{"address":"10.0.1.10","cidr":"10.0.1.0/24"}]}]}}`
+	resp := admit(t, &admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: runtime.RawExtension{Raw: []byte(beta)}})
+
+	assert.False(t, resp.Allowed)
+	assert.Contains(t, resp.Result.Message, "VAL036")
+}
@@ -0,0 +1,5 @@
+// Package multinic contains the multinic.io API group.
+package multinic
+
+// GroupName is the API group of the MultiNIC custom resources
+const GroupName = "multinic.io"
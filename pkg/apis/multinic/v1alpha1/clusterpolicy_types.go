@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MultiNicClusterPolicy supplies defaults to the MultiNicNodeConfigs of the nodes it selects
+type MultiNicClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MultiNicClusterPolicySpec `json:"spec"`
+}
+
+// MultiNicClusterPolicySpec selects nodes and the defaults merged into their node configs
+type MultiNicClusterPolicySpec struct {
+	// NodeSelector matches Node labels; an empty selector matches every node
+	NodeSelector metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// Priority orders overlapping policies: for each default the highest priority wins
+	// (ties are broken by name)
+	Priority int32          `json:"priority,omitempty"`
+	Defaults PolicyDefaults `json:"defaults"`
+	// Rollout limits how many of the selected nodes are reconfigured at once. It is not a
+	// default: the highest priority matching policy that sets it applies to the node.
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+}
+
+// RolloutPolicy spreads agent Jobs over waves instead of starting them all at once
+type RolloutPolicy struct {
+	// MaxConcurrentNodes is the number of nodes with a running agent Job (0 = unlimited)
+	MaxConcurrentNodes int32 `json:"maxConcurrentNodes,omitempty"`
+	// FailureThreshold pauses the rollout while this many node configs are Failed (0 = never)
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// PolicyDefaults fills settings that the node config leaves unset
+type PolicyDefaults struct {
+	// MTU applies to interfaces without mtu
+	MTU int32 `json:"mtu,omitempty"`
+	// InterfacePrefix replaces the controller INTERFACE_PREFIX for the node's agent Jobs
+	InterfacePrefix string `json:"interfacePrefix,omitempty"`
+	// DNS applies to interfaces without dns
+	DNS     *DNSSpec        `json:"dns,omitempty"`
+	Options *NetworkOptions `json:"options,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MultiNicClusterPolicyList is a list of MultiNicClusterPolicy
+type MultiNicClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MultiNicClusterPolicy `json:"items"`
+}
@@ -0,0 +1,219 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"multinic-agent/pkg/apis/multinic/v1beta1"
+)
+
+// ImplicitPrimaryAnnotation lists the spec.interfaces indexes whose v1alpha1 entry had no
+// address field, i.e. the first addresses entry was the primary address. v1beta1 only has
+// the addresses list, so the annotation keeps the v1alpha1 layout stable across a round trip.
+const ImplicitPrimaryAnnotation = "multinic.io/v1alpha1-implicit-primary"
+
+// ConvertTo converts this v1alpha1 object to the v1beta1 hub version
+func (src *MultiNicNodeConfig) ConvertTo(dst *v1beta1.MultiNicNodeConfig) error {
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.APIVersion = v1beta1.SchemeGroupVersion.String()
+	dst.Kind = "MultiNicNodeConfig"
+
+	dst.Spec = v1beta1.MultiNicNodeConfigSpec{
+		NodeName:   src.Spec.NodeName,
+		InstanceID: src.Spec.InstanceID,
+		Options:    (*v1beta1.NetworkOptions)(src.Spec.Options.DeepCopy()),
+
+		MaintenanceWindow: (*v1beta1.MaintenanceWindow)(src.Spec.MaintenanceWindow.DeepCopy()),
+		DisruptionPolicy:  (*v1beta1.DisruptionPolicy)(src.Spec.DisruptionPolicy.DeepCopy()),
+	}
+	var implicit []string
+	for i, in := range src.Spec.Interfaces {
+		out := v1beta1.InterfaceSpec{
+			ID:         in.ID,
+			PortID:     in.PortID,
+			Name:       in.Name,
+			MacAddress: in.MacAddress,
+			Type:       v1beta1.InterfaceType(in.Kind),
+			MTU:        in.MTU,
+			IPv4Mode:   in.IPv4Mode,
+			IPv6Mode:   in.IPv6Mode,
+			Gateway:    in.Gateway,
+		}
+		if in.Address != "" || in.CIDR != "" {
+			out.Addresses = append(out.Addresses, v1beta1.AddressSpec{Address: in.Address, CIDR: in.CIDR})
+		} else if len(in.Addresses) > 0 {
+			implicit = append(implicit, strconv.Itoa(i))
+		}
+		for _, a := range in.Addresses {
+			out.Addresses = append(out.Addresses, v1beta1.AddressSpec(a))
+		}
+		for _, r := range in.Routes {
+			out.Routes = append(out.Routes, v1beta1.RouteSpec(r))
+		}
+		if in.DNS != nil {
+			out.DNS = (*v1beta1.DNSSpec)(in.DNS.DeepCopy())
+		}
+		for _, v := range in.VLANs {
+			ov := v1beta1.VLANSpec{ID: v.ID, Name: v.Name, MTU: v.MTU}
+			if v.Address != "" || v.CIDR != "" {
+				ov.Addresses = []v1beta1.AddressSpec{{Address: v.Address, CIDR: v.CIDR}}
+			}
+			out.VLANs = append(out.VLANs, ov)
+		}
+		if in.Bond != nil {
+			out.Bond = (*v1beta1.BondSpec)(in.Bond.DeepCopy())
+		}
+		if in.Bridge != nil {
+			out.Bridge = (*v1beta1.BridgeSpec)(in.Bridge.DeepCopy())
+		}
+		if in.VRF != nil {
+			out.VRF = (*v1beta1.VRFSpec)(in.VRF.DeepCopy())
+		}
+		if in.Options != nil {
+			out.Options = (*v1beta1.NetworkOptions)(in.Options.DeepCopy())
+		}
+		dst.Spec.Interfaces = append(dst.Spec.Interfaces, out)
+	}
+	setImplicitPrimary(&dst.Annotations, implicit)
+
+	dst.Status = v1beta1.MultiNicNodeConfigStatus{
+		State:              v1beta1.NodeConfigState(src.Status.State),
+		ObservedGeneration: src.Status.ObservedGeneration,
+		ObservedSpecHash:   src.Status.ObservedSpecHash,
+		LastProcessed:      src.Status.LastProcessed.DeepCopy(),
+		LastJobName:        src.Status.LastJobName,
+		AppliedPolicies:    append([]string(nil), src.Status.AppliedPolicies...),
+		LastUpdated:        src.Status.LastUpdated.DeepCopy(),
+		LastInterfaceCheck: src.Status.LastInterfaceCheck.DeepCopy(),
+		NodeReady:          src.Status.NodeReady,
+	}
+	for _, c := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, v1beta1.Condition(*c.DeepCopy()))
+	}
+	for _, s := range src.Status.InterfaceStatuses {
+		dst.Status.InterfaceStatuses = append(dst.Status.InterfaceStatuses, v1beta1.InterfaceStatus(*s.DeepCopy()))
+	}
+	for _, a := range src.Status.AppliedInterfaces {
+		dst.Status.AppliedInterfaces = append(dst.Status.AppliedInterfaces, v1beta1.AppliedInterface(*a.DeepCopy()))
+	}
+	dst.Status.AppliedInterfacePrefix = src.Status.AppliedInterfacePrefix
+	dst.Status.AppliedOptions = (*v1beta1.NetworkOptions)(src.Status.AppliedOptions.DeepCopy())
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version into this v1alpha1 object
+func (dst *MultiNicNodeConfig) ConvertFrom(src *v1beta1.MultiNicNodeConfig) error {
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.APIVersion = SchemeGroupVersion.String()
+	dst.Kind = "MultiNicNodeConfig"
+
+	implicit := map[int]bool{}
+	for _, idx := range strings.Split(dst.Annotations[ImplicitPrimaryAnnotation], ",") {
+		if i, err := strconv.Atoi(strings.TrimSpace(idx)); err == nil {
+			implicit[i] = true
+		}
+	}
+	setImplicitPrimary(&dst.Annotations, nil)
+
+	dst.Spec = MultiNicNodeConfigSpec{
+		NodeName:   src.Spec.NodeName,
+		InstanceID: src.Spec.InstanceID,
+		Options:    (*NetworkOptions)(src.Spec.Options.DeepCopy()),
+
+		MaintenanceWindow: (*MaintenanceWindow)(src.Spec.MaintenanceWindow.DeepCopy()),
+		DisruptionPolicy:  (*DisruptionPolicy)(src.Spec.DisruptionPolicy.DeepCopy()),
+	}
+	for i, in := range src.Spec.Interfaces {
+		out := InterfaceSpec{
+			ID:         in.ID,
+			PortID:     in.PortID,
+			Name:       in.Name,
+			MacAddress: in.MacAddress,
+			Kind:       string(in.Type),
+			MTU:        in.MTU,
+			IPv4Mode:   in.IPv4Mode,
+			IPv6Mode:   in.IPv6Mode,
+			Gateway:    in.Gateway,
+		}
+		addrs := in.Addresses
+		if len(addrs) > 0 && !implicit[i] {
+			out.Address, out.CIDR = addrs[0].Address, addrs[0].CIDR
+			addrs = addrs[1:]
+		}
+		for _, a := range addrs {
+			out.Addresses = append(out.Addresses, AddressSpec(a))
+		}
+		for _, r := range in.Routes {
+			out.Routes = append(out.Routes, RouteSpec(r))
+		}
+		if in.DNS != nil {
+			out.DNS = (*DNSSpec)(in.DNS.DeepCopy())
+		}
+		for _, v := range in.VLANs {
+			ov := VLANSpec{ID: v.ID, Name: v.Name, MTU: v.MTU}
+			switch len(v.Addresses) {
+			case 0:
+			case 1:
+				ov.Address, ov.CIDR = v.Addresses[0].Address, v.Addresses[0].CIDR
+			default:
+				return fmt.Errorf("interfaces[%d] vlan %d: v1alpha1 holds a single VLAN address, got %d", i, v.ID, len(v.Addresses))
+			}
+			out.VLANs = append(out.VLANs, ov)
+		}
+		if in.Bond != nil {
+			out.Bond = (*BondSpec)(in.Bond.DeepCopy())
+		}
+		if in.Bridge != nil {
+			out.Bridge = (*BridgeSpec)(in.Bridge.DeepCopy())
+		}
+		if in.VRF != nil {
+			out.VRF = (*VRFSpec)(in.VRF.DeepCopy())
+		}
+		if in.Options != nil {
+			out.Options = (*NetworkOptions)(in.Options.DeepCopy())
+		}
+		dst.Spec.Interfaces = append(dst.Spec.Interfaces, out)
+	}
+
+	dst.Status = MultiNicNodeConfigStatus{
+		State:              NodeConfigState(src.Status.State),
+		ObservedGeneration: src.Status.ObservedGeneration,
+		ObservedSpecHash:   src.Status.ObservedSpecHash,
+		LastProcessed:      src.Status.LastProcessed.DeepCopy(),
+		LastJobName:        src.Status.LastJobName,
+		AppliedPolicies:    append([]string(nil), src.Status.AppliedPolicies...),
+		LastUpdated:        src.Status.LastUpdated.DeepCopy(),
+		LastInterfaceCheck: src.Status.LastInterfaceCheck.DeepCopy(),
+		NodeReady:          src.Status.NodeReady,
+	}
+	for _, c := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, Condition(*c.DeepCopy()))
+	}
+	for _, s := range src.Status.InterfaceStatuses {
+		dst.Status.InterfaceStatuses = append(dst.Status.InterfaceStatuses, InterfaceStatus(*s.DeepCopy()))
+	}
+	for _, a := range src.Status.AppliedInterfaces {
+		dst.Status.AppliedInterfaces = append(dst.Status.AppliedInterfaces, AppliedInterface(*a.DeepCopy()))
+	}
+	dst.Status.AppliedInterfacePrefix = src.Status.AppliedInterfacePrefix
+	dst.Status.AppliedOptions = (*NetworkOptions)(src.Status.AppliedOptions.DeepCopy())
+	return nil
+}
+
+// setImplicitPrimary writes (or, for an empty list, removes) the implicit-primary annotation
+func setImplicitPrimary(annotations *map[string]string, indexes []string) {
+	if len(indexes) == 0 {
+		if *annotations != nil {
+			delete(*annotations, ImplicitPrimaryAnnotation)
+			if len(*annotations) == 0 {
+				*annotations = nil
+			}
+		}
+		return
+	}
+	if *annotations == nil {
+		*annotations = map[string]string{}
+	}
+	(*annotations)[ImplicitPrimaryAnnotation] = strings.Join(indexes, ",")
+}
@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"multinic-agent/pkg/apis/multinic/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConversion_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   InterfaceSpec
+	}{
+		{
+			name: "primary address plus extra addresses",
+			in: InterfaceSpec{
+				MacAddress: "fa:16:3e:00:00:01",
+				Address:    "10.0.0.10",
+				CIDR:       "10.0.0.0/24",
+				Addresses:  []AddressSpec{{Address: "10.0.1.10", CIDR: "10.0.1.0/24"}},
+				Routes:     []RouteSpec{{To: "10.1.0.0/16", Via: "10.0.0.1"}},
+				VLANs:      []VLANSpec{{ID: 100, Address: "192.168.100.10", CIDR: "192.168.100.0/24"}},
+			},
+		},
+		{
+			name: "addresses only (implicit primary)",
+			in: InterfaceSpec{
+				MacAddress: "fa:16:3e:00:00:02",
+				Addresses:  []AddressSpec{{Address: "10.0.2.10", CIDR: "10.0.2.0/24"}},
+			},
+		},
+		{
+			name: "bond with bridge and vrf",
+			in: InterfaceSpec{
+				MacAddress: "fa:16:3e:00:00:03",
+				Kind:       "bond",
+				Bond:       &BondSpec{Members: []string{"fa:16:3e:00:00:04", "fa:16:3e:00:00:05"}, Mode: "active-backup"},
+				Bridge:     &BridgeSpec{Name: "br-data"},
+				VRF:        &VRFSpec{Name: "blue", Table: 1100},
+				DNS:        &DNSSpec{Servers: []string{"10.0.0.2"}},
+				IPv4Mode:   "dhcp",
+			},
+		},
+		{
+			name: "interface network options",
+			in: InterfaceSpec{
+				MacAddress: "fa:16:3e:00:00:06",
+				Address:    "10.0.3.10",
+				CIDR:       "10.0.3.0/24",
+				Options:    &NetworkOptions{RoutingTableBase: ptrInt32(2000), UseNoPrefixRoute: ptrBool(true)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &MultiNicNodeConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "multinic-system"},
+				Spec: MultiNicNodeConfigSpec{
+					NodeName:   "worker-1",
+					Interfaces: []InterfaceSpec{tt.in},
+					Options:    &NetworkOptions{PolicyRoutingEnabled: ptrBool(false)},
+
+					MaintenanceWindow: &MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: "2h", TimeZone: "Asia/Seoul"},
+					DisruptionPolicy: &DisruptionPolicy{
+						Cordon:           true,
+						EvictPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"multinic.io/evict": "true"}},
+					},
+				},
+				Status: MultiNicNodeConfigStatus{
+					State:              StateConfigured,
+					ObservedGeneration: 2,
+					AppliedInterfaces:  []AppliedInterface{{MacAddress: "fa:16:3e:00:00:01", Name: "multinic0", MTU: 1450, Addresses: []string{"10.0.0.10"}}},
+				},
+			}
+			var hub v1beta1.MultiNicNodeConfig
+			require.NoError(t, src.ConvertTo(&hub))
+
+			var back MultiNicNodeConfig
+			require.NoError(t, back.ConvertFrom(&hub))
+			back.TypeMeta = src.TypeMeta
+			assert.Equal(t, src, &back)
+		})
+	}
+}
+
+func TestConvertTo_PrimaryAddressComesFirst(t *testing.T) {
+	src := &MultiNicNodeConfig{Spec: MultiNicNodeConfigSpec{Interfaces: []InterfaceSpec{{
+		MacAddress: "fa:16:3e:00:00:01",
+		Address:    "10.0.0.10",
+		CIDR:       "10.0.0.0/24",
+		Addresses:  []AddressSpec{{Address: "10.0.1.10", CIDR: "10.0.1.0/24"}},
+		Kind:       "bond",
+	}}}}
+	var hub v1beta1.MultiNicNodeConfig
+	require.NoError(t, src.ConvertTo(&hub))
+
+	iface := hub.Spec.Interfaces[0]
+	assert.Equal(t, v1beta1.InterfaceTypeBond, iface.Type)
+	require.Len(t, iface.Addresses, 2)
+	assert.Equal(t, "10.0.0.10", iface.Addresses[0].Address)
+	assert.Empty(t, hub.Annotations)
+}
+
+func TestConvertFrom_RejectsMultipleVLANAddresses(t *testing.T) {
+	hub := &v1beta1.MultiNicNodeConfig{Spec: v1beta1.MultiNicNodeConfigSpec{Interfaces: []v1beta1.InterfaceSpec{{
+		MacAddress: "fa:16:3e:00:00:01",
+		VLANs: []v1beta1.VLANSpec{{ID: 100, Addresses: []v1beta1.AddressSpec{
+			{Address: "192.168.100.10", CIDR: "192.168.100.0/24"},
+			{Address: "192.168.101.10", CIDR: "192.168.101.0/24"},
+		}}},
+	}}}}
+	var dst MultiNicNodeConfig
+	err := dst.ConvertFrom(hub)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vlan 100")
+}
+
+func ptrBool(v bool) *bool { return &v }
+
+func ptrInt32(v int32) *int32 { return &v }
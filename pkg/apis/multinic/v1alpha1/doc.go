@@ -0,0 +1,6 @@
+// Package v1alpha1 contains the multinic.io/v1alpha1 API types (MultiNicNodeConfig).
+// The CRD schema lives in deployments/crds; keep both in sync when adding fields.
+//
+// +k8s:deepcopy-gen=package
+// +groupName=multinic.io
+package v1alpha1
@@ -0,0 +1,233 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MultiNicNodeConfig is the node-scoped interface configuration applied by the agent Job
+type MultiNicNodeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MultiNicNodeConfigSpec   `json:"spec"`
+	Status MultiNicNodeConfigStatus `json:"status,omitempty"`
+}
+
+// MultiNicNodeConfigSpec is the desired state for the target node
+type MultiNicNodeConfigSpec struct {
+	// NodeName is the Kubernetes node this config targets (defaults to metadata.name)
+	NodeName string `json:"nodeName"`
+	// InstanceID is the OpenStack instance UUID (equals the node SystemUUID)
+	InstanceID string          `json:"instanceId,omitempty"`
+	Interfaces []InterfaceSpec `json:"interfaces"`
+	// Options override the agent network options for this node
+	Options *NetworkOptions `json:"options,omitempty"`
+	// MaintenanceWindow restricts new agent Jobs to a recurring window (nil = any time)
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// DisruptionPolicy cordons/drains the node around changes that may interrupt traffic (nil = off)
+	DisruptionPolicy *DisruptionPolicy `json:"disruptionPolicy,omitempty"`
+}
+
+// MaintenanceWindow is a recurring window in which the controller may reconfigure the node
+type MaintenanceWindow struct {
+	// Schedule is a 5-field cron expression (minute hour day-of-month month day-of-week)
+	// for the moments the window opens
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open (Go duration, e.g. "2h")
+	Duration string `json:"duration"`
+	// TimeZone is the IANA zone of the schedule (default UTC)
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// DisruptionPolicy protects the node's workloads while a disruptive change (rename of a
+// configured link, MTU decrease, address change or removal) is applied
+type DisruptionPolicy struct {
+	// Cordon marks the node unschedulable until the agent Job succeeded
+	Cordon bool `json:"cordon,omitempty"`
+	// EvictPodSelector selects the pods on the node that are evicted before the Job starts
+	// (DaemonSet and mirror pods are skipped)
+	EvictPodSelector *metav1.LabelSelector `json:"evictPodSelector,omitempty"`
+}
+
+// InterfaceSpec is one entry of spec.interfaces
+type InterfaceSpec struct {
+	// ID is the optional interface order identifier (0 = unset)
+	ID     int32  `json:"id,omitempty"`
+	PortID string `json:"portId,omitempty"`
+	// Name is the optional desired interface name (<prefix>N)
+	Name       string `json:"name,omitempty"`
+	MacAddress string `json:"macAddress"`
+	Address    string `json:"address,omitempty"`
+	CIDR       string `json:"cidr,omitempty"`
+	// IPv4Mode/IPv6Mode: static (default) | dhcp | disabled
+	IPv4Mode  string        `json:"ipv4Mode,omitempty"`
+	IPv6Mode  string        `json:"ipv6Mode,omitempty"`
+	DNS       *DNSSpec      `json:"dns,omitempty"`
+	Addresses []AddressSpec `json:"addresses,omitempty"`
+	Routes    []RouteSpec   `json:"routes,omitempty"`
+	Gateway   string        `json:"gateway,omitempty"`
+	VLANs     []VLANSpec    `json:"vlans,omitempty"`
+	// Kind is ethernet (default) or bond
+	Kind   string      `json:"kind,omitempty"`
+	Bond   *BondSpec   `json:"bond,omitempty"`
+	Bridge *BridgeSpec `json:"bridge,omitempty"`
+	VRF    *VRFSpec    `json:"vrf,omitempty"`
+	MTU    int32       `json:"mtu,omitempty"`
+	// Options override spec.options for this interface only
+	Options *NetworkOptions `json:"options,omitempty"`
+}
+
+// DNSSpec lists the resolvers contributed by an interface
+type DNSSpec struct {
+	Servers []string `json:"servers,omitempty"`
+	Search  []string `json:"search,omitempty"`
+}
+
+// AddressSpec is an additional address of an interface
+type AddressSpec struct {
+	Address string `json:"address"`
+	CIDR    string `json:"cidr"`
+}
+
+// RouteSpec is a static route reachable through an interface
+type RouteSpec struct {
+	To     string `json:"to"`
+	Via    string `json:"via,omitempty"`
+	Metric int32  `json:"metric,omitempty"`
+	Table  int32  `json:"table,omitempty"`
+	OnLink bool   `json:"onlink,omitempty"`
+}
+
+// VLANSpec is an 802.1Q sub-interface created on top of an interface
+type VLANSpec struct {
+	ID      int32  `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+	CIDR    string `json:"cidr,omitempty"`
+	MTU     int32  `json:"mtu,omitempty"`
+}
+
+// BondSpec holds the bond settings of a kind: bond interface
+type BondSpec struct {
+	// Members are the MAC addresses of the member ports
+	Members        []string `json:"members"`
+	Mode           string   `json:"mode,omitempty"`
+	MIIMon         int32    `json:"miimon,omitempty"`
+	XmitHashPolicy string   `json:"xmitHashPolicy,omitempty"`
+}
+
+// BridgeSpec makes the interface a port of a Linux bridge
+type BridgeSpec struct {
+	Name string `json:"name"`
+	STP  bool   `json:"stp,omitempty"`
+	// MoveIP defaults to true: addresses and routes are configured on the bridge
+	MoveIP *bool `json:"moveIP,omitempty"`
+}
+
+// VRFSpec places the interface in a VRF routing domain
+type VRFSpec struct {
+	Name string `json:"name"`
+	// Table defaults to the routing table base + interface index
+	Table int32 `json:"table,omitempty"`
+}
+
+// NetworkOptions are the agent network handling options (nil = agent default)
+type NetworkOptions struct {
+	PolicyRoutingEnabled *bool  `json:"policyRoutingEnabled,omitempty"`
+	RoutingTableBase     *int32 `json:"routingTableBase,omitempty"`
+	RouteMetric          *int32 `json:"routeMetric,omitempty"`
+	UseNoPrefixRoute     *bool  `json:"useNoPrefixRoute,omitempty"`
+	SetArpSysctls        *bool  `json:"setArpSysctls,omitempty"`
+	SetLooseRPFilter     *bool  `json:"setLooseRPFilter,omitempty"`
+}
+
+// NodeConfigState is the overall state reported in status.state
+type NodeConfigState string
+
+const (
+	StatePending    NodeConfigState = "Pending"
+	StateInProgress NodeConfigState = "InProgress"
+	StateConfigured NodeConfigState = "Configured"
+	StateFailed     NodeConfigState = "Failed"
+	// StateDrifted: a resync found the node no longer matching the last applied config
+	StateDrifted NodeConfigState = "Drifted"
+)
+
+// DriftReportAnnotation carries the last verify result the DaemonSet agent wrote for its node;
+// the controller reads it when drift is sourced from the DaemonSet.
+const DriftReportAnnotation = "multinic.io/drift-report"
+
+// MultiNicNodeConfigStatus is the controller-managed status
+type MultiNicNodeConfigStatus struct {
+	State              NodeConfigState `json:"state,omitempty"`
+	ObservedGeneration int64           `json:"observedGeneration,omitempty"`
+	ObservedSpecHash   string          `json:"observedSpecHash,omitempty"`
+	LastProcessed      *metav1.Time    `json:"lastProcessed,omitempty"`
+	LastJobName        string          `json:"lastJobName,omitempty"`
+	// AppliedPolicies lists the MultiNicClusterPolicies merged into the last scheduled Job
+	AppliedPolicies []string    `json:"appliedPolicies,omitempty"`
+	Conditions      []Condition `json:"conditions,omitempty"`
+	// InterfaceStatuses holds one entry per spec interface
+	InterfaceStatuses  []InterfaceStatus `json:"interfaceStatuses,omitempty"`
+	LastUpdated        *metav1.Time      `json:"lastUpdated,omitempty"`
+	LastInterfaceCheck *metav1.Time      `json:"lastInterfaceCheck,omitempty"`
+	NodeReady          bool              `json:"nodeReady,omitempty"`
+
+	// AppliedInterfaces records what the last successful agent Job configured
+	AppliedInterfaces []AppliedInterface `json:"appliedInterfaces,omitempty"`
+
+	// AppliedInterfacePrefix and AppliedOptions are the interface name prefix and agent
+	// options the last scheduled Job ran with; the cleanup Job reuses them, so that a policy
+	// changed or deleted since then does not orphan interfaces
+	AppliedInterfacePrefix string          `json:"appliedInterfacePrefix,omitempty"`
+	AppliedOptions         *NetworkOptions `json:"appliedOptions,omitempty"`
+}
+
+// AppliedInterface is one interface as configured by the last successful agent Job
+type AppliedInterface struct {
+	MacAddress string   `json:"macAddress"`
+	Name       string   `json:"name,omitempty"`
+	MTU        int32    `json:"mtu,omitempty"`
+	Addresses  []string `json:"addresses,omitempty"`
+}
+
+// Condition is a status condition (Ready, InProgress)
+type Condition struct {
+	Type               string       `json:"type"`
+	Status             string       `json:"status"`
+	Reason             string       `json:"reason,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// InterfaceStatus is the per-interface configuration result
+type InterfaceStatus struct {
+	// Name is the interface name on the node (multinic0, multinic1, ...)
+	Name           string       `json:"name,omitempty"`
+	InterfaceIndex int64        `json:"interfaceIndex"`
+	ID             int64        `json:"id,omitempty"`
+	MacAddress     string       `json:"macAddress,omitempty"`
+	Address        string       `json:"address,omitempty"`
+	CIDR           string       `json:"cidr,omitempty"`
+	MTU            int64        `json:"mtu,omitempty"`
+	Status         string       `json:"status,omitempty"`
+	Reason         string       `json:"reason,omitempty"`
+	Message        string       `json:"message,omitempty"`
+	ActualState    string       `json:"actualState,omitempty"`
+	LastUpdated    *metav1.Time `json:"lastUpdated,omitempty"`
+	LastChecked    *metav1.Time `json:"lastChecked,omitempty"`
+	LastConfigured *metav1.Time `json:"lastConfigured,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MultiNicNodeConfigList is a list of MultiNicNodeConfig
+type MultiNicNodeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MultiNicNodeConfig `json:"items"`
+}
@@ -0,0 +1,7 @@
+// Package v1beta1 contains the multinic.io/v1beta1 API types (MultiNicNodeConfig).
+// v1beta1 is the storage version; v1alpha1 objects are converted by the controller
+// conversion webhook (see pkg/apis/multinic/v1alpha1/conversion.go).
+//
+// +k8s:deepcopy-gen=package
+// +groupName=multinic.io
+package v1beta1
@@ -315,6 +315,14 @@ else
     exit 1
 fi
 
+# 클러스터 정책 CRD (helm upgrade는 새 CRD를 설치하지 않으므로 함께 적용)
+POLICY_CRD_FILE="deployments/crds/multiniclusterpolicy-crd.yaml"
+if kubectl apply -f "$POLICY_CRD_FILE"; then
+    echo -e "${GREEN}✓ MultiNicClusterPolicy CRD 배포 완료${NC}"
+else
+    echo -e "${YELLOW}⚠ MultiNicClusterPolicy CRD 적용 실패 - 클러스터 정책 없이 계속합니다${NC}"
+fi
+
 
 # registry 인증이 필요한 경우 imagePullSecret 생성
 HELM_EXTRA_ARGS=""